@@ -3,6 +3,7 @@ package api
 import (
 	"siddu-verse-backend/internal/handlers"
 	"siddu-verse-backend/internal/middleware"
+	"siddu-verse-backend/internal/storage"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -16,6 +17,11 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB) {
 	// Create a new handler instance with the database connection
 	h := handlers.NewBaseHandler(db)
 
+	// Serve uploaded files when they are stored on the local filesystem
+	if local, ok := h.Storage.(*storage.LocalStorage); ok {
+		router.Static(local.URLPath, local.Dir)
+	}
+
 	// Group API routes under /api
 	apiGroup := router.Group("/api")
 	{
@@ -33,16 +39,48 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB) {
 		apiGroup.GET("/awards/:id", h.GetAwardByID)
 		apiGroup.GET("/cricket/matches", h.GetCricketMatches)
 		apiGroup.GET("/cricket/matches/:id", h.GetCricketMatchByID)
-		apiGroup.GET("/talent/profiles", h.GetTalentProfiles)
-		apiGroup.GET("/talent/profiles/:id", h.GetTalentProfileByID)
-		apiGroup.GET("/talent/casting-calls", h.GetCastingCalls)
-		apiGroup.GET("/talent/casting-calls/:id", h.GetCastingCallByID)
-		apiGroup.GET("/pulses", h.GetPulses)
-		apiGroup.GET("/users/:id", h.GetUserByID) // Public user profile
+		apiGroup.GET("/talent/profiles", middleware.OptionalAuthMiddleware(db), h.GetTalentProfiles)
+		apiGroup.GET("/talent/profiles/:id", middleware.OptionalAuthMiddleware(db), h.GetTalentProfileByID)
+		apiGroup.GET("/talent/profiles/:id/availability.ics", middleware.OptionalAuthMiddleware(db), h.ExportAvailabilityICS)
+		apiGroup.GET("/talent/profiles/:id/resume", middleware.OptionalAuthMiddleware(db), h.GetTalentProfileResume)
+		apiGroup.GET("/talent/resume-templates", h.GetResumeTemplates)
+		apiGroup.GET("/talent/profiles/:id/skills/:skill_id/endorsements", middleware.OptionalAuthMiddleware(db), h.GetSkillEndorsements)
+		apiGroup.GET("/talent/skills", h.GetSkillTaxonomy)
+		apiGroup.GET("/agencies/:id", middleware.OptionalAuthMiddleware(db), h.GetAgencyByID)
+		apiGroup.POST("/saved-searches/unsubscribe/:token", h.UnsubscribeSavedSearch)
+		apiGroup.GET("/talent/casting-calls", middleware.OptionalAuthMiddleware(db), h.GetCastingCalls)
+		apiGroup.GET("/talent/casting-calls/:id", middleware.OptionalAuthMiddleware(db), h.GetCastingCallByID)
+		apiGroup.GET("/pulses", middleware.OptionalAuthMiddleware(db), h.GetPulses)
+		apiGroup.GET("/pulses/:id", middleware.OptionalAuthMiddleware(db), h.GetPulseByID)
+		apiGroup.GET("/pulses/:id/edits", middleware.OptionalAuthMiddleware(db), h.GetPulseEdits)
+		apiGroup.GET("/pulses/:id/quotes", middleware.OptionalAuthMiddleware(db), h.GetPulseQuotes)
+		apiGroup.GET("/pulses/:id/poll", middleware.OptionalAuthMiddleware(db), h.GetPoll)
+		apiGroup.GET("/pulses/:id/poll/stream", middleware.OptionalAuthMiddleware(db), h.StreamPollResults) // Live results (Server-Sent Events)
+		apiGroup.GET("/hashtags/trending", h.GetTrendingHashtags)
+		apiGroup.GET("/hashtags/:tag/pulses", middleware.OptionalAuthMiddleware(db), h.GetHashtagPulses)
+		apiGroup.GET("/comments/:id/replies", middleware.OptionalAuthMiddleware(db), h.GetCommentReplies)
+		apiGroup.GET("/movies/:id/reviews", h.GetMovieReviews)
+		apiGroup.GET("/reviews/:id", h.GetReviewByID)
+		apiGroup.GET("/users/:id", middleware.OptionalAuthMiddleware(db), h.GetUserByID) // Public user profile
+		apiGroup.GET("/users/:id/followers", middleware.OptionalAuthMiddleware(db), h.GetUserFollowers)
+		apiGroup.GET("/users/:id/following", middleware.OptionalAuthMiddleware(db), h.GetUserFollowing)
+		apiGroup.GET("/talent/profiles/:id/followers", middleware.OptionalAuthMiddleware(db), h.GetTalentProfileFollowers)
+
+		// Moderation: reason codes and the public log of moderation decisions
+		apiGroup.GET("/moderation/reasons", h.GetReportReasons)
+		apiGroup.GET("/moderation/transparency", h.GetTransparencyLog)
+
+		// Account standing and appeals stay open to suspended and banned users
+		account := apiGroup.Group("/moderation")
+		account.Use(middleware.AccountMiddleware())
+		{
+			account.GET("/status", h.GetModerationStatus)
+			account.POST("/appeals", h.CreateAppeal)
+		}
 
 		// --- Protected Routes ---
 		authed := apiGroup.Group("/")
-		authed.Use(middleware.AuthMiddleware())
+		authed.Use(middleware.AuthMiddleware(db))
 		{
 			// Protected Award routes
 			authed.POST("/awards", h.CreateAward)
@@ -51,6 +89,7 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB) {
 
 			// Protected Movie routes
 			authed.POST("/movies", h.CreateMovie)
+			authed.POST("/movies/:id/reviews", h.CreateMovieReview)
 
 			// Protected Talent Hub routes
 			talent := authed.Group("/talent")
@@ -64,6 +103,8 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB) {
 					// Skills Management
 					profiles.POST("/:id/skills", h.AddSkillToProfile)
 					profiles.DELETE("/:id/skills/:skill_id", h.RemoveSkillFromProfile)
+					profiles.POST("/:id/skills/:skill_id/endorsements", h.EndorseSkill)
+					profiles.DELETE("/:id/skills/:skill_id/endorsements", h.RemoveSkillEndorsement)
 
 					// Experience Management
 					profiles.POST("/:id/experience", h.AddExperienceToProfile)
@@ -73,19 +114,93 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB) {
 					// Portfolio Management
 					profiles.POST("/:id/portfolio", h.AddPortfolioItem)
 					profiles.DELETE("/:id/portfolio/:item_id", h.RemovePortfolioItem)
+					profiles.PUT("/:id/portfolio/order", h.ReorderPortfolio)
+					profiles.POST("/:id/portfolio/uploads", h.UploadPortfolioMedia)
+					profiles.POST("/:id/portfolio/upload-sessions", h.CreatePortfolioUpload)
+					profiles.GET("/:id/portfolio/upload-sessions/:upload_id", h.GetPortfolioUpload)
+					profiles.PUT("/:id/portfolio/upload-sessions/:upload_id", h.UploadPortfolioChunk)
+					profiles.DELETE("/:id/portfolio/upload-sessions/:upload_id", h.CancelPortfolioUpload)
 
 					// Get applications submitted by this profile
 					profiles.GET("/:id/applications", h.GetMyApplications)
+
+					// Availability Calendar
+					profiles.GET("/:id/availability", h.GetAvailability)
+					profiles.POST("/:id/availability", h.AddUnavailability)
+					profiles.DELETE("/:id/availability/:entry_id", h.RemoveAvailabilityEntry)
+					profiles.POST("/:id/holds", h.PlaceHold)
+					profiles.POST("/:id/holds/:entry_id/confirm", h.ConfirmHold)
+
+					// Verification
+					profiles.POST("/:id/verification", h.SubmitVerificationRequest)
+					profiles.GET("/:id/verification", h.GetVerificationRequests)
+
+					// Résumé import
+					profiles.POST("/:id/resume/import", h.ImportJSONResume)
+
+					// Agency representation
+					profiles.GET("/:id/representation", h.GetMyRepresentation)
+					profiles.POST("/:id/representation/:rep_id/accept", h.AcceptRepresentation)
+					profiles.POST("/:id/representation/:rep_id/decline", h.DeclineRepresentation)
+					profiles.DELETE("/:id/representation/:rep_id", h.EndMyRepresentation)
+
+					// Followers
+					profiles.POST("/:id/follow", h.FollowTalentProfile)
+					profiles.DELETE("/:id/follow", h.UnfollowTalentProfile)
+
+					// View analytics
+					profiles.GET("/:id/analytics/views", h.GetProfileViewSeries)
+					profiles.GET("/:id/analytics/breakdown", h.GetProfileViewBreakdown)
+
+					// Casting calls matched to this profile
+					profiles.GET("/:id/suggested-calls", h.GetSuggestedCallsForProfile)
 				}
 
+				// Talent free over a date range
+				talent.GET("/availability", h.FindAvailableTalent)
+
 				// Casting Call Management
 				casting := talent.Group("/casting-calls")
 				{
 					casting.POST("", h.CreateCastingCall)
+					casting.PUT("/:id", h.UpdateCastingCall)
+
+					// Lifecycle
+					casting.POST("/:id/publish", h.PublishCastingCall)
+					casting.POST("/:id/close", h.CloseCastingCall)
+					casting.POST("/:id/reopen", h.ReopenCastingCall)
+					casting.POST("/:id/extend", h.ExtendCastingCallDeadline)
+					casting.POST("/:id/archive", h.ArchiveCastingCall)
+
+					// Roles and talent matching
+					casting.POST("/:id/roles", h.AddCastingCallRole)
+					casting.PUT("/:id/roles/:role_id", h.UpdateCastingCallRole)
+					casting.DELETE("/:id/roles/:role_id", h.RemoveCastingCallRole)
+					casting.GET("/:id/roles/:role_id/suggested-talent", h.GetSuggestedTalentForRole)
+
 					// Apply to a casting call
 					casting.POST("/:id/apply", h.ApplyToCastingCall)
 					// Get applications for a specific casting call (for recruiter)
 					casting.GET("/:id/applications", h.GetApplicationsForCastingCall)
+					casting.POST("/:id/applications/bulk-status", h.BulkUpdateApplicationStatus)
+					casting.GET("/:id/applications/export.csv", h.ExportShortlistCSV)
+
+					// View analytics
+					casting.GET("/:id/analytics/views", h.GetCastingCallViewSeries)
+					casting.GET("/:id/analytics/breakdown", h.GetCastingCallViewBreakdown)
+
+					// Team Management
+					casting.GET("/:id/members", h.GetCastingCallMembers)
+					casting.PUT("/:id/members/:user_id", h.UpdateCastingCallMemberRole)
+					casting.DELETE("/:id/members/:user_id", h.RemoveCastingCallMember)
+					casting.GET("/:id/invitations", h.GetCastingCallInvitations)
+					casting.POST("/:id/invitations", h.InviteCastingCallMember)
+					casting.DELETE("/:id/invitations/:invitation_id", h.RevokeCastingCallInvitation)
+
+					// Invitations addressed to the authenticated user
+					casting.GET("/invitations", h.GetMyCastingCallInvitations)
+					casting.POST("/invitations/:token/accept", h.AcceptCastingCallInvitation)
+					casting.POST("/invitations/:token/decline", h.DeclineCastingCallInvitation)
 				}
 
 				// Direct Application Management (for recruiter or applicant)
@@ -93,15 +208,162 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB) {
 				{
 					applications.GET("/:app_id", h.GetApplicationByID)
 					applications.PUT("/:app_id", h.UpdateApplicationStatus)
+					applications.GET("/:app_id/history", h.GetApplicationHistory)
+
+					// Recruiter review tools
+					applications.GET("/:app_id/notes", h.GetApplicationNotes)
+					applications.POST("/:app_id/notes", h.AddApplicationNote)
+					applications.DELETE("/:app_id/notes/:note_id", h.DeleteApplicationNote)
+					applications.PUT("/:app_id/rating", h.RateApplication)
+					applications.POST("/:app_id/tags", h.AddApplicationTag)
+					applications.DELETE("/:app_id/tags/:tag", h.RemoveApplicationTag)
 				}
 			}
 
+			// Saved Searches and Alerts
+			savedSearches := authed.Group("/saved-searches")
+			{
+				savedSearches.GET("", h.GetSavedSearches)
+				savedSearches.POST("", h.CreateSavedSearch)
+				savedSearches.PUT("/:id", h.UpdateSavedSearch)
+				savedSearches.DELETE("/:id", h.DeleteSavedSearch)
+				savedSearches.GET("/:id/matches", h.GetSavedSearchMatches)
+			}
+
+			// Talent Agencies
+			agencies := authed.Group("/agencies")
+			{
+				agencies.POST("", h.CreateAgency)
+				agencies.GET("/mine", h.GetMyAgencies)
+				agencies.PUT("/:id", h.UpdateAgency)
+
+				agencies.POST("/:id/members", h.AddAgencyMember)
+				agencies.PUT("/:id/members/:member_id", h.UpdateAgencyMember)
+				agencies.DELETE("/:id/members/:member_id", h.RemoveAgencyMember)
+
+				agencies.GET("/:id/roster", h.GetAgencyRoster)
+				agencies.POST("/:id/roster", h.RequestRepresentation)
+				agencies.DELETE("/:id/roster/:rep_id", h.RemoveFromRoster)
+
+				agencies.GET("/:id/applications", h.GetAgencyApplications)
+				agencies.POST("/:id/applications", h.ApplyOnBehalf)
+			}
+
+			// Admin routes
+			admin := authed.Group("/admin")
+			admin.Use(middleware.AdminMiddleware(db))
+			{
+				admin.GET("/verification-requests", h.GetVerificationQueue)
+				admin.POST("/verification-requests/:request_id/approve", h.ApproveVerificationRequest)
+				admin.POST("/verification-requests/:request_id/reject", h.RejectVerificationRequest)
+				admin.POST("/talent/profiles/:id/revoke-verification", h.RevokeVerification)
+
+				// Skill taxonomy curation
+				admin.POST("/skill-categories", h.CreateSkillCategory)
+				admin.POST("/skills", h.CreateTaxonomySkill)
+				admin.GET("/skills/unmapped", h.GetUnmappedSkills)
+				admin.POST("/skills/:skill_id/aliases", h.AddSkillAlias)
+				admin.DELETE("/skills/:skill_id/aliases/:alias_id", h.RemoveSkillAlias)
+				admin.POST("/skills/:skill_id/merge", h.MergeSkills)
+
+				// Moderation queue, enforcement and appeals
+				admin.GET("/moderation/queue", h.GetModerationQueue)
+				admin.GET("/moderation/reports", h.GetSubjectReports)
+				admin.PUT("/moderation/reports/:report_id", h.TriageReport)
+				admin.POST("/moderation/actions", h.TakeModerationAction)
+				admin.GET("/moderation/appeals", h.GetAppealQueue)
+				admin.POST("/moderation/appeals/:appeal_id/grant", h.GrantAppeal)
+				admin.POST("/moderation/appeals/:appeal_id/deny", h.DenyAppeal)
+				admin.GET("/moderation/screening", h.GetScreeningQueue)
+			}
+
+			// Direct Messaging
+			conversations := authed.Group("/conversations")
+			{
+				conversations.GET("", h.GetConversations)
+				conversations.POST("", h.StartConversation)
+				conversations.GET("/:id", h.GetConversationByID)
+				conversations.GET("/:id/messages", h.GetConversationMessages)
+				conversations.POST("/:id/messages", h.SendMessage)
+				conversations.POST("/:id/read", h.MarkConversationRead)
+			}
+
+			// Real-time events (Server-Sent Events)
+			authed.GET("/events/stream", h.StreamEvents)
+
+			// Reporting content and accounts to moderators
+			authed.POST("/reports", h.CreateReport)
+
+			// Follow graph
+			authed.POST("/users/:id/follow", h.FollowUser)
+			authed.DELETE("/users/:id/follow", h.UnfollowUser)
+
+			// Notification inbox, preferences and web push subscriptions
+			authed.GET("/notifications", h.GetNotifications)
+			authed.POST("/notifications/read-all", h.MarkAllNotificationsRead)
+			authed.PUT("/notifications/:id/read", h.MarkNotificationRead)
+			authed.DELETE("/notifications/:id/read", h.MarkNotificationUnread)
+			authed.GET("/notifications/preferences", h.GetNotificationPreferences)
+			authed.PUT("/notifications/preferences", h.UpdateNotificationPreferences)
+			authed.POST("/notifications/push/subscriptions", h.SubscribePush)
+			authed.DELETE("/notifications/push/subscriptions/:id", h.UnsubscribePush)
+
+			// Blocking and muting
+			authed.POST("/users/:id/block", h.BlockUser)
+			authed.DELETE("/users/:id/block", h.UnblockUser)
+			authed.GET("/blocks", h.GetBlockedUsers)
+			authed.POST("/users/:id/mute", h.MuteUser)
+			authed.DELETE("/users/:id/mute", h.UnmuteUser)
+			authed.GET("/mutes", h.GetMutedUsers)
+
 			// Protected Social Pulse routes
 			pulses := authed.Group("/pulses")
 			{
 				pulses.POST("", h.CreatePulse)
+				pulses.GET("/feed", h.GetPulseFeed)
 				pulses.POST("/:id/like", h.LikePulse)
 				pulses.POST("/:id/comment", h.CommentOnPulse)
+				pulses.PUT("/:id", h.UpdatePulse)
+				pulses.DELETE("/:id", h.DeletePulse)
+				pulses.POST("/:id/repost", h.RepostPulse)
+				pulses.DELETE("/:id/repost", h.UndoRepost)
+				pulses.POST("/:id/quote", h.QuotePulse)
+				pulses.POST("/:id/poll/votes", h.VoteInPoll)
+				pulses.POST("/:id/bookmark", h.BookmarkPulse)
+				pulses.DELETE("/:id/bookmark", h.RemoveBookmark)
+			}
+
+			// Private bookmarks and their folders
+			bookmarks := authed.Group("/bookmarks")
+			{
+				bookmarks.GET("", h.GetBookmarks)
+				bookmarks.GET("/folders", h.GetBookmarkFolders)
+				bookmarks.POST("/folders", h.CreateBookmarkFolder)
+				bookmarks.PUT("/folders/:id", h.RenameBookmarkFolder)
+				bookmarks.DELETE("/folders/:id", h.DeleteBookmarkFolder)
+			}
+
+			comments := authed.Group("/comments")
+			{
+				comments.PUT("/:id", h.UpdateComment)
+				comments.DELETE("/:id", h.DeleteComment)
+				comments.POST("/:id/like", h.LikeComment)
+			}
+
+			// Comments and reactions, mounted for each content type in the handlers' registry
+			for _, item := range []struct{ path, ownerType string }{
+				{"/pulses/:id", "pulses"},
+				{"/movies/:id", "movies"},
+				{"/reviews/:id", "reviews"},
+				{"/talent/casting-calls/:id", "casting_calls"},
+				{"/awards/:id", "awards"},
+				{"/cricket/matches/:id", "cricket_matches"},
+			} {
+				apiGroup.GET(item.path+"/comments", middleware.OptionalAuthMiddleware(db), h.GetComments(item.ownerType))
+				apiGroup.GET(item.path+"/reactions", middleware.OptionalAuthMiddleware(db), h.GetReactions(item.ownerType))
+				authed.POST(item.path+"/comments", h.AddComment(item.ownerType))
+				authed.PUT(item.path+"/reactions", h.SetReaction(item.ownerType))
+				authed.DELETE(item.path+"/reactions", h.RemoveReaction(item.ownerType))
 			}
 		}
 	}
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"siddu-verse-backend/api"
 	"siddu-verse-backend/database"
+	"siddu-verse-backend/internal/jobs"
+	"siddu-verse-backend/internal/mailer"
+	"siddu-verse-backend/internal/storage"
+	"siddu-verse-backend/internal/webpush"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +21,9 @@ func main() {
 	}
 	log.Println("Database connection successful and schema migrated.")
 
+	// Start background jobs (e.g. closing casting calls past their deadline)
+	jobs.Start(context.Background(), db, jobs.DefaultTasks(mailer.NewFromEnv(), webpush.NewFromEnv(), storage.NewFromEnv())...)
+
 	// Initialize Gin router
 	router := gin.Default()
 
@@ -24,27 +24,116 @@ func Initialize() (*gorm.DB, error) {
 		return nil, err
 	}
 
+	if err := dedupeApplicationTags(db); err != nil {
+		return nil, err
+	}
+
 	// AutoMigrate will create or update the database schema
 	// based on the models defined in the models package.
 	err = db.AutoMigrate(
 		&models.User{},
 		&models.TalentProfile{},
+		&models.VerificationRequest{},
+		&models.VerificationEvidence{},
 		&models.Skill{},
+		&models.SkillEndorsement{},
+		&models.SkillCategory{},
+		&models.TaxonomySkill{},
+		&models.SkillAlias{},
 		&models.Experience{},
 		&models.PortfolioItem{},
+		&models.PortfolioThumbnail{},
+		&models.PortfolioUpload{},
+		&models.PortfolioUploadPart{},
+		&models.AvailabilityEntry{},
 		&models.CastingCall{},
 		&models.CastingCallRole{},
 		&models.Application{},
+		&models.CastingCallMember{},
+		&models.CastingCallInvitation{},
+		&models.ApplicationNote{},
+		&models.ApplicationRating{},
+		&models.ApplicationTag{},
+		&models.ApplicationEvent{},
+		&models.Agency{},
+		&models.AgencyMember{},
+		&models.AgencyRepresentation{},
 		&models.Pulse{},
+		&models.PulseEdit{},
+		&models.Poll{},
+		&models.PollOption{},
+		&models.PollVote{},
+		&models.Bookmark{},
+		&models.BookmarkFolder{},
+		&models.PulseLink{},
+		&models.LinkPreview{},
+		&models.Follow{},
+		&models.FeedEntry{},
+		&models.Block{},
+		&models.Mute{},
+		&models.Hashtag{},
+		&models.PulseHashtag{},
+		&models.PulseMention{},
+		&models.PulseReference{},
 		&models.Comment{},
 		&models.Like{},
+		&models.Conversation{},
+		&models.ConversationParticipant{},
+		&models.Message{},
+		&models.MessageAttachment{},
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.NotificationDelivery{},
+		&models.PushSubscription{},
+		&models.SavedSearch{},
+		&models.SavedSearchMatch{},
+		&models.JobCheckpoint{},
+		&models.ViewEvent{},
+		&models.DailyViewStat{},
+		&models.DailyReferrerStat{},
+		&models.SubjectViewer{},
 		&models.Movie{},
+		&models.Review{},
 		&models.Award{},
 		&models.CricketMatch{},
+		&models.Report{},
+		&models.ModerationAction{},
+		&models.Appeal{},
+		&models.ScreeningResult{},
 	)
 	if err != nil {
 		return nil, err
 	}
+	if err := migrateCastingCallStatus(db); err != nil {
+		return nil, err
+	}
 
 	return db, nil
 }
+
+// migrateCastingCallStatus moves the is_active flag casting calls had before their lifecycle
+// into status: inactive calls are closed. The flag is dropped once moved, so this runs once.
+func migrateCastingCallStatus(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.CastingCall{}, "is_active") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CastingCall{}).
+			Where("is_active = ? AND status = ?", false, models.CastingStatusPublished).
+			Updates(map[string]interface{}{"status": models.CastingStatusClosed, "closed_at": gorm.Expr("COALESCE(closed_at, updated_at)")}).Error; err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&models.CastingCall{}, "is_active")
+	})
+}
+
+// dedupeApplicationTags clears out removed and repeated application tags, which the unique
+// index on application and tag name doesn't allow, before the index is created.
+func dedupeApplicationTags(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.ApplicationTag{}) || db.Migrator().HasIndex(&models.ApplicationTag{}, "idx_application_tag") {
+		return nil
+	}
+	return db.Exec(`DELETE FROM application_tags t WHERE t.deleted_at IS NOT NULL OR EXISTS (
+		SELECT 1 FROM application_tags d
+		WHERE d.application_id = t.application_id AND d.name = t.name AND d.deleted_at IS NULL AND d.id < t.id)`).Error
+}
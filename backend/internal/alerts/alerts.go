@@ -0,0 +1,119 @@
+package alerts
+
+import (
+	"fmt"
+	"siddu-verse-backend/internal/matching"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/skills"
+	"strings"
+	"time"
+)
+
+// Email digest frequencies.
+const (
+	DigestNone   = "none"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestInterval is how long to wait between digests of the given frequency.
+func DigestInterval(frequency string) time.Duration {
+	switch frequency {
+	case DigestDaily:
+		return 24 * time.Hour
+	case DigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Matches reports whether the casting call meets every criterion the search sets. The call's
+// Roles must be loaded, and taxonomy should resolve the search's and the roles' skills.
+// Criteria left empty match anything.
+func Matches(search models.SavedSearch, call models.CastingCall, taxonomy skills.Taxonomy) bool {
+	if projectType := strings.TrimSpace(search.ProjectType); projectType != "" && !strings.EqualFold(projectType, strings.TrimSpace(call.ProjectType)) {
+		return false
+	}
+
+	if keywords := matching.SplitList(search.Keywords); len(keywords) > 0 {
+		text := []string{call.ProjectTitle, call.Description}
+		for _, role := range call.Roles {
+			text = append(text, role.RoleName, role.Description, role.Requirements)
+		}
+		if !containsAny(strings.ToLower(strings.Join(text, " ")), keywords) {
+			return false
+		}
+	}
+
+	if location := strings.ToLower(strings.TrimSpace(search.Location)); location != "" {
+		found := false
+		for _, role := range call.Roles {
+			if strings.Contains(strings.ToLower(role.Location), location) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if wanted := matching.SplitList(search.RequiredSkills); len(wanted) > 0 {
+		for i := range wanted {
+			wanted[i] = taxonomy.CanonicalKey(wanted[i])
+		}
+		found := false
+		for _, role := range call.Roles {
+			for _, required := range matching.SplitList(role.RequiredSkills) {
+				if containsAny(taxonomy.CanonicalKey(required), wanted) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsAny(text string, needles []string) bool {
+	for _, needle := range needles {
+		if strings.Contains(text, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// SkillNames lists the skill names a taxonomy needs to resolve to match the searches against
+// the calls.
+func SkillNames(searches []models.SavedSearch, calls []models.CastingCall) []string {
+	var names []string
+	for _, search := range searches {
+		names = append(names, matching.SplitList(search.RequiredSkills)...)
+	}
+	for _, call := range calls {
+		names = append(names, matching.SkillNames(nil, call.Roles)...)
+	}
+	return names
+}
+
+// DigestBody is the plain-text email listing the casting calls that matched a saved search.
+func DigestBody(search models.SavedSearch, calls []models.CastingCall, frontendURL string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d new casting call(s) match your saved search \"%s\":\n\n", len(calls), search.Name)
+	for _, call := range calls {
+		fmt.Fprintf(&b, "- %s", call.ProjectTitle)
+		if call.ProjectType != "" {
+			fmt.Fprintf(&b, " (%s)", call.ProjectType)
+		}
+		if call.ApplicationDeadline != nil {
+			fmt.Fprintf(&b, ", apply by %s", call.ApplicationDeadline.Format("January 2, 2006"))
+		}
+		fmt.Fprintf(&b, "\n  %s/talent/casting-calls/%d\n", frontendURL, call.ID)
+	}
+	fmt.Fprintf(&b, "\nStop emails for this alert: %s/alerts/unsubscribe/%s\n", frontendURL, search.UnsubscribeToken)
+	return b.String()
+}
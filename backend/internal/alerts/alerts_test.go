@@ -0,0 +1,56 @@
+package alerts
+
+import (
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/skills"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatches(t *testing.T) {
+	call := models.CastingCall{
+		ProjectTitle: "Monsoon Wedding",
+		ProjectType:  "Feature Film",
+		Description:  "A family drama set in Delhi",
+		Roles: []models.CastingCallRole{
+			{RoleName: "Bride", Location: "New Delhi", RequiredSkills: "Acting, Kathak"},
+		},
+	}
+
+	// Test case: Empty criteria match everything
+	assert.True(t, Matches(models.SavedSearch{}, call, nil))
+
+	assert.True(t, Matches(models.SavedSearch{ProjectType: "feature film", Keywords: "thriller, drama"}, call, nil))
+	assert.True(t, Matches(models.SavedSearch{Location: "delhi", RequiredSkills: "kathak"}, call, nil))
+
+	// Test case: Every criterion that is set must match
+	assert.False(t, Matches(models.SavedSearch{ProjectType: "Web Series", Keywords: "drama"}, call, nil))
+	assert.False(t, Matches(models.SavedSearch{Keywords: "thriller"}, call, nil))
+	assert.False(t, Matches(models.SavedSearch{Location: "Mumbai"}, call, nil))
+	assert.False(t, Matches(models.SavedSearch{RequiredSkills: "Singing"}, call, nil))
+
+	// Test case: Skills match under any of their names
+	taxonomy := skills.Taxonomy{"acting": "acting", "actor": "acting"}
+	assert.True(t, Matches(models.SavedSearch{RequiredSkills: "Actor"}, call, taxonomy))
+	assert.False(t, Matches(models.SavedSearch{RequiredSkills: "Actor"}, call, nil))
+}
+
+func TestDigestInterval(t *testing.T) {
+	assert.Equal(t, 24*time.Hour, DigestInterval(DigestDaily))
+	assert.Equal(t, 7*24*time.Hour, DigestInterval(DigestWeekly))
+	assert.Equal(t, time.Duration(0), DigestInterval(DigestNone))
+}
+
+func TestDigestBody(t *testing.T) {
+	search := models.SavedSearch{Name: "Delhi films", UnsubscribeToken: "tok123"}
+	call := models.CastingCall{ProjectTitle: "Monsoon Wedding", ProjectType: "Feature Film"}
+	call.ID = 7
+
+	body := DigestBody(search, []models.CastingCall{call}, "https://siddu.example")
+
+	assert.Contains(t, body, "Monsoon Wedding (Feature Film)")
+	assert.Contains(t, body, "https://siddu.example/talent/casting-calls/7")
+	assert.Contains(t, body, "https://siddu.example/alerts/unsubscribe/tok123")
+}
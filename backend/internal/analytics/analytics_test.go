@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"siddu-verse-backend/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewerKey(t *testing.T) {
+	userID := uint(42)
+	assert.Equal(t, "user:42", ViewerKey(&userID, "10.0.0.1", "Mozilla"))
+
+	// Test case: Anonymous viewers are hashed, never stored raw
+	key := ViewerKey(nil, "10.0.0.1", "Mozilla")
+	assert.True(t, strings.HasPrefix(key, "anon:"))
+	assert.NotContains(t, key, "10.0.0.1")
+	assert.Equal(t, key, ViewerKey(nil, "10.0.0.1", "Mozilla"))
+	assert.NotEqual(t, key, ViewerKey(nil, "10.0.0.2", "Mozilla"))
+}
+
+func TestNormalizeReferrer(t *testing.T) {
+	assert.Equal(t, "kathak dancer", NormalizeReferrer("  Kathak   Dancer "))
+	assert.Len(t, NormalizeReferrer(strings.Repeat("a", 500)), maxReferrerLength)
+}
+
+func TestFillSeries(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 3)
+	stats := []models.DailyViewStat{
+		{Day: from.AddDate(0, 0, 1), Views: 5, SearchViews: 2, Applications: 1},
+	}
+
+	series := FillSeries(stats, from, to)
+
+	// Test case: Days without activity are zero-filled
+	assert.Len(t, series, 3)
+	assert.Equal(t, DayPoint{Day: "2026-03-01"}, series[0])
+	assert.Equal(t, DayPoint{Day: "2026-03-02", Views: 5, SearchViews: 2, Applications: 1}, series[1])
+	assert.Equal(t, int64(0), series[2].Views)
+}
+
+func TestConversionRate(t *testing.T) {
+	assert.Equal(t, 0.0, ConversionRate(3, 0))
+	assert.Equal(t, 33.3, ConversionRate(1, 3))
+	assert.Equal(t, 100.0, ConversionRate(4, 4))
+}
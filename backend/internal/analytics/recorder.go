@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"siddu-verse-backend/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Subjects that views are recorded for.
+const (
+	SubjectTalentProfile = "talent_profile"
+	SubjectCastingCall   = "casting_call"
+)
+
+// Viewer kinds used in breakdowns.
+const (
+	ViewerAnonymous = "anonymous"
+	ViewerMember    = "member"
+	ViewerRecruiter = "recruiter"
+)
+
+// maxReferrerLength caps stored search terms so free-text queries can't bloat the table.
+const maxReferrerLength = 100
+
+// View is a single page view waiting to be recorded.
+type View struct {
+	SubjectType  string
+	SubjectID    uint
+	ViewerUserID *uint
+	ViewerKey    string
+	Referrer     string
+	At           time.Time
+}
+
+// ViewerKey identifies a viewer for de-duplication: their user ID when signed in, otherwise
+// a hash of their IP address and user agent so raw addresses are never stored.
+func ViewerKey(userID *uint, ip, userAgent string) string {
+	if userID != nil {
+		return "user:" + strconv.FormatUint(uint64(*userID), 10)
+	}
+	sum := sha256.Sum256([]byte(ip + "|" + userAgent))
+	return "anon:" + hex.EncodeToString(sum[:8])
+}
+
+// NormalizeReferrer turns the search that led to a view into a stable, bounded key.
+func NormalizeReferrer(search string) string {
+	search = strings.ToLower(strings.Join(strings.Fields(search), " "))
+	if len(search) > maxReferrerLength {
+		search = search[:maxReferrerLength]
+	}
+	return search
+}
+
+// Day truncates t to the UTC calendar day views are de-duplicated and aggregated by.
+func Day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Recorder writes views in the background so recording never slows down the page being viewed.
+type Recorder struct {
+	db    *gorm.DB
+	views chan View
+}
+
+// NewRecorder starts a recorder that writes to db.
+func NewRecorder(db *gorm.DB) *Recorder {
+	r := &Recorder{db: db, views: make(chan View, 1024)}
+	go r.run()
+	return r
+}
+
+// Record queues a view. If the queue is full the view is dropped rather than blocking the request.
+func (r *Recorder) Record(view View) {
+	select {
+	case r.views <- view:
+	default:
+		log.Printf("analytics: dropped view of %s %d, queue full", view.SubjectType, view.SubjectID)
+	}
+}
+
+func (r *Recorder) run() {
+	for view := range r.views {
+		if err := r.write(view); err != nil {
+			log.Printf("analytics: failed to record view of %s %d: %v", view.SubjectType, view.SubjectID, err)
+		}
+	}
+}
+
+func (r *Recorder) write(view View) error {
+	kind := ViewerAnonymous
+	if view.ViewerUserID != nil {
+		if isOwner(r.db, view.SubjectType, view.SubjectID, *view.ViewerUserID) {
+			return nil
+		}
+		kind = ViewerMember
+		if isRecruiter(r.db, *view.ViewerUserID) {
+			kind = ViewerRecruiter
+		}
+	}
+
+	event := models.ViewEvent{
+		SubjectType:  view.SubjectType,
+		SubjectID:    view.SubjectID,
+		ViewerKey:    view.ViewerKey,
+		Day:          Day(view.At),
+		ViewerUserID: view.ViewerUserID,
+		ViewerKind:   kind,
+		Referrer:     NormalizeReferrer(view.Referrer),
+	}
+	// The unique index does the per-viewer, per-day de-duplication
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&event).Error
+}
+
+// isOwner reports whether the viewer owns the subject: the talent themself, or anyone on the
+// casting call's team.
+func isOwner(db *gorm.DB, subjectType string, subjectID, userID uint) bool {
+	var count int64
+	switch subjectType {
+	case SubjectTalentProfile:
+		db.Model(&models.TalentProfile{}).Where("id = ? AND user_id = ?", subjectID, userID).Count(&count)
+	case SubjectCastingCall:
+		db.Model(&models.CastingCall{}).Where("id = ? AND posted_by_user_id = ?", subjectID, userID).Count(&count)
+		if count == 0 {
+			db.Model(&models.CastingCallMember{}).Where("casting_call_id = ? AND user_id = ?", subjectID, userID).Count(&count)
+		}
+	}
+	return count > 0
+}
+
+// isRecruiter reports whether the user has posted or works on any casting call.
+func isRecruiter(db *gorm.DB, userID uint) bool {
+	var count int64
+	db.Model(&models.CastingCall{}).Where("posted_by_user_id = ?", userID).Count(&count)
+	if count > 0 {
+		return true
+	}
+	db.Model(&models.CastingCallMember{}).Where("user_id = ?", userID).Count(&count)
+	return count > 0
+}
@@ -0,0 +1,173 @@
+package analytics
+
+import (
+	"math"
+	"siddu-verse-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DayPoint is one day in an analytics time series.
+type DayPoint struct {
+	Day          string `json:"day"` // YYYY-MM-DD
+	Views        int64  `json:"views"`
+	SearchViews  int64  `json:"searchViews"`
+	Applications int64  `json:"applications,omitempty"`
+}
+
+type statKey struct {
+	SubjectType string
+	SubjectID   uint
+	Day         time.Time
+}
+
+// AggregateDailyStats recomputes the daily view and referrer rollups for every day from since
+// onwards, and folds those days into the per-viewer rollups. Views are written
+// asynchronously, so recent days are recomputed rather than incremented.
+func AggregateDailyStats(db *gorm.DB, since time.Time) error {
+	since = Day(since)
+
+	var viewRows []struct {
+		SubjectType    string
+		SubjectID      uint
+		Day            time.Time
+		Views          int64
+		AnonymousViews int64
+		MemberViews    int64
+		RecruiterViews int64
+		SearchViews    int64
+	}
+	if err := db.Model(&models.ViewEvent{}).
+		Select(`subject_type, subject_id, day, COUNT(*) AS views,
+			SUM(CASE WHEN viewer_kind = ? THEN 1 ELSE 0 END) AS anonymous_views,
+			SUM(CASE WHEN viewer_kind = ? THEN 1 ELSE 0 END) AS member_views,
+			SUM(CASE WHEN viewer_kind = ? THEN 1 ELSE 0 END) AS recruiter_views,
+			SUM(CASE WHEN referrer <> '' THEN 1 ELSE 0 END) AS search_views`,
+			ViewerAnonymous, ViewerMember, ViewerRecruiter).
+		Where("day >= ?", since).
+		Group("subject_type, subject_id, day").
+		Scan(&viewRows).Error; err != nil {
+		return err
+	}
+
+	var applicationRows []struct {
+		CastingCallID uint
+		Day           time.Time
+		Total         int64
+	}
+	if err := db.Model(&models.Application{}).
+		Select("casting_call_id, DATE(created_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS total").
+		Where("created_at >= ?", since).
+		Group("casting_call_id, DATE(created_at AT TIME ZONE 'UTC')").
+		Scan(&applicationRows).Error; err != nil {
+		return err
+	}
+
+	stats := make(map[statKey]*models.DailyViewStat)
+	statFor := func(key statKey) *models.DailyViewStat {
+		if stats[key] == nil {
+			stats[key] = &models.DailyViewStat{SubjectType: key.SubjectType, SubjectID: key.SubjectID, Day: key.Day}
+		}
+		return stats[key]
+	}
+	for _, row := range viewRows {
+		stat := statFor(statKey{row.SubjectType, row.SubjectID, Day(row.Day)})
+		stat.Views = row.Views
+		stat.AnonymousViews = row.AnonymousViews
+		stat.MemberViews = row.MemberViews
+		stat.RecruiterViews = row.RecruiterViews
+		stat.SearchViews = row.SearchViews
+	}
+	for _, row := range applicationRows {
+		statFor(statKey{SubjectCastingCall, row.CastingCallID, Day(row.Day)}).Applications = row.Total
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+
+	rows := make([]models.DailyViewStat, 0, len(stats))
+	for _, stat := range stats {
+		rows = append(rows, *stat)
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subject_type"}, {Name: "subject_id"}, {Name: "day"}},
+		DoUpdates: clause.AssignmentColumns([]string{"views", "anonymous_views", "member_views", "recruiter_views", "search_views", "applications", "updated_at"}),
+	}).CreateInBatches(rows, 500).Error; err != nil {
+		return err
+	}
+
+	if err := aggregateReferrers(db, since); err != nil {
+		return err
+	}
+	return aggregateViewers(db, since)
+}
+
+// aggregateReferrers recomputes the per-day referrer rollups from since onwards.
+func aggregateReferrers(db *gorm.DB, since time.Time) error {
+	var rows []models.DailyReferrerStat
+	if err := db.Model(&models.ViewEvent{}).
+		Select("subject_type, subject_id, day, referrer, COUNT(*) AS views").
+		Where("day >= ? AND referrer <> ''", since).
+		Group("subject_type, subject_id, day, referrer").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subject_type"}, {Name: "subject_id"}, {Name: "day"}, {Name: "referrer"}},
+		DoUpdates: clause.AssignmentColumns([]string{"views", "updated_at"}),
+	}).CreateInBatches(rows, 500).Error
+}
+
+// aggregateViewers widens each viewer's first and last view day with the views from since
+// onwards.
+func aggregateViewers(db *gorm.DB, since time.Time) error {
+	var rows []models.SubjectViewer
+	if err := db.Model(&models.ViewEvent{}).
+		Select("subject_type, subject_id, viewer_key, MIN(day) AS first_day, MAX(day) AS last_day").
+		Where("day >= ?", since).
+		Group("subject_type, subject_id, viewer_key").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "subject_type"}, {Name: "subject_id"}, {Name: "viewer_key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"first_day":  gorm.Expr("LEAST(subject_viewers.first_day, excluded.first_day)"),
+			"last_day":   gorm.Expr("GREATEST(subject_viewers.last_day, excluded.last_day)"),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+		}),
+	}).CreateInBatches(rows, 500).Error
+}
+
+// FillSeries turns the rollups for [from, to) into one point per day, with zeroes for days
+// that had no activity.
+func FillSeries(stats []models.DailyViewStat, from, to time.Time) []DayPoint {
+	byDay := make(map[string]models.DailyViewStat, len(stats))
+	for _, stat := range stats {
+		byDay[Day(stat.Day).Format("2006-01-02")] = stat
+	}
+
+	points := []DayPoint{}
+	for day := Day(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		stat := byDay[key]
+		points = append(points, DayPoint{Day: key, Views: stat.Views, SearchViews: stat.SearchViews, Applications: stat.Applications})
+	}
+	return points
+}
+
+// ConversionRate is the share of unique viewers who applied, as a percentage to one decimal.
+func ConversionRate(applications, uniqueViewers int64) float64 {
+	if uniqueViewers == 0 {
+		return 0
+	}
+	return math.Round(float64(applications)/float64(uniqueViewers)*1000) / 10
+}
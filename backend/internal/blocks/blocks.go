@@ -0,0 +1,79 @@
+package blocks
+
+import (
+	"siddu-verse-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// A block is mutual: neither user sees the other's pulses, comments, profiles or casting
+// calls, and they can't follow, message or apply to each other. A mute is one-way and only
+// keeps the muted user's pulses out of the muter's feeds; the muted user isn't told.
+
+// Between reports whether either user has blocked the other.
+func Between(db *gorm.DB, a, b uint) (bool, error) {
+	var count int64
+	err := db.Model(&models.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", a, b, b, a).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Pairs is a set of blocks between users, for checking many pairs after one query.
+type Pairs map[[2]uint]bool
+
+// Blocked reports whether either user has blocked the other.
+func (p Pairs) Blocked(a, b uint) bool {
+	return p[[2]uint{a, b}] || p[[2]uint{b, a}]
+}
+
+// LoadPairs loads the blocks either way between any of users and any of others.
+func LoadPairs(db *gorm.DB, users, others []uint) (Pairs, error) {
+	pairs := Pairs{}
+	if len(users) == 0 || len(others) == 0 {
+		return pairs, nil
+	}
+	var rows []models.Block
+	if err := db.Select("blocker_id", "blocked_id").
+		Where("(blocker_id IN ? AND blocked_id IN ?) OR (blocker_id IN ? AND blocked_id IN ?)", users, others, others, users).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		pairs[[2]uint{row.BlockerID, row.BlockedID}] = true
+	}
+	return pairs, nil
+}
+
+// Hidden selects the IDs of the users the viewer has blocked or been blocked by.
+func Hidden(db *gorm.DB, viewerID uint) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.Block{}).
+		Select("CASE WHEN blocker_id = ? THEN blocked_id ELSE blocker_id END", viewerID).
+		Where("blocker_id = ? OR blocked_id = ?", viewerID, viewerID)
+}
+
+// Muted selects the IDs of the users the viewer has muted.
+func Muted(db *gorm.DB, viewerID uint) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.Mute{}).Select("muted_id").Where("muter_id = ?", viewerID)
+}
+
+// Exclude scopes a listing to rows whose author, in authorColumn, isn't blocked either way
+// with the viewer. Anonymous viewers (ID 0) see everything.
+func Exclude(viewerID uint, authorColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == 0 {
+			return db
+		}
+		return db.Where(authorColumn+" NOT IN (?)", Hidden(db, viewerID))
+	}
+}
+
+// ExcludeFromFeed is Exclude for the viewer's pulse feeds, which also leave out muted authors.
+func ExcludeFromFeed(viewerID uint, authorColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == 0 {
+			return db
+		}
+		return db.Where(authorColumn+" NOT IN (?) AND "+authorColumn+" NOT IN (?)", Hidden(db, viewerID), Muted(db, viewerID))
+	}
+}
@@ -0,0 +1,37 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	ics := Encode("Priya's availability", []Event{{
+		UID:     "availability-1@siddu-verse",
+		Summary: "Hold: Feature Film; Day 1, Mumbai",
+		Start:   start,
+		End:     start.Add(8 * time.Hour),
+		Status:  "TENTATIVE",
+	}})
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "DTSTART:20250301T090000Z\r\n")
+	assert.Contains(t, ics, "DTEND:20250301T170000Z\r\n")
+	assert.Contains(t, ics, `SUMMARY:Hold: Feature Film\; Day 1\, Mumbai`)
+	assert.Contains(t, ics, "STATUS:TENTATIVE\r\n")
+}
+
+func TestWriteLineFoldsLongLines(t *testing.T) {
+	var b strings.Builder
+	writeLine(&b, "DESCRIPTION:"+strings.Repeat("a", 100))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	assert.Len(t, lines, 2)
+	assert.Len(t, lines[0], 75)
+	assert.True(t, strings.HasPrefix(lines[1], " "))
+}
@@ -0,0 +1,36 @@
+package engagement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	// Test case: Counts every kind, with zeroes for kinds nobody used
+	summary := Summarize([]KindCount{{Kind: "like", Count: 3}, {Kind: "laugh", Count: 2}}, "laugh")
+	assert.Equal(t, int64(5), summary.Total)
+	assert.Equal(t, int64(3), summary.Counts["like"])
+	assert.Equal(t, int64(0), summary.Counts["angry"])
+	assert.Len(t, summary.Counts, len(Kinds))
+	assert.Equal(t, "laugh", summary.Mine)
+
+	// Test case: Kinds that are no longer supported are left out
+	summary = Summarize([]KindCount{{Kind: "retired", Count: 4}}, "")
+	assert.Equal(t, int64(0), summary.Total)
+	assert.NotContains(t, summary.Counts, "retired")
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(Type{Name: "movies"}, Type{Name: "awards"})
+
+	// Test case: Registered types can be looked up, others can't
+	_, ok := registry.Lookup("movies")
+	assert.True(t, ok)
+	_, ok = registry.Lookup("users")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"awards", "movies"}, registry.Names())
+
+	// Test case: Registering a type twice is a programming error
+	assert.Panics(t, func() { NewRegistry(Type{Name: "movies"}, Type{Name: "movies"}) })
+}
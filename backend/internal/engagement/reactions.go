@@ -0,0 +1,74 @@
+package engagement
+
+import (
+	"siddu-verse-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// KindLike is the reaction recorded by the original like endpoints.
+const KindLike = "like"
+
+// Kinds maps each reaction kind to the emoji clients show for it.
+var Kinds = map[string]string{
+	KindLike: "👍",
+	"love":   "❤️",
+	"laugh":  "😂",
+	"wow":    "😮",
+	"sad":    "😢",
+	"angry":  "😠",
+}
+
+// IsKind reports whether kind is a supported reaction.
+func IsKind(kind string) bool {
+	_, ok := Kinds[kind]
+	return ok
+}
+
+// KindCount is how many reactions of one kind an item has.
+type KindCount struct {
+	Kind  string
+	Count int64
+}
+
+// Summary is the aggregated reactions on an item.
+type Summary struct {
+	Counts map[string]int64 `json:"counts"` // Every kind, including those with no reactions
+	Total  int64            `json:"total"`
+	Mine   string           `json:"mine,omitempty"` // The viewer's reaction, if any
+}
+
+// Summarize totals the per-kind counts. Rows for unknown kinds are ignored.
+func Summarize(rows []KindCount, mine string) Summary {
+	summary := Summary{Counts: make(map[string]int64, len(Kinds)), Mine: mine}
+	for kind := range Kinds {
+		summary.Counts[kind] = 0
+	}
+	for _, row := range rows {
+		if IsKind(row.Kind) {
+			summary.Counts[row.Kind] += row.Count
+			summary.Total += row.Count
+		}
+	}
+	return summary
+}
+
+// LoadSummary aggregates the reactions on an item. viewerID is 0 for anonymous viewers.
+func LoadSummary(db *gorm.DB, ownerType string, ownerID, viewerID uint) (Summary, error) {
+	var rows []KindCount
+	if err := db.Model(&models.Like{}).Select("kind, COUNT(*) AS count").
+		Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Group("kind").Scan(&rows).Error; err != nil {
+		return Summary{}, err
+	}
+
+	var mine string
+	if viewerID != 0 {
+		var like models.Like
+		if err := db.Where("owner_type = ? AND owner_id = ? AND user_id = ?", ownerType, ownerID, viewerID).Limit(1).Find(&like).Error; err != nil {
+			return Summary{}, err
+		}
+		mine = like.Kind
+	}
+	return Summarize(rows, mine), nil
+}
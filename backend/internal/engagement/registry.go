@@ -0,0 +1,71 @@
+package engagement
+
+import (
+	"errors"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned by Resolve when the item doesn't exist or the viewer can't see it.
+// The two are deliberately indistinguishable so hidden items aren't revealed.
+var ErrNotFound = errors.New("item not found")
+
+// Type is a kind of content that can be commented on and reacted to.
+type Type struct {
+	// Name is the polymorphic owner type stored on comments and reactions, which is the
+	// content's table name (e.g. "movies"), matching gorm's polymorphic default.
+	Name string
+	// Resolve checks the item exists and is visible to the viewer (0 when anonymous), and
+	// returns the user who owns it. Owners can delete any comment on their item. Editorial
+	// content such as movies has no owner and returns 0.
+	Resolve func(db *gorm.DB, viewerID, id uint) (ownerUserID uint, err error)
+}
+
+// Registry holds the content types comments and reactions are enabled for.
+type Registry struct {
+	types map[string]Type
+}
+
+// NewRegistry registers the given types. Registering a name twice panics, since it is a
+// programming error.
+func NewRegistry(types ...Type) *Registry {
+	r := &Registry{types: make(map[string]Type, len(types))}
+	for _, t := range types {
+		if _, exists := r.types[t.Name]; exists {
+			panic("engagement: type " + t.Name + " registered twice")
+		}
+		r.types[t.Name] = t
+	}
+	return r
+}
+
+// Lookup returns the registered type with the name.
+func (r *Registry) Lookup(name string) (Type, bool) {
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// Names lists the registered type names in alphabetical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Public resolves items anyone can see and nobody owns, such as movies and awards.
+func Public(model interface{}) func(db *gorm.DB, viewerID, id uint) (uint, error) {
+	return func(db *gorm.DB, viewerID, id uint) (uint, error) {
+		var count int64
+		if err := db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		if count == 0 {
+			return 0, ErrNotFound
+		}
+		return 0, nil
+	}
+}
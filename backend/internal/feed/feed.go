@@ -0,0 +1,182 @@
+package feed
+
+import (
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Followee types.
+const (
+	FolloweeUser          = "users"
+	FolloweeTalentProfile = "talent_profiles"
+)
+
+// The home feed is a hybrid of fan-out on write and on read. Pulses from most authors are
+// copied into each follower's feed when posted, so reading a feed is one indexed range scan
+// however many accounts the user follows. Authors whose reach exceeds FanOutThreshold would
+// make posting too slow, so their pulses are pulled and merged in when the feed is read.
+const (
+	FanOutThreshold = 5000
+	// BackfillSize is how many recent pulses are copied into a feed when following an author.
+	BackfillSize = 20
+)
+
+// reachSQL estimates an author's follower count: followers of the account plus followers of
+// their talent profile. Users following both are counted twice, which only matters near the
+// threshold.
+const reachSQL = "users.follower_count + COALESCE((SELECT talent_profiles.follower_count FROM talent_profiles WHERE talent_profiles.user_id = users.id AND talent_profiles.deleted_at IS NULL), 0)"
+
+// IsHighReach reports whether the author's pulses are pulled at read time instead of fanned out.
+func IsHighReach(db *gorm.DB, authorID uint) (bool, error) {
+	var reach int64
+	if err := db.Model(&models.User{}).Select(reachSQL).Where("users.id = ?", authorID).Scan(&reach).Error; err != nil {
+		return false, err
+	}
+	return reach > FanOutThreshold, nil
+}
+
+// FanOut writes a newly posted pulse into the feed of everyone following its author.
+func FanOut(db *gorm.DB, pulse models.Pulse) error {
+	highReach, err := IsHighReach(db, pulse.UserID)
+	if err != nil || highReach {
+		return err
+	}
+
+	var followerIDs []uint
+	if err := db.Model(&models.Follow{}).Where("author_user_id = ?", pulse.UserID).Distinct().Pluck("follower_id", &followerIDs).Error; err != nil {
+		return err
+	}
+	if len(followerIDs) == 0 {
+		return nil
+	}
+
+	entries := make([]models.FeedEntry, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		entries = append(entries, models.FeedEntry{UserID: followerID, PulseID: pulse.ID, AuthorUserID: pulse.UserID, CreatedAt: pulse.CreatedAt})
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(entries, 1000).Error
+}
+
+// Backfill copies the author's recent pulses into the follower's feed so it isn't empty until
+// the author next posts. High-reach authors are skipped since their pulses are pulled anyway.
+func Backfill(db *gorm.DB, followerID, authorID uint) error {
+	highReach, err := IsHighReach(db, authorID)
+	if err != nil || highReach {
+		return err
+	}
+
+	var pulses []models.Pulse
+	if err := db.Where("user_id = ?", authorID).Order("id desc").Limit(BackfillSize).Find(&pulses).Error; err != nil {
+		return err
+	}
+	if len(pulses) == 0 {
+		return nil
+	}
+
+	entries := make([]models.FeedEntry, 0, len(pulses))
+	for _, pulse := range pulses {
+		entries = append(entries, models.FeedEntry{UserID: followerID, PulseID: pulse.ID, AuthorUserID: authorID, CreatedAt: pulse.CreatedAt})
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entries).Error
+}
+
+// RemoveAuthor takes the author's pulses out of the follower's feed after an unfollow, unless
+// the follower still follows the author another way (the account or their talent profile).
+func RemoveAuthor(db *gorm.DB, followerID, authorID uint) error {
+	var remaining int64
+	if err := db.Model(&models.Follow{}).Where("follower_id = ? AND author_user_id = ?", followerID, authorID).Count(&remaining).Error; err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return nil
+	}
+	return db.Where("user_id = ? AND author_user_id = ?", followerID, authorID).Delete(&models.FeedEntry{}).Error
+}
+
+// Timeline returns up to limit pulses for the user's home feed, newest first, with IDs below
+// beforeID when it is set. It merges the fanned-out entries with pulses pulled from the
+// high-reach authors the user follows and the user's own pulses. Pulses taken down by
+// moderators, and from users the viewer blocked, muted or was blocked by, are left out.
+func Timeline(db *gorm.DB, userID, beforeID uint, limit int) ([]models.Pulse, error) {
+	pushed := db.Model(&models.FeedEntry{}).Where("user_id = ?", userID).
+		Where("author_user_id NOT IN (?) AND pulse_id NOT IN (?)", moderation.BannedUsers(db), moderation.HiddenPulses(db)).
+		Scopes(blocks.ExcludeFromFeed(userID, "author_user_id"))
+	if beforeID > 0 {
+		pushed = pushed.Where("pulse_id < ?", beforeID)
+	}
+	var pushedIDs []uint
+	if err := pushed.Order("pulse_id desc").Limit(limit).Pluck("pulse_id", &pushedIDs).Error; err != nil {
+		return nil, err
+	}
+
+	pulledAuthors := []uint{userID}
+	var highReach []uint
+	if err := db.Model(&models.User{}).
+		Where("users.id IN (?)", db.Model(&models.Follow{}).Select("author_user_id").Where("follower_id = ?", userID)).
+		Where(reachSQL+" > ?", FanOutThreshold).
+		Pluck("users.id", &highReach).Error; err != nil {
+		return nil, err
+	}
+	pulledAuthors = append(pulledAuthors, highReach...)
+
+	pulled := db.Model(&models.Pulse{}).Scopes(moderation.VisiblePulses, blocks.ExcludeFromFeed(userID, "pulses.user_id")).Where("user_id IN ?", pulledAuthors)
+	if beforeID > 0 {
+		pulled = pulled.Where("id < ?", beforeID)
+	}
+	var pulledIDs []uint
+	if err := pulled.Order("id desc").Limit(limit).Pluck("id", &pulledIDs).Error; err != nil {
+		return nil, err
+	}
+
+	ids := MergeIDs(pushedIDs, pulledIDs, limit)
+	pulses := []models.Pulse{}
+	if len(ids) == 0 {
+		return pulses, nil
+	}
+	if err := db.Scopes(Details(userID)).Where("id IN ?", ids).Order("id desc").Find(&pulses).Error; err != nil {
+		return nil, err
+	}
+	return pulses, nil
+}
+
+// Details preloads what a pulse is shown to the viewer with: its author, hashtags, mentions,
+// links and their preview cards, poll and the pulse it reposts or quotes, unless a moderator
+// has taken that down or its author and the viewer have blocked each other.
+func Details(viewerID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload("User").Preload("Hashtags.Hashtag").Preload("Mentions.User").Preload("References").
+			Preload("Poll.Options", byPosition).Preload("Links", byPosition).Preload("Links.LinkPreview").
+			Preload("OriginalPulse", moderation.VisiblePulses, blocks.Exclude(viewerID, "pulses.user_id")).Preload("OriginalPulse.User").
+			Preload("OriginalPulse.Poll.Options", byPosition).
+			Preload("OriginalPulse.Links", byPosition).Preload("OriginalPulse.Links.LinkPreview")
+	}
+}
+
+// byPosition lists poll options and links in the order they were written.
+func byPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+// MergeIDs merges two lists of IDs sorted newest (highest) first into one, dropping
+// duplicates, and keeps at most limit.
+func MergeIDs(a, b []uint, limit int) []uint {
+	merged := make([]uint, 0, min(len(a)+len(b), limit))
+	i, j := 0, 0
+	for len(merged) < limit && (i < len(a) || j < len(b)) {
+		var next uint
+		switch {
+		case j >= len(b) || (i < len(a) && a[i] > b[j]):
+			next, i = a[i], i+1
+		case i >= len(a) || b[j] > a[i]:
+			next, j = b[j], j+1
+		default:
+			next, i, j = a[i], i+1, j+1
+		}
+		merged = append(merged, next)
+	}
+	return merged
+}
@@ -0,0 +1,22 @@
+package feed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeIDs(t *testing.T) {
+	// Test case: Interleaves both lists newest first
+	assert.Equal(t, []uint{9, 8, 5, 3, 2}, MergeIDs([]uint{9, 5, 2}, []uint{8, 3}, 10))
+
+	// Test case: A pulse in both lists appears once
+	assert.Equal(t, []uint{7, 6, 4}, MergeIDs([]uint{7, 4}, []uint{7, 6, 4}, 10))
+
+	// Test case: Stops at the limit
+	assert.Equal(t, []uint{9, 8}, MergeIDs([]uint{9, 5, 2}, []uint{8, 3}, 2))
+
+	// Test case: Either list can be empty
+	assert.Equal(t, []uint{3, 1}, MergeIDs(nil, []uint{3, 1}, 10))
+	assert.Empty(t, MergeIDs(nil, nil, 10))
+}
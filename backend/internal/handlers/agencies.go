@@ -0,0 +1,657 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Agency member roles, from most to least privileged.
+const (
+	AgencyRoleAdmin = "admin" // manages the agency, its agents and its roster
+	AgencyRoleAgent = "agent" // requests representation and applies on behalf of the roster
+)
+
+var agencyRoleRank = map[string]int{
+	AgencyRoleAgent: 1,
+	AgencyRoleAdmin: 2,
+}
+
+// Representation states.
+const (
+	RepresentationPending  = "pending"
+	RepresentationActive   = "active"
+	RepresentationDeclined = "declined"
+	RepresentationEnded    = "ended"
+)
+
+// agencyRole returns the user's role at the agency, or "" if they don't work there.
+func agencyRole(db *gorm.DB, userID uint, agencyID uint) string {
+	var member models.AgencyMember
+	if err := db.First(&member, "agency_id = ? AND user_id = ?", agencyID, userID).Error; err != nil {
+		return ""
+	}
+	return member.Role
+}
+
+// isAgencyMember checks if the user works at the agency in any role.
+func isAgencyMember(db *gorm.DB, userID uint, agencyID uint) bool {
+	return agencyRole(db, userID, agencyID) != ""
+}
+
+// findAgency loads the agency in the :id param and verifies the user holds at least minRole
+// there. It writes the error response on failure.
+func (h *BaseHandler) findAgency(c *gin.Context, userID uint, minRole string) (models.Agency, bool) {
+	var agency models.Agency
+	if err := h.DB.First(&agency, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency not found"})
+		return agency, false
+	}
+
+	role := agencyRole(h.DB, userID, agency.ID)
+	if role == "" || agencyRoleRank[role] < agencyRoleRank[minRole] {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to manage this agency"})
+		return agency, false
+	}
+	return agency, true
+}
+
+// errLastAgencyAdmin is returned when a change would leave an agency without an admin.
+var errLastAgencyAdmin = errors.New("agency must keep at least one admin")
+
+// ensureAgencyAdminRemains fails if the agency has no admins left, so it must run after the change.
+func ensureAgencyAdminRemains(tx *gorm.DB, agencyID uint) error {
+	var admins int64
+	if err := tx.Model(&models.AgencyMember{}).Where("agency_id = ? AND role = ?", agencyID, AgencyRoleAdmin).Count(&admins).Error; err != nil {
+		return err
+	}
+	if admins == 0 {
+		return errLastAgencyAdmin
+	}
+	return nil
+}
+
+// notifyUser sends a single in-app notification, logging rather than returning failures.
+func (h *BaseHandler) notifyUser(userID uint, notificationType, title, body, link string) {
+	if err := notifications.Send(h.DB, []uint{userID}, notificationType, title, body, link); err != nil {
+		log.Printf("Failed to send %s notification to user %d: %v", notificationType, userID, err)
+	}
+}
+
+// --- Agency Handlers ---
+
+type AgencyInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Website     string `json:"website" binding:"omitempty,url"`
+	Location    string `json:"location"`
+}
+
+// screeningText is the agency text checked by the content screener.
+func (input AgencyInput) screeningText() string {
+	return strings.Join([]string{input.Name, input.Description, input.Location}, "\n")
+}
+
+// CreateAgency creates an agency with the creator as its first admin.
+func (h *BaseHandler) CreateAgency(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input AgencyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var existing int64
+	h.DB.Model(&models.Agency{}).Where("LOWER(name) = LOWER(?)", input.Name).Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "An agency with this name already exists"})
+		return
+	}
+
+	screened, ok := h.screenContent(c, userID.(uint), "agencies", input.screeningText(), input.Website)
+	if !ok {
+		return
+	}
+
+	agency := models.Agency{
+		Name:            input.Name,
+		Description:     input.Description,
+		Website:         input.Website,
+		Location:        input.Location,
+		CreatedByUserID: userID.(uint),
+		HiddenAt:        heldAt(screened),
+	}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&agency).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.AgencyMember{AgencyID: agency.ID, UserID: userID.(uint), Role: AgencyRoleAdmin}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create agency"})
+		return
+	}
+	h.recordScreening(agency.CreatedByUserID, "agencies", agency.ID, input.screeningText(), screened)
+
+	c.JSON(screenedStatus(screened, http.StatusCreated), agency)
+}
+
+// GetAgencyByID returns the agency with its agents and the talent it actively represents.
+func (h *BaseHandler) GetAgencyByID(c *gin.Context) {
+	var agency models.Agency
+	if err := h.DB.Preload("Members.User").Scopes(moderation.VisibleAgencies).First(&agency, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency not found"})
+		return
+	}
+
+	// The roster only lists profiles the viewer could open on their own
+	visibleProfiles := h.DB.Model(&models.TalentProfile{}).Select("talent_profiles.id").
+		Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(viewerID(c), "talent_profiles.user_id"))
+	var roster []models.AgencyRepresentation
+	if err := h.DB.Preload("TalentProfile").
+		Where("agency_id = ? AND status = ? AND talent_profile_id IN (?)", agency.ID, RepresentationActive, visibleProfiles).
+		Find(&roster).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch agency roster"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"agency": agency, "roster": roster})
+}
+
+// GetMyAgencies lists the agencies the user works at.
+func (h *BaseHandler) GetMyAgencies(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var agencies []models.Agency
+	if err := h.DB.Where("id IN (?)", h.DB.Model(&models.AgencyMember{}).Select("agency_id").Where("user_id = ?", userID.(uint))).
+		Order("name").Find(&agencies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch your agencies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, agencies)
+}
+
+func (h *BaseHandler) UpdateAgency(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAdmin)
+	if !ok {
+		return
+	}
+
+	var input AgencyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	screened, ok := h.screenEdit(c, userID.(uint), "agencies", agency.ID, input.screeningText(), input.Website)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.Model(&agency).Updates(map[string]interface{}{
+		"name":        input.Name,
+		"description": input.Description,
+		"website":     input.Website,
+		"location":    input.Location,
+	}).Error; err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Failed to update agency; the name may already be taken"})
+		return
+	}
+	h.holdForReview(userID.(uint), "agencies", agency.ID, input.screeningText(), screened)
+
+	c.JSON(screenedStatus(screened, http.StatusOK), agency)
+}
+
+// --- Agency Member Handlers ---
+
+type AddAgencyMemberInput struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=admin agent"`
+}
+
+func (h *BaseHandler) AddAgencyMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAdmin)
+	if !ok {
+		return
+	}
+
+	var input AddAgencyMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user models.User
+	if err := h.DB.First(&user, "username = ?", input.Username).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if isAgencyMember(h.DB, user.ID, agency.ID) {
+		c.JSON(http.StatusConflict, gin.H{"error": "User already works at this agency"})
+		return
+	}
+
+	member := models.AgencyMember{AgencyID: agency.ID, UserID: user.ID, Role: input.Role}
+	if err := h.DB.Create(&member).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add agency member"})
+		return
+	}
+
+	h.notifyUser(user.ID, notifications.TypeAgencyMemberAdded, "You were added to "+agency.Name, "You can now manage applications for the agency's roster.",
+		"/agencies/"+strconv.FormatUint(uint64(agency.ID), 10))
+	c.JSON(http.StatusCreated, member)
+}
+
+type UpdateAgencyMemberInput struct {
+	Role string `json:"role" binding:"required,oneof=admin agent"`
+}
+
+func (h *BaseHandler) UpdateAgencyMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAdmin)
+	if !ok {
+		return
+	}
+
+	var input UpdateAgencyMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var member models.AgencyMember
+	if err := h.DB.First(&member, "id = ? AND agency_id = ?", c.Param("member_id"), agency.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency member not found"})
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&member).Update("role", input.Role).Error; err != nil {
+			return err
+		}
+		return ensureAgencyAdminRemains(tx, agency.ID)
+	})
+	if errors.Is(err, errLastAgencyAdmin) {
+		c.JSON(http.StatusConflict, gin.H{"error": "An agency must keep at least one admin"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update agency member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveAgencyMember removes an agent. Admins can remove anyone; agents can remove themselves.
+func (h *BaseHandler) RemoveAgencyMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var member models.AgencyMember
+	if err := h.DB.First(&member, "id = ? AND agency_id = ?", c.Param("member_id"), c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency member not found"})
+		return
+	}
+	if member.UserID != userID.(uint) && agencyRole(h.DB, userID.(uint), member.AgencyID) != AgencyRoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to manage this agency"})
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Unscoped so the user can be added back later
+		if err := tx.Unscoped().Delete(&member).Error; err != nil {
+			return err
+		}
+		return ensureAgencyAdminRemains(tx, member.AgencyID)
+	})
+	if errors.Is(err, errLastAgencyAdmin) {
+		c.JSON(http.StatusConflict, gin.H{"error": "An agency must keep at least one admin"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove agency member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Agency member removed successfully"})
+}
+
+// --- Roster Handlers ---
+
+type RequestRepresentationInput struct {
+	TalentProfileID uint `json:"talentProfileId" binding:"required"`
+}
+
+// RequestRepresentation asks a talent to join the agency's roster. It stays pending until the
+// talent consents.
+func (h *BaseHandler) RequestRepresentation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAgent)
+	if !ok {
+		return
+	}
+
+	var input RequestRepresentationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Hidden profiles and banned talent can't be added to a roster
+	var profile models.TalentProfile
+	if err := h.DB.Scopes(moderation.VisibleTalentProfiles).First(&profile, input.TalentProfileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		return
+	}
+	if !h.checkNotBlocked(c, userID.(uint), profile.UserID, "You can't request to represent this talent") {
+		return
+	}
+
+	var open int64
+	h.DB.Model(&models.AgencyRepresentation{}).
+		Where("agency_id = ? AND talent_profile_id = ? AND status IN ?", agency.ID, profile.ID, []string{RepresentationPending, RepresentationActive}).
+		Count(&open)
+	if open > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "This talent is already on the roster or has a pending request"})
+		return
+	}
+
+	representation := models.AgencyRepresentation{
+		AgencyID:          agency.ID,
+		TalentProfileID:   profile.ID,
+		Status:            RepresentationPending,
+		RequestedByUserID: userID.(uint),
+	}
+	if err := h.DB.Create(&representation).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request representation"})
+		return
+	}
+
+	h.notifyUser(profile.UserID, notifications.TypeRepresentationRequested, agency.Name+" wants to represent you",
+		"Accept to let their agents apply to casting calls on your behalf.",
+		"/talent/profiles/"+strconv.FormatUint(uint64(profile.ID), 10)+"/representation")
+	c.JSON(http.StatusCreated, representation)
+}
+
+// GetAgencyRoster lists the agency's representation records, optionally filtered by ?status=.
+func (h *BaseHandler) GetAgencyRoster(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAgent)
+	if !ok {
+		return
+	}
+
+	query := h.DB.Preload("TalentProfile").Where("agency_id = ?", agency.ID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var roster []models.AgencyRepresentation
+	if err := query.Order("created_at desc").Find(&roster).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch agency roster"})
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// endRepresentation ends an active representation or withdraws a pending request.
+func (h *BaseHandler) endRepresentation(c *gin.Context, representation models.AgencyRepresentation, endedBy uint) bool {
+	if representation.Status != RepresentationPending && representation.Status != RepresentationActive {
+		c.JSON(http.StatusConflict, gin.H{"error": "This representation has already ended"})
+		return false
+	}
+
+	if err := h.DB.Model(&representation).Updates(map[string]interface{}{
+		"status":           RepresentationEnded,
+		"ended_at":         time.Now(),
+		"ended_by_user_id": endedBy,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to end representation"})
+		return false
+	}
+	return true
+}
+
+// RemoveFromRoster lets an agency admin end a representation or withdraw a pending request.
+func (h *BaseHandler) RemoveFromRoster(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAdmin)
+	if !ok {
+		return
+	}
+
+	var representation models.AgencyRepresentation
+	if err := h.DB.Preload("TalentProfile").First(&representation, "id = ? AND agency_id = ?", c.Param("rep_id"), agency.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Representation not found"})
+		return
+	}
+
+	wasActive := representation.Status == RepresentationActive
+	if !h.endRepresentation(c, representation, userID.(uint)) {
+		return
+	}
+
+	if wasActive {
+		h.notifyUser(representation.TalentProfile.UserID, notifications.TypeRepresentationEnded, agency.Name+" no longer represents you", "", "")
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Representation ended successfully"})
+}
+
+// --- Talent Representation Handlers ---
+
+// GetMyRepresentation lists the profile's representation requests and agencies, newest first.
+func (h *BaseHandler) GetMyRepresentation(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this profile's representation"})
+		return
+	}
+
+	var representations []models.AgencyRepresentation
+	if err := h.DB.Preload("Agency").Where("talent_profile_id = ?", profileID).Order("created_at desc").Find(&representations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch representation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, representations)
+}
+
+// findMyRepresentation loads the representation in :rep_id for the profile in :id, which the
+// user must own. It writes the error response on failure.
+func (h *BaseHandler) findMyRepresentation(c *gin.Context, userID uint) (models.AgencyRepresentation, bool) {
+	var representation models.AgencyRepresentation
+	if !isProfileOwner(h.DB, userID, c.Param("id")) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to manage this profile's representation"})
+		return representation, false
+	}
+
+	if err := h.DB.Preload("Agency").First(&representation, "id = ? AND talent_profile_id = ?", c.Param("rep_id"), c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Representation not found"})
+		return representation, false
+	}
+	return representation, true
+}
+
+// AcceptRepresentation records the talent's consent. A profile is represented by at most one
+// agency at a time.
+func (h *BaseHandler) AcceptRepresentation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	representation, ok := h.findMyRepresentation(c, userID.(uint))
+	if !ok {
+		return
+	}
+	if representation.Status != RepresentationPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "This request is no longer pending"})
+		return
+	}
+
+	var active int64
+	h.DB.Model(&models.AgencyRepresentation{}).Where("talent_profile_id = ? AND status = ?", representation.TalentProfileID, RepresentationActive).Count(&active)
+	if active > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "End your current representation before accepting another agency"})
+		return
+	}
+
+	if err := h.DB.Model(&representation).Updates(map[string]interface{}{
+		"status":       RepresentationActive,
+		"consented_at": time.Now(),
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept representation"})
+		return
+	}
+
+	h.notifyUser(representation.RequestedByUserID, notifications.TypeRepresentationAccepted, "Your representation request was accepted", "", "/agencies/"+strconv.FormatUint(uint64(representation.AgencyID), 10))
+	c.JSON(http.StatusOK, representation)
+}
+
+func (h *BaseHandler) DeclineRepresentation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	representation, ok := h.findMyRepresentation(c, userID.(uint))
+	if !ok {
+		return
+	}
+	if representation.Status != RepresentationPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "This request is no longer pending"})
+		return
+	}
+
+	if err := h.DB.Model(&representation).Update("status", RepresentationDeclined).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decline representation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, representation)
+}
+
+// EndMyRepresentation lets the talent leave an agency's roster at any time, withdrawing consent.
+func (h *BaseHandler) EndMyRepresentation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	representation, ok := h.findMyRepresentation(c, userID.(uint))
+	if !ok {
+		return
+	}
+	if !h.endRepresentation(c, representation, userID.(uint)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Representation ended successfully"})
+}
+
+// --- Agency Application Handlers ---
+
+type ApplyOnBehalfInput struct {
+	TalentProfileID uint   `json:"talentProfileId" binding:"required"`
+	CastingCallID   uint   `json:"castingCallId" binding:"required"`
+	CoverLetter     string `json:"coverLetter"`
+}
+
+// ApplyOnBehalf lets an agent apply to a casting call for talent the agency actively represents.
+func (h *BaseHandler) ApplyOnBehalf(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAgent)
+	if !ok {
+		return
+	}
+
+	var input ApplyOnBehalfInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var representation models.AgencyRepresentation
+	if err := h.DB.Preload("TalentProfile").First(&representation, "agency_id = ? AND talent_profile_id = ? AND status = ?",
+		agency.ID, input.TalentProfileID, RepresentationActive).Error; err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "This agency does not represent that talent"})
+		return
+	}
+
+	castingCallID := strconv.FormatUint(uint64(input.CastingCallID), 10)
+	application, ok := h.submitApplication(c, representation.TalentProfile, castingCallID, input.CoverLetter, userID.(uint), &agency.ID)
+	if !ok {
+		return
+	}
+
+	h.notifyUser(representation.TalentProfile.UserID, notifications.TypeAgencyApplied, agency.Name+" applied to a casting call for you",
+		"", "/talent/casting-calls/"+castingCallID)
+	c.JSON(http.StatusCreated, application)
+}
+
+// GetAgencyApplications lists applications the agency submitted, optionally for one talent.
+func (h *BaseHandler) GetAgencyApplications(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	agency, ok := h.findAgency(c, userID.(uint), AgencyRoleAgent)
+	if !ok {
+		return
+	}
+
+	query := h.DB.Preload("TalentProfile").Preload("CastingCall").Where("agency_id = ?", agency.ID)
+	if profileID := c.Query("talent_profile_id"); profileID != "" {
+		query = query.Where("talent_profile_id = ?", profileID)
+	}
+
+	var applications []models.Application
+	if err := query.Order("created_at desc").Find(&applications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch agency applications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+// GetApplicationHistory returns the application's audit trail to the talent, their agency and
+// the casting call's team.
+func (h *BaseHandler) GetApplicationHistory(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var application models.Application
+	if err := h.DB.Preload("TalentProfile").First(&application, c.Param("app_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found."})
+		return
+	}
+
+	castingCallIDStr := strconv.FormatUint(uint64(application.CastingCallID), 10)
+	allowed := application.TalentProfile.UserID == userID.(uint) ||
+		(application.AgencyID != nil && isAgencyMember(h.DB, userID.(uint), *application.AgencyID)) ||
+		hasCastingCallRole(h.DB, userID.(uint), castingCallIDStr, CastingRoleViewer)
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this application."})
+		return
+	}
+
+	var events []models.ApplicationEvent
+	if err := h.DB.Preload("Actor").Where("application_id = ?", application.ID).Order("created_at asc").Find(&events).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch application history."})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/analytics"
+	"siddu-verse-backend/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxAnalyticsDays bounds analytics ranges so a single request can't scan years of events.
+const maxAnalyticsDays = 366
+
+// SearchCount is a search term and how many views it led to.
+type SearchCount struct {
+	Search string `json:"search"`
+	Views  int64  `json:"views"`
+}
+
+// ViewBreakdown summarises the views of a profile or casting call over a date range.
+type ViewBreakdown struct {
+	From           string           `json:"from"`
+	To             string           `json:"to"`
+	Views          int64            `json:"views"`
+	UniqueViewers  int64            `json:"uniqueViewers"`
+	ByViewer       map[string]int64 `json:"byViewer"`
+	BySource       map[string]int64 `json:"bySource"`
+	TopSearches    []SearchCount    `json:"topSearches"`
+	Applications   *int64           `json:"applications,omitempty"`   // Casting calls only
+	ConversionRate *float64         `json:"conversionRate,omitempty"` // Casting calls only, percent of unique viewers who applied
+}
+
+// recordView queues a view of the subject. Owners are filtered out when the view is written.
+// Pages linked from search results pass the query as ?ref_search= so it shows up as a referrer.
+func (h *BaseHandler) recordView(c *gin.Context, subjectType string, subjectID uint) {
+	var viewerID *uint
+	if userID, exists := c.Get("userID"); exists {
+		id := userID.(uint)
+		viewerID = &id
+	}
+
+	h.Analytics.Record(analytics.View{
+		SubjectType:  subjectType,
+		SubjectID:    subjectID,
+		ViewerUserID: viewerID,
+		ViewerKey:    analytics.ViewerKey(viewerID, c.ClientIP(), c.Request.UserAgent()),
+		Referrer:     c.Query("ref_search"),
+		At:           time.Now(),
+	})
+}
+
+// analyticsRange reads ?from= and ?to=, defaulting to the last 30 days. It writes the error
+// response on failure.
+func analyticsRange(c *gin.Context) (time.Time, time.Time, bool) {
+	if c.Query("from") == "" && c.Query("to") == "" {
+		to := analytics.Day(time.Now()).AddDate(0, 0, 1)
+		return to.AddDate(0, 0, -30), to, true
+	}
+
+	from, to, err := parseDateRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return from, to, false
+	}
+	from, to = analytics.Day(from), analytics.Day(to.Add(-time.Nanosecond)).AddDate(0, 0, 1)
+	if to.Sub(from) > maxAnalyticsDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Date range cannot exceed " + strconv.Itoa(maxAnalyticsDays) + " days"})
+		return from, to, false
+	}
+	return from, to, true
+}
+
+// loadViewSeries returns one point per day in [from, to) from the daily rollups.
+func loadViewSeries(db *gorm.DB, subjectType string, subjectID uint, from, to time.Time) ([]analytics.DayPoint, error) {
+	var stats []models.DailyViewStat
+	if err := db.Where("subject_type = ? AND subject_id = ? AND day >= ? AND day < ?", subjectType, subjectID, from, to).
+		Order("day").Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	return analytics.FillSeries(stats, from, to), nil
+}
+
+// loadViewBreakdown totals the daily view and referrer rollups and counts unique viewers from
+// the per-viewer rollups, so reads never scan raw view events. A viewer counts towards the
+// range when it overlaps the days between their first and last views of the subject.
+func loadViewBreakdown(db *gorm.DB, subjectType string, subjectID uint, from, to time.Time) (ViewBreakdown, error) {
+	breakdown := ViewBreakdown{
+		From: from.Format("2006-01-02"),
+		To:   to.AddDate(0, 0, -1).Format("2006-01-02"),
+	}
+
+	var totals struct {
+		Views          int64
+		AnonymousViews int64
+		MemberViews    int64
+		RecruiterViews int64
+		SearchViews    int64
+		Applications   int64
+	}
+	if err := db.Model(&models.DailyViewStat{}).
+		Select(`COALESCE(SUM(views), 0) AS views, COALESCE(SUM(anonymous_views), 0) AS anonymous_views,
+			COALESCE(SUM(member_views), 0) AS member_views, COALESCE(SUM(recruiter_views), 0) AS recruiter_views,
+			COALESCE(SUM(search_views), 0) AS search_views, COALESCE(SUM(applications), 0) AS applications`).
+		Where("subject_type = ? AND subject_id = ? AND day >= ? AND day < ?", subjectType, subjectID, from, to).
+		Scan(&totals).Error; err != nil {
+		return breakdown, err
+	}
+
+	if err := db.Model(&models.SubjectViewer{}).
+		Where("subject_type = ? AND subject_id = ? AND first_day < ? AND last_day >= ?", subjectType, subjectID, to, from).
+		Count(&breakdown.UniqueViewers).Error; err != nil {
+		return breakdown, err
+	}
+
+	breakdown.TopSearches = []SearchCount{}
+	if err := db.Model(&models.DailyReferrerStat{}).
+		Select("referrer AS search, SUM(views) AS views").
+		Where("subject_type = ? AND subject_id = ? AND day >= ? AND day < ?", subjectType, subjectID, from, to).
+		Group("referrer").
+		Order("views desc, search").
+		Limit(10).
+		Scan(&breakdown.TopSearches).Error; err != nil {
+		return breakdown, err
+	}
+
+	breakdown.Views = totals.Views
+	breakdown.ByViewer = map[string]int64{
+		analytics.ViewerAnonymous: totals.AnonymousViews,
+		analytics.ViewerMember:    totals.MemberViews,
+		analytics.ViewerRecruiter: totals.RecruiterViews,
+	}
+	breakdown.BySource = map[string]int64{
+		"search": totals.SearchViews,
+		"direct": totals.Views - totals.SearchViews,
+	}
+
+	if subjectType == analytics.SubjectCastingCall {
+		rate := analytics.ConversionRate(totals.Applications, breakdown.UniqueViewers)
+		breakdown.Applications = &totals.Applications
+		breakdown.ConversionRate = &rate
+	}
+	return breakdown, nil
+}
+
+// --- View Analytics Handlers ---
+// Figures come from rollups refreshed every few minutes, so the latest views may lag slightly.
+
+func (h *BaseHandler) GetProfileViewSeries(c *gin.Context) {
+	h.respondViewSeries(c, analytics.SubjectTalentProfile, h.authorizeProfileAnalytics)
+}
+
+func (h *BaseHandler) GetProfileViewBreakdown(c *gin.Context) {
+	h.respondViewBreakdown(c, analytics.SubjectTalentProfile, h.authorizeProfileAnalytics)
+}
+
+func (h *BaseHandler) GetCastingCallViewSeries(c *gin.Context) {
+	h.respondViewSeries(c, analytics.SubjectCastingCall, h.authorizeCastingCallAnalytics)
+}
+
+func (h *BaseHandler) GetCastingCallViewBreakdown(c *gin.Context) {
+	h.respondViewBreakdown(c, analytics.SubjectCastingCall, h.authorizeCastingCallAnalytics)
+}
+
+// authorizeProfileAnalytics lets only the profile's owner see its analytics.
+func (h *BaseHandler) authorizeProfileAnalytics(c *gin.Context) (uint, bool) {
+	userID, _ := c.Get("userID")
+	if !isProfileOwner(h.DB, userID.(uint), c.Param("id")) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this profile's analytics"})
+		return 0, false
+	}
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	return uint(id), true
+}
+
+// authorizeCastingCallAnalytics lets anyone on the casting call's team see its analytics.
+func (h *BaseHandler) authorizeCastingCallAnalytics(c *gin.Context) (uint, bool) {
+	userID, _ := c.Get("userID")
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleViewer)
+	return call.ID, ok
+}
+
+func (h *BaseHandler) respondViewSeries(c *gin.Context, subjectType string, authorize func(*gin.Context) (uint, bool)) {
+	subjectID, ok := authorize(c)
+	if !ok {
+		return
+	}
+	from, to, ok := analyticsRange(c)
+	if !ok {
+		return
+	}
+
+	series, err := loadViewSeries(h.DB, subjectType, subjectID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch view analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (h *BaseHandler) respondViewBreakdown(c *gin.Context, subjectType string, authorize func(*gin.Context) (uint, bool)) {
+	subjectID, ok := authorize(c)
+	if !ok {
+		return
+	}
+	from, to, ok := analyticsRange(c)
+	if !ok {
+		return
+	}
+
+	breakdown, err := loadViewBreakdown(h.DB, subjectType, subjectID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch view analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/calendar"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Availability entry kinds.
+const (
+	AvailabilityUnavailable = "unavailable"
+	AvailabilityHold        = "hold"
+	AvailabilityBooking     = "booking"
+)
+
+// defaultHoldDuration is how long a hold lasts when the recruiter doesn't set an expiry.
+const defaultHoldDuration = 7 * 24 * time.Hour
+
+// parseDateRange reads a from/to pair given as RFC 3339 timestamps or plain dates.
+// A plain "to" date is inclusive, so it is moved to the end of that day.
+func parseDateRange(from, to string) (time.Time, time.Time, error) {
+	parse := func(value string, endOfDay bool) (time.Time, error) {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return t, fmt.Errorf("invalid date %q, use YYYY-MM-DD or RFC 3339", value)
+		}
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	if from == "" || to == "" {
+		return time.Time{}, time.Time{}, errors.New("from and to are required")
+	}
+	start, err := parse(from, false)
+	if err != nil {
+		return start, start, err
+	}
+	end, err := parse(to, true)
+	if err != nil {
+		return start, end, err
+	}
+	if !end.After(start) {
+		return start, end, errors.New("to must be after from")
+	}
+	return start, end, nil
+}
+
+// activeAvailability filters out holds that have expired.
+func activeAvailability(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("kind <> ? OR expires_at IS NULL OR expires_at > ?", AvailabilityHold, now)
+}
+
+// findConflicts returns the active entries of the given kinds that overlap [start, end).
+func findConflicts(db *gorm.DB, profileID uint, start, end time.Time, kinds []string, excludeID uint) ([]models.AvailabilityEntry, error) {
+	var conflicts []models.AvailabilityEntry
+	err := activeAvailability(db, time.Now()).
+		Where("talent_profile_id = ? AND kind IN ? AND starts_at < ? AND ends_at > ? AND id <> ?", profileID, kinds, end, start, excludeID).
+		Order("starts_at").
+		Find(&conflicts).Error
+	return conflicts, err
+}
+
+// redactAvailability hides the details of entries the viewer has no business seeing,
+// leaving just the busy block.
+func redactAvailability(entry models.AvailabilityEntry, viewerID uint, isOwner bool) models.AvailabilityEntry {
+	if isOwner || entry.CreatedByUserID == viewerID {
+		return entry
+	}
+	entry.Title = availabilityLabel(entry.Kind)
+	entry.Note = ""
+	entry.CastingCallID = nil
+	return entry
+}
+
+func availabilityLabel(kind string) string {
+	switch kind {
+	case AvailabilityHold:
+		return "Tentative hold"
+	case AvailabilityBooking:
+		return "Booked"
+	default:
+		return "Unavailable"
+	}
+}
+
+// --- Availability Calendar Handlers ---
+
+func (h *BaseHandler) GetAvailability(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	start, end, err := parseDateRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(userID.(uint), "talent_profiles.user_id")).First(&profile, profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
+		return
+	}
+
+	var entries []models.AvailabilityEntry
+	if err := activeAvailability(h.DB, time.Now()).
+		Where("talent_profile_id = ? AND starts_at < ? AND ends_at > ?", profile.ID, end, start).
+		Order("starts_at").
+		Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch availability"})
+		return
+	}
+
+	isOwner := profile.UserID == userID.(uint)
+	for i := range entries {
+		entries[i] = redactAvailability(entries[i], userID.(uint), isOwner)
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+type AvailabilityInput struct {
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required,gtfield=StartsAt"`
+	Title    string    `json:"title"`
+	Note     string    `json:"note"`
+}
+
+// AddUnavailability lets talent block out time they can't work.
+func (h *BaseHandler) AddUnavailability(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to modify this profile"})
+		return
+	}
+
+	var input AvailabilityInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profileIDUint, _ := strconv.ParseUint(profileID, 10, 32)
+	conflicts, err := findConflicts(h.DB, uint(profileIDUint), input.StartsAt, input.EndsAt, []string{AvailabilityBooking}, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check availability"})
+		return
+	}
+	if len(conflicts) > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "This time overlaps a confirmed booking", "conflicts": conflicts})
+		return
+	}
+
+	entry := models.AvailabilityEntry{
+		TalentProfileID: uint(profileIDUint),
+		Kind:            AvailabilityUnavailable,
+		StartsAt:        input.StartsAt,
+		EndsAt:          input.EndsAt,
+		Title:           input.Title,
+		Note:            input.Note,
+		CreatedByUserID: userID.(uint),
+	}
+	if err := h.DB.Create(&entry).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add unavailability"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+type PlaceHoldInput struct {
+	AvailabilityInput
+	CastingCallID uint       `json:"castingCallId" binding:"required"`
+	ExpiresAt     *time.Time `json:"expiresAt"`
+}
+
+// PlaceHold lets a recruiter tentatively reserve talent for a shoot window.
+func (h *BaseHandler) PlaceHold(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	var input PlaceHoldInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	castingCallID := strconv.FormatUint(uint64(input.CastingCallID), 10)
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleReviewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only place holds for casting calls you recruit for"})
+		return
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.Scopes(moderation.VisibleTalentProfiles).First(&profile, profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
+		return
+	}
+	if !h.checkNotBlocked(c, userID.(uint), profile.UserID, "You can't place a hold on this profile") {
+		return
+	}
+
+	expiresAt := time.Now().Add(defaultHoldDuration)
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Hold expiry must be in the future"})
+			return
+		}
+		expiresAt = *input.ExpiresAt
+	}
+
+	// Holds may stack on top of each other, but not on time the talent has blocked or booked
+	conflicts, err := findConflicts(h.DB, profile.ID, input.StartsAt, input.EndsAt, []string{AvailabilityUnavailable, AvailabilityBooking}, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check availability"})
+		return
+	}
+	if len(conflicts) > 0 {
+		for i := range conflicts {
+			conflicts[i] = redactAvailability(conflicts[i], userID.(uint), false)
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": "Talent is not available for this time", "conflicts": conflicts})
+		return
+	}
+
+	title := input.Title
+	if title == "" {
+		var call models.CastingCall
+		h.DB.First(&call, input.CastingCallID)
+		title = "Hold: " + call.ProjectTitle
+	}
+
+	entry := models.AvailabilityEntry{
+		TalentProfileID: profile.ID,
+		Kind:            AvailabilityHold,
+		StartsAt:        input.StartsAt,
+		EndsAt:          input.EndsAt,
+		Title:           title,
+		Note:            input.Note,
+		CastingCallID:   &input.CastingCallID,
+		CreatedByUserID: userID.(uint),
+		ExpiresAt:       &expiresAt,
+	}
+	if err := h.DB.Create(&entry).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to place hold"})
+		return
+	}
+
+	body := fmt.Sprintf("%s to %s. The hold expires on %s.",
+		entry.StartsAt.Format("Jan 2, 2006"), entry.EndsAt.Format("Jan 2, 2006"), expiresAt.Format("Jan 2, 2006"))
+	if err := notifications.Send(h.DB, []uint{profile.UserID}, notifications.TypeAvailabilityHold, "New hold: "+title, body,
+		"/talent/profiles/"+profileID+"/availability"); err != nil {
+		log.Printf("Failed to notify talent profile %d of hold %d: %v", profile.ID, entry.ID, err)
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// ConfirmHold lets talent accept a hold, turning it into a confirmed booking.
+func (h *BaseHandler) ConfirmHold(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to modify this profile"})
+		return
+	}
+
+	var entry models.AvailabilityEntry
+	if err := h.DB.First(&entry, "id = ? AND talent_profile_id = ? AND kind = ?", c.Param("entry_id"), profileID, AvailabilityHold).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Hold not found"})
+		return
+	}
+	if entry.ExpiresAt != nil && !entry.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This hold has expired"})
+		return
+	}
+
+	conflicts, err := findConflicts(h.DB, entry.TalentProfileID, entry.StartsAt, entry.EndsAt, []string{AvailabilityUnavailable, AvailabilityBooking}, entry.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check availability"})
+		return
+	}
+	if len(conflicts) > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "This hold overlaps time that is already blocked or booked", "conflicts": conflicts})
+		return
+	}
+
+	if err := h.DB.Model(&entry).Updates(map[string]interface{}{"kind": AvailabilityBooking, "expires_at": nil}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to confirm hold"})
+		return
+	}
+
+	if err := notifications.Send(h.DB, []uint{entry.CreatedByUserID}, notifications.TypeAvailabilityBooking, "Hold confirmed: "+entry.Title,
+		"The talent confirmed your hold. It is now a booking.", "/talent/profiles/"+profileID+"/availability"); err != nil {
+		log.Printf("Failed to notify user %d of confirmed hold %d: %v", entry.CreatedByUserID, entry.ID, err)
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// RemoveAvailabilityEntry deletes an entry. Talent can remove anything on their calendar;
+// recruiters can release the holds and bookings they placed.
+func (h *BaseHandler) RemoveAvailabilityEntry(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	var entry models.AvailabilityEntry
+	if err := h.DB.First(&entry, "id = ? AND talent_profile_id = ?", c.Param("entry_id"), profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Availability entry not found"})
+		return
+	}
+
+	if entry.CreatedByUserID != userID.(uint) && !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to remove this entry"})
+		return
+	}
+
+	if err := h.DB.Delete(&entry).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove availability entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Availability entry removed successfully"})
+}
+
+// FindAvailableTalent returns the talent profiles that are free for the whole date range.
+// Holds only count as busy with ?include_holds=true.
+func (h *BaseHandler) FindAvailableTalent(c *gin.Context) {
+	start, end, err := parseDateRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	busyKinds := []string{AvailabilityUnavailable, AvailabilityBooking}
+	if c.Query("include_holds") == "true" {
+		busyKinds = append(busyKinds, AvailabilityHold)
+	}
+
+	busy := activeAvailability(h.DB.Model(&models.AvailabilityEntry{}), time.Now()).
+		Select("talent_profile_id").
+		Where("kind IN ? AND starts_at < ? AND ends_at > ?", busyKinds, end, start)
+
+	var profiles []models.TalentProfile
+	if err := h.DB.Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(viewerID(c), "talent_profiles.user_id")).
+		Where("id NOT IN (?)", busy).Find(&profiles).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch available talent"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+// ExportAvailabilityICS serves the talent's calendar as an iCalendar feed. Only the owner
+// sees the details; everyone else sees busy blocks.
+func (h *BaseHandler) ExportAvailabilityICS(c *gin.Context) {
+	profileID := c.Param("id")
+	viewer := viewerID(c)
+
+	var profile models.TalentProfile
+	if err := h.DB.Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(viewer, "talent_profiles.user_id")).First(&profile, profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
+		return
+	}
+
+	isOwner := viewer != 0 && profile.UserID == viewer
+
+	var entries []models.AvailabilityEntry
+	if err := activeAvailability(h.DB, time.Now()).
+		Where("talent_profile_id = ? AND ends_at > ?", profile.ID, time.Now().AddDate(0, -1, 0)).
+		Order("starts_at").
+		Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch availability"})
+		return
+	}
+
+	events := make([]calendar.Event, 0, len(entries))
+	for _, entry := range entries {
+		entry = redactAvailability(entry, viewer, isOwner)
+		if entry.Title == "" {
+			entry.Title = availabilityLabel(entry.Kind)
+		}
+		status := "CONFIRMED"
+		if entry.Kind == AvailabilityHold {
+			status = "TENTATIVE"
+		}
+		events = append(events, calendar.Event{
+			UID:         fmt.Sprintf("availability-%d@siddu-verse", entry.ID),
+			Summary:     entry.Title,
+			Description: entry.Note,
+			Start:       entry.StartsAt,
+			End:         entry.EndsAt,
+			Status:      status,
+		})
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=talent-%d-availability.ics", profile.ID))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar.Encode(profile.FullName+" availability", events)))
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"siddu-verse-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDateRange(t *testing.T) {
+	// Test case: Plain dates include the whole "to" day
+	start, end, err := parseDateRange("2025-03-01", "2025-03-03")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), end)
+
+	// Test case: RFC 3339 timestamps are used as-is
+	start, end, err = parseDateRange("2025-03-01T09:00:00Z", "2025-03-01T17:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, 8*time.Hour, end.Sub(start))
+
+	_, _, err = parseDateRange("2025-03-03", "2025-03-01T00:00:00Z")
+	assert.Error(t, err)
+	_, _, err = parseDateRange("", "2025-03-01")
+	assert.Error(t, err)
+	_, _, err = parseDateRange("next tuesday", "2025-03-01")
+	assert.Error(t, err)
+}
+
+func TestRedactAvailability(t *testing.T) {
+	callID := uint(7)
+	hold := models.AvailabilityEntry{Kind: AvailabilityHold, Title: "Hold: Secret Project", Note: "Day 1", CastingCallID: &callID, CreatedByUserID: 2}
+
+	// Test case: The recruiter who placed the hold sees its details
+	assert.Equal(t, "Hold: Secret Project", redactAvailability(hold, 2, false).Title)
+
+	// Test case: Other users only see a busy block
+	redacted := redactAvailability(hold, 3, false)
+	assert.Equal(t, "Tentative hold", redacted.Title)
+	assert.Empty(t, redacted.Note)
+	assert.Nil(t, redacted.CastingCallID)
+}
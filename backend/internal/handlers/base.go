@@ -1,15 +1,36 @@
 package handlers
 
 import (
+	"siddu-verse-backend/internal/analytics"
+	"siddu-verse-backend/internal/mailer"
+	"siddu-verse-backend/internal/realtime"
+	"siddu-verse-backend/internal/screening"
+	"siddu-verse-backend/internal/storage"
+	"siddu-verse-backend/internal/webpush"
+
 	"gorm.io/gorm"
 )
 
 // BaseHandler will hold a reference to the database connection
 type BaseHandler struct {
-	DB *gorm.DB
+	DB        *gorm.DB
+	Mailer    mailer.Mailer
+	Realtime  *realtime.Hub
+	Analytics *analytics.Recorder
+	Storage   storage.Storage
+	Screener  screening.ContentScreener
+	Pusher    webpush.Pusher
 }
 
 // NewBaseHandler creates a new handler with a database connection.
 func NewBaseHandler(db *gorm.DB) *BaseHandler {
-	return &BaseHandler{DB: db}
+	return &BaseHandler{
+		DB:        db,
+		Mailer:    mailer.NewFromEnv(),
+		Realtime:  realtime.NewHub(),
+		Analytics: analytics.NewRecorder(db),
+		Storage:   storage.NewFromEnv(),
+		Screener:  screening.NewFromEnv(db),
+		Pusher:    webpush.NewFromEnv(),
+	}
 }
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/feed"
+	"siddu-verse-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// checkNotBlocked writes a 403 with the message and returns false when either user has
+// blocked the other.
+func (h *BaseHandler) checkNotBlocked(c *gin.Context, userID, otherID uint, message string) bool {
+	blocked, err := blocks.Between(h.DB, userID, otherID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not check blocked users"})
+		return false
+	}
+	if blocked {
+		c.JSON(http.StatusForbidden, gin.H{"error": message})
+		return false
+	}
+	return true
+}
+
+// findOtherUser loads the user in the :id param, refusing the signed-in user themself. It
+// writes the error response on failure.
+func (h *BaseHandler) findOtherUser(c *gin.Context, userID uint, action string) (models.User, bool) {
+	var target models.User
+	if err := h.DB.First(&target, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return target, false
+	}
+	if target.ID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot " + action + " yourself"})
+		return target, false
+	}
+	return target, true
+}
+
+// --- Block Handlers ---
+
+// BlockUser blocks the user and removes any follows between the two, in either direction,
+// along with their pulses in each other's feeds. Unblocking doesn't restore the follows.
+func (h *BaseHandler) BlockUser(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	target, ok := h.findOtherUser(c, userID.(uint), "block")
+	if !ok {
+		return
+	}
+
+	block := models.Block{BlockerID: userID.(uint), BlockedID: target.ID}
+	created := false
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&block)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		created = true
+
+		var follows []models.Follow
+		if err := tx.Where("(follower_id = ? AND author_user_id = ?) OR (follower_id = ? AND author_user_id = ?)",
+			userID.(uint), target.ID, target.ID, userID.(uint)).Find(&follows).Error; err != nil {
+			return err
+		}
+		for _, follow := range follows {
+			if err := removeFollow(tx, follow); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to block user"})
+		return
+	}
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"message": "Already blocked"})
+		return
+	}
+
+	for _, pair := range [][2]uint{{userID.(uint), target.ID}, {target.ID, userID.(uint)}} {
+		if err := feed.RemoveAuthor(h.DB, pair[0], pair[1]); err != nil {
+			log.Printf("Failed to remove user %d from the feed of user %d: %v", pair[1], pair[0], err)
+		}
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "User blocked"})
+}
+
+func (h *BaseHandler) UnblockUser(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	// Hard delete so the user can be blocked again
+	result := h.DB.Unscoped().Where("blocker_id = ? AND blocked_id = ?", userID.(uint), c.Param("id")).Delete(&models.Block{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unblock user"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You have not blocked this user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unblocked"})
+}
+
+// GetBlockedUsers lists the users the signed-in user has blocked, newest first. Page back with
+// ?before=<block id>.
+func (h *BaseHandler) GetBlockedUsers(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	before, limit := pageParams(c, 50)
+
+	query := h.DB.Preload("Blocked").Where("blocker_id = ?", userID.(uint))
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	blocked := []models.Block{}
+	if err := query.Order("id desc").Limit(limit).Find(&blocked).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch blocked users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, blocked)
+}
+
+// --- Mute Handlers ---
+
+// MuteUser keeps the user's pulses out of the signed-in user's feeds. Unlike blocking, the
+// muted user can still see and interact with the muter, and isn't told.
+func (h *BaseHandler) MuteUser(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	target, ok := h.findOtherUser(c, userID.(uint), "mute")
+	if !ok {
+		return
+	}
+
+	mute := models.Mute{MuterID: userID.(uint), MutedID: target.ID}
+	result := h.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&mute)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mute user"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "Already muted"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "User muted"})
+}
+
+func (h *BaseHandler) UnmuteUser(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	// Hard delete so the user can be muted again
+	result := h.DB.Unscoped().Where("muter_id = ? AND muted_id = ?", userID.(uint), c.Param("id")).Delete(&models.Mute{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unmute user"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You have not muted this user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unmuted"})
+}
+
+// GetMutedUsers lists the users the signed-in user has muted, newest first. Page back with
+// ?before=<mute id>.
+func (h *BaseHandler) GetMutedUsers(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	before, limit := pageParams(c, 50)
+
+	query := h.DB.Preload("Muted").Where("muter_id = ?", userID.(uint))
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	muted := []models.Mute{}
+	if err := query.Order("id desc").Limit(limit).Find(&muted).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch muted users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, muted)
+}
@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/feed"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxBookmarkFolders caps how many folders one user can create.
+const maxBookmarkFolders = 100
+
+// markBookmarked flags the pulses, and the originals they repost or quote, that the viewer has
+// bookmarked, with one query for the whole listing.
+func (h *BaseHandler) markBookmarked(viewerID uint, pulses []*models.Pulse) {
+	if viewerID == 0 || len(pulses) == 0 {
+		return
+	}
+
+	shown := make([]*models.Pulse, 0, len(pulses))
+	for _, pulse := range pulses {
+		shown = append(shown, pulse)
+		if pulse.OriginalPulse != nil {
+			shown = append(shown, pulse.OriginalPulse)
+		}
+	}
+	ids := make([]uint, 0, len(shown))
+	for _, pulse := range shown {
+		ids = append(ids, pulse.ID)
+	}
+
+	var bookmarkedIDs []uint
+	h.DB.Model(&models.Bookmark{}).Where("user_id = ? AND pulse_id IN ?", viewerID, ids).Pluck("pulse_id", &bookmarkedIDs)
+	bookmarked := make(map[uint]bool, len(bookmarkedIDs))
+	for _, id := range bookmarkedIDs {
+		bookmarked[id] = true
+	}
+	for _, pulse := range shown {
+		pulse.Bookmarked = bookmarked[pulse.ID]
+	}
+}
+
+// findBookmarkFolder loads the user's folder with the given ID. It writes the error response
+// on failure.
+func (h *BaseHandler) findBookmarkFolder(c *gin.Context, userID uint, folderID interface{}) (models.BookmarkFolder, bool) {
+	var folder models.BookmarkFolder
+	if err := h.DB.First(&folder, "id = ? AND user_id = ?", folderID, userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bookmark folder not found"})
+		return folder, false
+	}
+	return folder, true
+}
+
+// --- Bookmark Handlers ---
+
+type BookmarkInput struct {
+	FolderID *uint `json:"folderId"` // Leave out to keep the bookmark unfiled
+}
+
+// BookmarkPulse privately saves the pulse for the user, or moves an existing bookmark to the
+// given folder. Bookmarking a repost saves the pulse it reposts.
+func (h *BaseHandler) BookmarkPulse(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input BookmarkInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if input.FolderID != nil {
+		if _, ok := h.findBookmarkFolder(c, userID.(uint), *input.FolderID); !ok {
+			return
+		}
+	}
+
+	pulse, ok := h.findShareablePulse(c)
+	if !ok {
+		return
+	}
+
+	bookmark := models.Bookmark{UserID: userID.(uint), PulseID: pulse.ID, FolderID: input.FolderID}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "pulse_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"folder_id", "updated_at"}),
+		}).Create(&bookmark).Error; err != nil {
+			return err
+		}
+		return tx.First(&bookmark, "user_id = ? AND pulse_id = ?", userID.(uint), pulse.ID).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to bookmark pulse"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bookmark)
+}
+
+func (h *BaseHandler) RemoveBookmark(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	// Hard delete so the pulse can be bookmarked again
+	result := h.DB.Unscoped().Where("user_id = ? AND pulse_id = ?", userID.(uint), c.Param("id")).Delete(&models.Bookmark{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove bookmark"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You have not bookmarked this pulse"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bookmark removed"})
+}
+
+// GetBookmarks lists the user's bookmarks with their pulses, most recently saved first. Filter
+// with ?folderId=<folder id>, or ?folderId=none for unfiled bookmarks, and page back with
+// ?before=<bookmark id>. Bookmarks of pulses that are no longer available are left out.
+func (h *BaseHandler) GetBookmarks(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	before, limit := pageParams(c, 20)
+
+	query := h.DB.Model(&models.Bookmark{}).
+		Joins("JOIN pulses ON pulses.id = bookmarks.pulse_id AND pulses.deleted_at IS NULL").
+		Scopes(moderation.VisiblePulses, blocks.Exclude(userID.(uint), "pulses.user_id")).
+		Where("bookmarks.user_id = ?", userID.(uint))
+	switch folder := c.Query("folderId"); folder {
+	case "":
+	case "none":
+		query = query.Where("bookmarks.folder_id IS NULL")
+	default:
+		folderID, err := strconv.ParseUint(folder, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid folderId"})
+			return
+		}
+		query = query.Where("bookmarks.folder_id = ?", folderID)
+	}
+	if before > 0 {
+		query = query.Where("bookmarks.id < ?", before)
+	}
+	bookmarks := []models.Bookmark{}
+	if err := query.Order("bookmarks.id desc").Limit(limit).Find(&bookmarks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch bookmarks"})
+		return
+	}
+
+	if len(bookmarks) > 0 {
+		pulseIDs := make([]uint, 0, len(bookmarks))
+		for _, bookmark := range bookmarks {
+			pulseIDs = append(pulseIDs, bookmark.PulseID)
+		}
+		var pulses []models.Pulse
+		if err := h.DB.Scopes(feed.Details(userID.(uint))).Where("id IN ?", pulseIDs).Find(&pulses).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch bookmarks"})
+			return
+		}
+		h.preparePulses(userID.(uint), pulseRefs(pulses)...)
+		byID := make(map[uint]*models.Pulse, len(pulses))
+		for i := range pulses {
+			byID[pulses[i].ID] = &pulses[i]
+		}
+		for i := range bookmarks {
+			bookmarks[i].Pulse = byID[bookmarks[i].PulseID]
+		}
+	}
+
+	c.JSON(http.StatusOK, bookmarks)
+}
+
+// --- Bookmark Folder Handlers ---
+
+type BookmarkFolderInput struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+// GetBookmarkFolders lists the user's folders by name, with how many bookmarks each holds.
+func (h *BaseHandler) GetBookmarkFolders(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	folders := []models.BookmarkFolder{}
+	if err := h.DB.Where("user_id = ?", userID.(uint)).Order("name").Find(&folders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch bookmark folders"})
+		return
+	}
+
+	var counts []struct {
+		FolderID uint
+		Count    int64
+	}
+	if err := h.DB.Model(&models.Bookmark{}).Select("folder_id, COUNT(*) AS count").
+		Where("user_id = ? AND folder_id IS NOT NULL", userID.(uint)).Group("folder_id").Scan(&counts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch bookmark folders"})
+		return
+	}
+	byFolder := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		byFolder[count.FolderID] = count.Count
+	}
+	for i := range folders {
+		folders[i].BookmarkCount = byFolder[folders[i].ID]
+	}
+
+	c.JSON(http.StatusOK, folders)
+}
+
+func (h *BaseHandler) CreateBookmarkFolder(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input BookmarkFolderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var count int64
+	h.DB.Model(&models.BookmarkFolder{}).Where("user_id = ?", userID.(uint)).Count(&count)
+	if count >= maxBookmarkFolders {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You can have at most " + strconv.Itoa(maxBookmarkFolders) + " bookmark folders"})
+		return
+	}
+	if !h.checkBookmarkFolderName(c, userID.(uint), input.Name, 0) {
+		return
+	}
+
+	folder := models.BookmarkFolder{UserID: userID.(uint), Name: input.Name}
+	if err := h.DB.Create(&folder).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create bookmark folder"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, folder)
+}
+
+// checkBookmarkFolderName writes a 409 and returns false when the user already has another
+// folder with the name.
+func (h *BaseHandler) checkBookmarkFolderName(c *gin.Context, userID uint, name string, folderID uint) bool {
+	var taken int64
+	h.DB.Model(&models.BookmarkFolder{}).Where("user_id = ? AND name = ? AND id <> ?", userID, name, folderID).Count(&taken)
+	if taken > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "You already have a bookmark folder with this name"})
+		return false
+	}
+	return true
+}
+
+func (h *BaseHandler) RenameBookmarkFolder(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	folder, ok := h.findBookmarkFolder(c, userID.(uint), c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var input BookmarkFolderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.checkBookmarkFolderName(c, userID.(uint), input.Name, folder.ID) {
+		return
+	}
+
+	if err := h.DB.Model(&folder).Update("name", input.Name).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rename bookmark folder"})
+		return
+	}
+
+	c.JSON(http.StatusOK, folder)
+}
+
+// DeleteBookmarkFolder deletes the folder. Its bookmarks are kept, unfiled.
+func (h *BaseHandler) DeleteBookmarkFolder(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	folder, ok := h.findBookmarkFolder(c, userID.(uint), c.Param("id"))
+	if !ok {
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Bookmark{}).Where("folder_id = ?", folder.ID).Update("folder_id", nil).Error; err != nil {
+			return err
+		}
+		// Hard delete so the name can be used again
+		return tx.Unscoped().Delete(&folder).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete bookmark folder"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bookmark folder deleted"})
+}
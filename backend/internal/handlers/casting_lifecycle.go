@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/notifications"
+	"siddu-verse-backend/internal/screening"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// isAcceptingApplications reports whether talent can still apply to the casting call.
+// The deadline is checked directly so calls are refused even before the scheduler closes them.
+func isAcceptingApplications(call models.CastingCall, now time.Time) bool {
+	if call.Status != models.CastingStatusPublished {
+		return false
+	}
+	return call.ApplicationDeadline == nil || call.ApplicationDeadline.After(now)
+}
+
+// findTeamCastingCall loads the casting call in the :id param and verifies the user holds
+// at least minRole on its team. It writes the error response on failure.
+func (h *BaseHandler) findTeamCastingCall(c *gin.Context, userID uint, minRole string) (models.CastingCall, bool) {
+	var call models.CastingCall
+	if err := h.DB.First(&call, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Casting call not found"})
+		return call, false
+	}
+
+	if !hasCastingCallRole(h.DB, userID, c.Param("id"), minRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to manage this casting call"})
+		return call, false
+	}
+	return call, true
+}
+
+// notifyApplicants lets everyone who applied to the call know about a change. Failures are
+// logged rather than returned so they never undo the change itself.
+func (h *BaseHandler) notifyApplicants(call models.CastingCall, notificationType, title, body string) {
+	if err := notifications.NotifyCastingCallApplicants(h.DB, call.ID, notificationType, title, body); err != nil {
+		log.Printf("Failed to notify applicants of casting call %d: %v", call.ID, err)
+	}
+}
+
+// --- Casting Call Lifecycle Handlers ---
+
+type UpdateCastingCallInput struct {
+	ProjectTitle string     `json:"projectTitle" binding:"required"`
+	ProjectType  string     `json:"projectType"`
+	Description  string     `json:"description"`
+	StartDate    *time.Time `json:"startDate"`
+}
+
+func (h *BaseHandler) UpdateCastingCall(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+	if call.Status == models.CastingStatusArchived {
+		c.JSON(http.StatusConflict, gin.H{"error": "Archived casting calls cannot be edited"})
+		return
+	}
+
+	var input UpdateCastingCallInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	screeningText := strings.Join([]string{input.ProjectTitle, input.ProjectType, input.Description}, "\n")
+	screened, ok := h.screenEdit(c, call.PostedByUserID, "casting_calls", call.ID, screeningText)
+	if !ok {
+		return
+	}
+	updates := map[string]interface{}{
+		"project_title": input.ProjectTitle,
+		"project_type":  input.ProjectType,
+		"description":   input.Description,
+		"start_date":    input.StartDate,
+	}
+	// An edit held for review hides the call; an allowed one leaves a moderator's hide in place
+	if hiddenAt := heldAt(screened); hiddenAt != nil {
+		updates["hidden_at"] = hiddenAt
+	}
+
+	if err := h.DB.Model(&call).Updates(updates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update casting call"})
+		return
+	}
+	h.recordScreening(call.PostedByUserID, "casting_calls", call.ID, screeningText, screened)
+
+	if call.Status != models.CastingStatusDraft && screened.Verdict != screening.Hold {
+		h.notifyApplicants(call, notifications.TypeCastingUpdated,
+			"Casting call updated: "+call.ProjectTitle,
+			"The details of a casting call you applied to have changed.")
+	}
+
+	c.JSON(screenedStatus(screened, http.StatusOK), call)
+}
+
+func (h *BaseHandler) PublishCastingCall(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+	if call.Status != models.CastingStatusDraft {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft casting calls can be published"})
+		return
+	}
+
+	now := time.Now()
+	if call.ApplicationDeadline != nil && !call.ApplicationDeadline.After(now) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Application deadline must be in the future"})
+		return
+	}
+
+	if err := h.DB.Model(&call).Updates(map[string]interface{}{"status": models.CastingStatusPublished, "published_at": now}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to publish casting call"})
+		return
+	}
+
+	c.JSON(http.StatusOK, call)
+}
+
+func (h *BaseHandler) CloseCastingCall(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+	if call.Status != models.CastingStatusPublished {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only published casting calls can be closed"})
+		return
+	}
+
+	if err := h.DB.Model(&call).Updates(map[string]interface{}{"status": models.CastingStatusClosed, "closed_at": time.Now()}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to close casting call"})
+		return
+	}
+
+	h.notifyApplicants(call, notifications.TypeCastingClosed,
+		"Applications closed for "+call.ProjectTitle,
+		"This casting call is no longer accepting applications.")
+
+	c.JSON(http.StatusOK, call)
+}
+
+type ReopenCastingCallInput struct {
+	ApplicationDeadline *time.Time `json:"applicationDeadline"`
+}
+
+func (h *BaseHandler) ReopenCastingCall(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+	if call.Status != models.CastingStatusClosed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only closed casting calls can be reopened"})
+		return
+	}
+
+	var input ReopenCastingCallInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A call closed by its deadline needs a new one, otherwise the scheduler closes it again
+	deadline := call.ApplicationDeadline
+	if input.ApplicationDeadline != nil {
+		deadline = input.ApplicationDeadline
+	}
+	if deadline != nil && !deadline.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A future application deadline is required to reopen this casting call"})
+		return
+	}
+
+	if err := h.DB.Model(&call).Updates(map[string]interface{}{
+		"status":               models.CastingStatusPublished,
+		"application_deadline": deadline,
+		"closed_at":            nil,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reopen casting call"})
+		return
+	}
+
+	h.notifyApplicants(call, notifications.TypeCastingReopened,
+		"Casting call reopened: "+call.ProjectTitle,
+		"A casting call you applied to is accepting applications again.")
+
+	c.JSON(http.StatusOK, call)
+}
+
+type ExtendCastingCallInput struct {
+	ApplicationDeadline time.Time `json:"applicationDeadline" binding:"required"`
+}
+
+func (h *BaseHandler) ExtendCastingCallDeadline(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+	if call.Status != models.CastingStatusPublished && call.Status != models.CastingStatusDraft {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft or published casting calls can be extended; reopen closed calls instead"})
+		return
+	}
+
+	var input ExtendCastingCallInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !input.ApplicationDeadline.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Application deadline must be in the future"})
+		return
+	}
+	if call.ApplicationDeadline != nil && !input.ApplicationDeadline.After(*call.ApplicationDeadline) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "New deadline must be later than the current deadline"})
+		return
+	}
+
+	if err := h.DB.Model(&call).Update("application_deadline", input.ApplicationDeadline).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to extend deadline"})
+		return
+	}
+
+	if call.Status == models.CastingStatusPublished {
+		h.notifyApplicants(call, notifications.TypeCastingExtended,
+			"Deadline extended for "+call.ProjectTitle,
+			fmt.Sprintf("Applications are now open until %s.", input.ApplicationDeadline.Format("January 2, 2006")))
+	}
+
+	c.JSON(http.StatusOK, call)
+}
+
+func (h *BaseHandler) ArchiveCastingCall(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleOwner)
+	if !ok {
+		return
+	}
+	if call.Status == models.CastingStatusPublished {
+		c.JSON(http.StatusConflict, gin.H{"error": "Close the casting call before archiving it"})
+		return
+	}
+	if call.Status == models.CastingStatusArchived {
+		c.JSON(http.StatusConflict, gin.H{"error": "Casting call is already archived"})
+		return
+	}
+
+	if err := h.DB.Model(&call).Update("status", models.CastingStatusArchived).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to archive casting call"})
+		return
+	}
+
+	c.JSON(http.StatusOK, call)
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"siddu-verse-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAcceptingApplications(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, isAcceptingApplications(models.CastingCall{Status: models.CastingStatusPublished}, now))
+	assert.True(t, isAcceptingApplications(models.CastingCall{Status: models.CastingStatusPublished, ApplicationDeadline: &future}, now))
+
+	// Test case: Deadline passed but the scheduler hasn't closed the call yet
+	assert.False(t, isAcceptingApplications(models.CastingCall{Status: models.CastingStatusPublished, ApplicationDeadline: &past}, now))
+
+	assert.False(t, isAcceptingApplications(models.CastingCall{Status: models.CastingStatusDraft}, now))
+	assert.False(t, isAcceptingApplications(models.CastingCall{Status: models.CastingStatusClosed}, now))
+	assert.False(t, isAcceptingApplications(models.CastingCall{Status: models.CastingStatusArchived}, now))
+}
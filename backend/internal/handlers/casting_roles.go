@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Casting Call Role Handlers ---
+
+type CastingCallRoleInput struct {
+	RoleName            string `json:"roleName" binding:"required"`
+	Description         string `json:"description"`
+	Requirements        string `json:"requirements"`
+	RequiredSkills      string `json:"requiredSkills"`
+	MinProficiency      string `json:"minProficiency" binding:"omitempty,oneof=Beginner Intermediate Advanced Expert"`
+	ExperienceKeywords  string `json:"experienceKeywords"`
+	PreferredMediaTypes string `json:"preferredMediaTypes"`
+	Location            string `json:"location"`
+}
+
+// screeningText is the role text checked by the content screener.
+func (input CastingCallRoleInput) screeningText() string {
+	return strings.Join([]string{input.RoleName, input.Description, input.Requirements, input.RequiredSkills, input.ExperienceKeywords, input.Location}, "\n")
+}
+
+func (h *BaseHandler) AddCastingCallRole(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+
+	var input CastingCallRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	screened, ok := h.screenEdit(c, call.PostedByUserID, "casting_calls", call.ID, input.screeningText())
+	if !ok {
+		return
+	}
+
+	role := models.CastingCallRole{
+		CastingCallID:       call.ID,
+		RoleName:            input.RoleName,
+		Description:         input.Description,
+		Requirements:        input.Requirements,
+		RequiredSkills:      input.RequiredSkills,
+		MinProficiency:      input.MinProficiency,
+		ExperienceKeywords:  input.ExperienceKeywords,
+		PreferredMediaTypes: input.PreferredMediaTypes,
+		Location:            input.Location,
+	}
+	if err := h.DB.Create(&role).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add role"})
+		return
+	}
+	h.holdForReview(call.PostedByUserID, "casting_calls", call.ID, input.screeningText(), screened)
+
+	c.JSON(screenedStatus(screened, http.StatusCreated), role)
+}
+
+func (h *BaseHandler) UpdateCastingCallRole(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+
+	var role models.CastingCallRole
+	if err := h.DB.First(&role, "id = ? AND casting_call_id = ?", c.Param("role_id"), call.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return
+	}
+
+	var input CastingCallRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	screened, ok := h.screenEdit(c, call.PostedByUserID, "casting_calls", call.ID, input.screeningText())
+	if !ok {
+		return
+	}
+
+	// Use a map so requirements can be cleared
+	if err := h.DB.Model(&role).Updates(map[string]interface{}{
+		"role_name":             input.RoleName,
+		"description":           input.Description,
+		"requirements":          input.Requirements,
+		"required_skills":       input.RequiredSkills,
+		"min_proficiency":       input.MinProficiency,
+		"experience_keywords":   input.ExperienceKeywords,
+		"preferred_media_types": input.PreferredMediaTypes,
+		"location":              input.Location,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+	h.holdForReview(call.PostedByUserID, "casting_calls", call.ID, input.screeningText(), screened)
+
+	c.JSON(screenedStatus(screened, http.StatusOK), role)
+}
+
+func (h *BaseHandler) RemoveCastingCallRole(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleEditor)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.Where("id = ? AND casting_call_id = ?", c.Param("role_id"), call.ID).Delete(&models.CastingCallRole{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role removed successfully"})
+}
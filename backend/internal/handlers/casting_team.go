@@ -0,0 +1,388 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/mailer"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errAlreadyOnTeam = errors.New("user is already on the casting call's team")
+
+// Casting call team roles, from most to least privileged.
+const (
+	CastingRoleOwner    = "owner"    // manages the team and everything below
+	CastingRoleEditor   = "editor"   // edits the call and its roles
+	CastingRoleReviewer = "reviewer" // notes, ratings, tags and status changes on applications
+	CastingRoleViewer   = "viewer"   // read-only access to applications
+)
+
+var castingRoleRank = map[string]int{
+	CastingRoleViewer:   1,
+	CastingRoleReviewer: 2,
+	CastingRoleEditor:   3,
+	CastingRoleOwner:    4,
+}
+
+// castingCallRole returns the user's role on the casting call's team, or "" if they are not on it.
+// The user who posted the call is always treated as an owner.
+func castingCallRole(db *gorm.DB, userID uint, castingCallID string) string {
+	callIDUint, err := strconv.ParseUint(castingCallID, 10, 32)
+	if err != nil {
+		return ""
+	}
+
+	var call models.CastingCall
+	if err := db.First(&call, uint(callIDUint)).Error; err != nil {
+		return ""
+	}
+	if call.PostedByUserID == userID {
+		return CastingRoleOwner
+	}
+
+	var member models.CastingCallMember
+	if err := db.First(&member, "casting_call_id = ? AND user_id = ?", call.ID, userID).Error; err != nil {
+		return ""
+	}
+	return member.Role
+}
+
+// hasCastingCallRole checks if the authenticated user holds at least minRole on the casting call's team.
+func hasCastingCallRole(db *gorm.DB, userID uint, castingCallID string, minRole string) bool {
+	role := castingCallRole(db, userID, castingCallID)
+	return role != "" && castingRoleRank[role] >= castingRoleRank[minRole]
+}
+
+// --- Invitation Handlers ---
+
+type InviteCastingCallMemberInput struct {
+	Username string `json:"username"`
+	Email    string `json:"email" binding:"omitempty,email"`
+	Role     string `json:"role" binding:"required,oneof=owner editor reviewer viewer"`
+}
+
+func (h *BaseHandler) InviteCastingCallMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only casting call owners can invite team members."})
+		return
+	}
+
+	var input InviteCastingCallMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Username == "" && input.Email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A username or email is required."})
+		return
+	}
+
+	var call models.CastingCall
+	if err := h.DB.First(&call, castingCallID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Casting call not found"})
+		return
+	}
+
+	invitation := models.CastingCallInvitation{
+		CastingCallID:   call.ID,
+		Email:           strings.ToLower(input.Email),
+		Role:            input.Role,
+		InvitedByUserID: userID.(uint),
+	}
+
+	// Invitations by username are bound to that account. Account emails aren't verified, so
+	// invitations by email are bound to the token in the email instead of a matching account.
+	var invitee models.User
+	var lookup *gorm.DB
+	if input.Username != "" {
+		lookup = h.DB.First(&invitee, "username = ?", input.Username)
+	} else {
+		lookup = h.DB.First(&invitee, "LOWER(email) = ?", invitation.Email)
+	}
+	if lookup.Error == nil {
+		if castingCallRole(h.DB, invitee.ID, castingCallID) != "" {
+			c.JSON(http.StatusConflict, gin.H{"error": "User is already on this casting call's team."})
+			return
+		}
+		if input.Username != "" {
+			invitation.InvitedUserID = &invitee.ID
+			invitation.Email = strings.ToLower(invitee.Email)
+		}
+	} else if input.Username != "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	var pending int64
+	h.DB.Model(&models.CastingCallInvitation{}).Where("casting_call_id = ? AND email = ? AND status = ?", call.ID, invitation.Email, "pending").Count(&pending)
+	if pending > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "An invitation is already pending for this user."})
+		return
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation."})
+		return
+	}
+	invitation.Token = token
+
+	if err := h.DB.Create(&invitation).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation."})
+		return
+	}
+
+	err = h.Mailer.Send(mailer.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You've been invited to join the casting team for %s", call.ProjectTitle),
+		Body: fmt.Sprintf("You've been invited as a %s on the casting call \"%s\".\n\nAccept the invitation: %s/talent/casting-calls/invitations/%s\n",
+			invitation.Role, call.ProjectTitle, utils.GetFrontendURL(), invitation.Token),
+	})
+	if err != nil {
+		log.Printf("Failed to send casting call invitation %d: %v", invitation.ID, err)
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+func (h *BaseHandler) GetCastingCallInvitations(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only casting call owners can view invitations."})
+		return
+	}
+
+	var invitations []models.CastingCallInvitation
+	if err := h.DB.Where("casting_call_id = ? AND status = ?", castingCallID, "pending").Find(&invitations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch invitations."})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *BaseHandler) RevokeCastingCallInvitation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only casting call owners can revoke invitations."})
+		return
+	}
+
+	result := h.DB.Model(&models.CastingCallInvitation{}).
+		Where("id = ? AND casting_call_id = ? AND status = ?", c.Param("invitation_id"), castingCallID, "pending").
+		Update("status", "revoked")
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invitation."})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
+// GetMyCastingCallInvitations lists the pending team invitations addressed to the authenticated
+// user's account. Invitations sent to an email address are only reachable from the email.
+func (h *BaseHandler) GetMyCastingCallInvitations(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var invitations []models.CastingCallInvitation
+	if err := h.DB.Preload("CastingCall").
+		Where("status = ? AND invited_user_id = ?", "pending", userID.(uint)).
+		Find(&invitations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch invitations."})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// findMyInvitation loads the pending invitation in the :token param and verifies the
+// authenticated user may answer it: invitations bound to an account only by that account, and
+// invitations sent to an email address by whoever holds the token from the email. It writes the
+// error response on failure.
+func (h *BaseHandler) findMyInvitation(c *gin.Context, userID uint) (models.CastingCallInvitation, bool) {
+	var invitation models.CastingCallInvitation
+	if err := h.DB.First(&invitation, "token = ? AND status = ?", c.Param("token"), "pending").Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found or no longer valid."})
+		return invitation, false
+	}
+
+	if invitation.InvitedUserID != nil && *invitation.InvitedUserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "This invitation is addressed to another user."})
+		return invitation, false
+	}
+	return invitation, true
+}
+
+func (h *BaseHandler) AcceptCastingCallInvitation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	invitation, ok := h.findMyInvitation(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	if castingCallRole(h.DB, userID.(uint), strconv.FormatUint(uint64(invitation.CastingCallID), 10)) != "" {
+		c.JSON(http.StatusConflict, gin.H{"error": "You are already on this casting call's team."})
+		return
+	}
+
+	member := models.CastingCallMember{
+		CastingCallID:   invitation.CastingCallID,
+		UserID:          userID.(uint),
+		Role:            invitation.Role,
+		InvitedByUserID: invitation.InvitedByUserID,
+	}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// A second invitation accepted at the same time loses on the unique index
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&member)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errAlreadyOnTeam
+		}
+		return tx.Model(&invitation).Update("status", "accepted").Error
+	})
+	if errors.Is(err, errAlreadyOnTeam) {
+		c.JSON(http.StatusConflict, gin.H{"error": "You are already on this casting call's team."})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept invitation."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+func (h *BaseHandler) DeclineCastingCallInvitation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	invitation, ok := h.findMyInvitation(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	if err := h.DB.Model(&invitation).Update("status", "declined").Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decline invitation."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation declined"})
+}
+
+// --- Team Member Handlers ---
+
+func (h *BaseHandler) GetCastingCallMembers(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this casting call's team."})
+		return
+	}
+
+	var members []models.CastingCallMember
+	if err := h.DB.Preload("User").Where("casting_call_id = ?", castingCallID).Find(&members).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch team members."})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+type UpdateCastingCallMemberInput struct {
+	Role string `json:"role" binding:"required,oneof=owner editor reviewer viewer"`
+}
+
+func (h *BaseHandler) UpdateCastingCallMemberRole(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only casting call owners can change team roles."})
+		return
+	}
+
+	var input UpdateCastingCallMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var call models.CastingCall
+	if err := h.DB.First(&call, castingCallID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Casting call not found"})
+		return
+	}
+
+	var member models.CastingCallMember
+	if err := h.DB.First(&member, "casting_call_id = ? AND user_id = ?", castingCallID, c.Param("user_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found."})
+		return
+	}
+
+	// The user who posted the call always stays an owner
+	if member.UserID == call.PostedByUserID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The casting call's creator must remain an owner."})
+		return
+	}
+
+	if err := h.DB.Model(&member).Update("role", input.Role).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team role."})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+func (h *BaseHandler) RemoveCastingCallMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+	memberUserID := c.Param("user_id")
+
+	// Owners can remove anyone; members can remove themselves
+	isSelf := memberUserID == strconv.FormatUint(uint64(userID.(uint)), 10)
+	if !isSelf && !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only casting call owners can remove team members."})
+		return
+	}
+
+	var call models.CastingCall
+	if err := h.DB.First(&call, castingCallID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Casting call not found"})
+		return
+	}
+	if memberUserID == strconv.FormatUint(uint64(call.PostedByUserID), 10) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The casting call's creator cannot be removed."})
+		return
+	}
+
+	// Hard delete so the user can be invited back later without hitting the unique index
+	if err := h.DB.Unscoped().Where("casting_call_id = ? AND user_id = ?", castingCallID, memberUserID).Delete(&models.CastingCallMember{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove team member."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Team member removed successfully"})
+}
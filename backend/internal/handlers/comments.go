@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxCommentDepth is the deepest a reply can be nested. Top-level comments have depth 0.
+const maxCommentDepth = 4
+
+// findComment loads the comment in the :id param. It writes the error response on failure.
+func (h *BaseHandler) findComment(c *gin.Context) (models.Comment, bool) {
+	var comment models.Comment
+	if err := h.DB.First(&comment, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return comment, false
+	}
+	return comment, true
+}
+
+// createComment adds a comment, or a reply when parentID is set, to the owner and lets
+// ownerUserID, who posted it, know. It writes the response.
+func (h *BaseHandler) createComment(c *gin.Context, userID uint, ownerType string, ownerID, ownerUserID uint, content string, parentID *uint) {
+	comment := models.Comment{
+		UserID:    userID,
+		Content:   content,
+		OwnerID:   ownerID,
+		OwnerType: ownerType,
+		ParentID:  parentID,
+	}
+
+	var parent models.Comment
+	if parentID != nil {
+		if err := h.DB.Scopes(blocks.Exclude(userID, "comments.user_id")).First(&parent, "id = ? AND owner_type = ? AND owner_id = ?", *parentID, ownerType, ownerID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The comment you are replying to was not found"})
+			return
+		}
+		if parent.Depth >= maxCommentDepth {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Replies cannot be nested more than " + strconv.Itoa(maxCommentDepth) + " levels deep"})
+			return
+		}
+		comment.Depth = parent.Depth + 1
+	}
+
+	screened, ok := h.screenContent(c, userID, "comments", content)
+	if !ok {
+		return
+	}
+	comment.HiddenAt = heldAt(screened)
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+		if parentID == nil {
+			return nil
+		}
+		return tx.Model(&models.Comment{}).Where("id = ?", *parentID).
+			UpdateColumn("reply_count", gorm.Expr("reply_count + 1")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create comment"})
+		return
+	}
+	h.recordScreening(userID, "comments", comment.ID, content, screened)
+
+	// Held comments aren't announced until a moderator restores them
+	if comment.HiddenAt == nil {
+		h.notifyEngagement(userID, ownerUserID, notifications.TypeComment, "commented on your", ownerType, ownerID, snippet(content))
+		if parentID != nil && parent.UserID != ownerUserID {
+			h.notifyEngagement(userID, parent.UserID, notifications.TypeReply, "replied to your comment on a", ownerType, ownerID, snippet(content))
+		}
+	}
+
+	// Preload user for the response
+	h.DB.Preload("User").First(&comment, comment.ID)
+
+	c.JSON(screenedStatus(screened, http.StatusCreated), comment)
+}
+
+// listComments pages through comments oldest first with ?after=<comment id>. Deleted and hidden
+// comments are only listed, without their content or author, while they still have replies.
+// Comments by banned users, and by users blocked either way with the viewer, are left out.
+func (h *BaseHandler) listComments(c *gin.Context, query *gorm.DB) {
+	_, limit := pageParams(c, 20)
+	query = query.Unscoped().Preload("User").
+		Where("(comments.deleted_at IS NULL AND comments.hidden_at IS NULL) OR comments.reply_count > 0").
+		Scopes(moderation.NotBanned("comments", "user_id"), blocks.Exclude(viewerID(c), "comments.user_id"))
+	if after, _ := strconv.ParseUint(c.Query("after"), 10, 32); after > 0 {
+		query = query.Where("comments.id > ?", after)
+	}
+
+	comments := []models.Comment{}
+	if err := query.Order("comments.id").Limit(limit).Find(&comments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch comments"})
+		return
+	}
+	for i := range comments {
+		if comments[i].DeletedAt.Valid || comments[i].HiddenAt != nil {
+			comments[i].Content = ""
+			comments[i].UserID = 0
+			comments[i].User = models.User{}
+		}
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// --- Comment Handlers ---
+
+// GetCommentReplies lists the direct replies to a comment, including deleted placeholders.
+func (h *BaseHandler) GetCommentReplies(c *gin.Context) {
+	var parent models.Comment
+	if err := h.DB.Unscoped().First(&parent, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+	if _, ok := h.resolveEngagementItem(c, parent.OwnerType, parent.OwnerID); !ok {
+		return
+	}
+
+	h.listComments(c, h.DB.Where("parent_id = ?", parent.ID))
+}
+
+type UpdateCommentInput struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// UpdateComment lets the author change a comment's text. Edited comments carry EditedAt.
+func (h *BaseHandler) UpdateComment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	comment, ok := h.findComment(c)
+	if !ok {
+		return
+	}
+	if comment.UserID != userID.(uint) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own comments"})
+		return
+	}
+
+	var input UpdateCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	screened, ok := h.screenEdit(c, comment.UserID, "comments", comment.ID, input.Content)
+	if !ok {
+		return
+	}
+	updates := map[string]interface{}{"content": input.Content, "edited_at": time.Now()}
+	if hiddenAt := heldAt(screened); hiddenAt != nil {
+		updates["hidden_at"] = hiddenAt
+	}
+	if err := h.DB.Model(&comment).Updates(updates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update comment"})
+		return
+	}
+	h.recordScreening(comment.UserID, "comments", comment.ID, input.Content, screened)
+
+	h.DB.Preload("User").First(&comment, comment.ID)
+	c.JSON(screenedStatus(screened, http.StatusOK), comment)
+}
+
+// DeleteComment soft-deletes a comment. Authors can delete their own comments, and owners of
+// what was commented on (a pulse's author, a casting call's poster) can delete any comment on it.
+func (h *BaseHandler) DeleteComment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	comment, ok := h.findComment(c)
+	if !ok {
+		return
+	}
+	if comment.UserID != userID.(uint) {
+		ownerUserID, ok := h.resolveEngagementItem(c, comment.OwnerType, comment.OwnerID)
+		if !ok {
+			return
+		}
+		if ownerUserID == 0 || ownerUserID != userID.(uint) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this comment"})
+			return
+		}
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&comment).Update("deleted_by_user_id", userID.(uint)).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&comment).Error; err != nil {
+			return err
+		}
+		if comment.ParentID == nil {
+			return nil
+		}
+		return tx.Unscoped().Model(&models.Comment{}).Where("id = ?", *comment.ParentID).
+			UpdateColumn("reply_count", gorm.Expr("GREATEST(reply_count - 1, 0)")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete comment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+// LikeComment toggles the user's like on a comment.
+func (h *BaseHandler) LikeComment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	// Comments taken down by moderators or from users blocked either way can't be liked
+	var comment models.Comment
+	if err := h.DB.Scopes(moderation.VisibleComments, blocks.Exclude(userID.(uint), "comments.user_id")).First(&comment, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+	if _, ok := h.resolveEngagementItem(c, comment.OwnerType, comment.OwnerID); !ok {
+		return
+	}
+
+	var existingLike models.Like
+	if h.DB.Where("user_id = ? AND owner_id = ? AND owner_type = ?", userID.(uint), comment.ID, "comments").First(&existingLike).Error == nil {
+		// User has already liked, so we unlike it
+		err := h.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(&existingLike).Error; err != nil {
+				return err
+			}
+			return tx.Model(&comment).UpdateColumn("like_count", gorm.Expr("GREATEST(like_count - 1, 0)")).Error
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlike comment"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Comment unliked"})
+		return
+	}
+
+	like := models.Like{UserID: userID.(uint), OwnerID: comment.ID, OwnerType: "comments"}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&like).Error; err != nil {
+			return err
+		}
+		return tx.Model(&comment).UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like comment"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Comment liked"})
+}
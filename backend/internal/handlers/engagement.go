@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/engagement"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// engagementTypes are the content types with comment and reaction endpoints, and who can see
+// and moderate each. Content is hidden from users blocked either way with its owner. A type
+// added here also needs its endpoints mounted in SetupRoutes.
+var engagementTypes = engagement.NewRegistry(
+	engagement.Type{Name: "pulses", Resolve: func(db *gorm.DB, viewerID, id uint) (uint, error) {
+		var pulse models.Pulse
+		if err := db.Scopes(moderation.VisiblePulses, blocks.Exclude(viewerID, "pulses.user_id")).First(&pulse, id).Error; err != nil {
+			return 0, notFoundOr(err)
+		}
+		return pulse.UserID, nil
+	}},
+	engagement.Type{Name: "reviews", Resolve: func(db *gorm.DB, viewerID, id uint) (uint, error) {
+		var review models.Review
+		if err := db.Scopes(moderation.NotBanned("reviews", "user_id"), blocks.Exclude(viewerID, "reviews.user_id")).First(&review, id).Error; err != nil {
+			return 0, notFoundOr(err)
+		}
+		return review.UserID, nil
+	}},
+	// Drafts and archived calls are only visible to the casting call's team
+	engagement.Type{Name: "casting_calls", Resolve: func(db *gorm.DB, viewerID, id uint) (uint, error) {
+		var call models.CastingCall
+		if err := db.Scopes(moderation.VisibleCastingCalls, blocks.Exclude(viewerID, "casting_calls.posted_by_user_id")).First(&call, id).Error; err != nil {
+			return 0, notFoundOr(err)
+		}
+		if call.Status == models.CastingStatusDraft || call.Status == models.CastingStatusArchived {
+			if viewerID == 0 || !hasCastingCallRole(db, viewerID, strconv.FormatUint(uint64(id), 10), CastingRoleViewer) {
+				return 0, engagement.ErrNotFound
+			}
+		}
+		return call.PostedByUserID, nil
+	}},
+	engagement.Type{Name: "movies", Resolve: engagement.Public(&models.Movie{})},
+	engagement.Type{Name: "awards", Resolve: engagement.Public(&models.Award{})},
+	engagement.Type{Name: "cricket_matches", Resolve: engagement.Public(&models.CricketMatch{})},
+)
+
+func notFoundOr(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return engagement.ErrNotFound
+	}
+	return err
+}
+
+// viewerID is the signed-in user, or 0 for anonymous requests on optionally authenticated routes.
+func viewerID(c *gin.Context) uint {
+	if userID, exists := c.Get("userID"); exists {
+		return userID.(uint)
+	}
+	return 0
+}
+
+// resolveEngagementItem checks the item of the given type exists and the viewer can see it,
+// and returns the user who owns it. It writes the error response on failure.
+func (h *BaseHandler) resolveEngagementItem(c *gin.Context, ownerType string, id uint) (uint, bool) {
+	t, ok := engagementTypes.Lookup(ownerType)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return 0, false
+	}
+	ownerUserID, err := t.Resolve(h.DB, viewerID(c), id)
+	if errors.Is(err, engagement.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return 0, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not load item"})
+		return 0, false
+	}
+	return ownerUserID, true
+}
+
+// engagementItem reads the :id param and resolves it as an item of ownerType, returning its ID
+// and the user who owns it. It writes the error response on failure.
+func (h *BaseHandler) engagementItem(c *gin.Context, ownerType string) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return 0, 0, false
+	}
+	ownerUserID, ok := h.resolveEngagementItem(c, ownerType, uint(id))
+	if !ok {
+		return 0, 0, false
+	}
+	return uint(id), ownerUserID, true
+}
+
+// engagementItemID is engagementItem for handlers that don't need the owner.
+func (h *BaseHandler) engagementItemID(c *gin.Context, ownerType string) (uint, bool) {
+	id, _, ok := h.engagementItem(c, ownerType)
+	return id, ok
+}
+
+// setReaction records the user's reaction on an item, replacing any reaction they already
+// left on it. It reports whether this is their first reaction to the item.
+func setReaction(db *gorm.DB, userID uint, ownerType string, ownerID uint, kind string) (bool, error) {
+	var existing models.Like
+	if err := db.Where("user_id = ? AND owner_type = ? AND owner_id = ?", userID, ownerType, ownerID).Limit(1).Find(&existing).Error; err != nil {
+		return false, err
+	}
+	if existing.ID != 0 {
+		return false, db.Model(&existing).Update("kind", kind).Error
+	}
+	return true, db.Create(&models.Like{UserID: userID, OwnerType: ownerType, OwnerID: ownerID, Kind: kind}).Error
+}
+
+// --- Comment and Reaction Handlers ---
+// Each returns the handler for one registered content type; the routes mount them per type.
+
+// GetComments lists the top-level comments on an item. Fetch each thread's replies with
+// GetCommentReplies.
+func (h *BaseHandler) GetComments(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := h.engagementItemID(c, ownerType)
+		if !ok {
+			return
+		}
+		h.listComments(c, h.DB.Where("owner_type = ? AND owner_id = ? AND parent_id IS NULL", ownerType, id))
+	}
+}
+
+// AddComment comments on an item, or replies to one of its comments with parentId.
+func (h *BaseHandler) AddComment(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+
+		var input CreateCommentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, ownerUserID, ok := h.engagementItem(c, ownerType)
+		if !ok {
+			return
+		}
+		h.createComment(c, userID.(uint), ownerType, id, ownerUserID, input.Content, input.ParentID)
+	}
+}
+
+// GetReactions returns the reaction counts per kind on an item, and the viewer's own reaction.
+func (h *BaseHandler) GetReactions(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := h.engagementItemID(c, ownerType)
+		if !ok {
+			return
+		}
+
+		summary, err := engagement.LoadSummary(h.DB, ownerType, id, viewerID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch reactions"})
+			return
+		}
+		c.JSON(http.StatusOK, summary)
+	}
+}
+
+type ReactionInput struct {
+	Kind string `json:"kind" binding:"required"`
+}
+
+// SetReaction reacts to an item, replacing the user's previous reaction on it.
+func (h *BaseHandler) SetReaction(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+
+		var input ReactionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if !engagement.IsKind(input.Kind) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported reaction kind"})
+			return
+		}
+
+		id, ownerUserID, ok := h.engagementItem(c, ownerType)
+		if !ok {
+			return
+		}
+
+		created, err := setReaction(h.DB, userID.(uint), ownerType, id, input.Kind)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save reaction"})
+			return
+		}
+		if created {
+			h.notifyEngagement(userID.(uint), ownerUserID, notifications.TypeLike, "reacted to your", ownerType, id, "")
+		}
+
+		summary, err := engagement.LoadSummary(h.DB, ownerType, id, userID.(uint))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch reactions"})
+			return
+		}
+		c.JSON(http.StatusOK, summary)
+	}
+}
+
+// RemoveReaction removes the user's reaction from an item.
+func (h *BaseHandler) RemoveReaction(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+
+		id, ok := h.engagementItemID(c, ownerType)
+		if !ok {
+			return
+		}
+
+		if err := h.DB.Where("user_id = ? AND owner_type = ? AND owner_id = ?", userID.(uint), ownerType, id).Delete(&models.Like{}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove reaction"})
+			return
+		}
+
+		summary, err := engagement.LoadSummary(h.DB, ownerType, id, userID.(uint))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch reactions"})
+			return
+		}
+		c.JSON(http.StatusOK, summary)
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"io"
+	"siddu-verse-backend/internal/realtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamEvents keeps a Server-Sent Events connection open and pushes the authenticated
+// user's real-time events (new messages, read receipts, ...) as they happen.
+func (h *BaseHandler) StreamEvents(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	events, unsubscribe := h.Realtime.Subscribe(userID.(uint))
+	defer unsubscribe()
+	streamEvents(c, events)
+}
+
+// streamEvents writes events to the Server-Sent Events connection until it closes.
+func streamEvents(c *gin.Context, events <-chan realtime.Event) {
+	// Periodic pings stop proxies from closing an idle connection
+	heartbeat := time.NewTicker(30 * time.Second)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event.Data)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", "")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/feed"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FollowingEntry is an account or talent profile a user follows. Exactly one of User and
+// TalentProfile is set, depending on FolloweeType.
+type FollowingEntry struct {
+	models.Follow
+	User          *models.User          `json:"user,omitempty"`
+	TalentProfile *models.TalentProfile `json:"talentProfile,omitempty"`
+}
+
+// pageParams reads ?before=<id> and ?limit= for lists paged newest first.
+func pageParams(c *gin.Context, defaultLimit int) (uint, int) {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = defaultLimit
+	}
+	before, _ := strconv.ParseUint(c.Query("before"), 10, 32)
+	return uint(before), limit
+}
+
+// follow records the follow, keeps the follower counts in step and copies the author's recent
+// pulses into the follower's feed. Following twice is a no-op.
+func (h *BaseHandler) follow(c *gin.Context, followerID uint, followeeType string, followeeID, authorID uint) {
+	follow := models.Follow{FollowerID: followerID, FolloweeType: followeeType, FolloweeID: followeeID, AuthorUserID: authorID}
+	created := false
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&follow)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		created = true
+
+		if err := tx.Model(&models.User{}).Where("id = ?", followerID).
+			UpdateColumn("following_count", gorm.Expr("following_count + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Table(followeeType).Where("id = ?", followeeID).
+			UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow"})
+		return
+	}
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"message": "Already following"})
+		return
+	}
+
+	if err := feed.Backfill(h.DB, followerID, authorID); err != nil {
+		log.Printf("Failed to backfill feed of user %d with user %d: %v", followerID, authorID, err)
+	}
+
+	var follower models.User
+	if h.DB.First(&follower, followerID).Error == nil {
+		link := "/users/" + strconv.FormatUint(uint64(followerID), 10)
+		if followeeType == feed.FolloweeTalentProfile {
+			h.notifyUser(authorID, notifications.TypeFollow, follower.Username+" followed your talent profile", "", link)
+		} else {
+			h.notifyUser(authorID, notifications.TypeFollow, follower.Username+" started following you", "", link)
+		}
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Followed successfully"})
+}
+
+// unfollow removes the follow and, unless the author is still followed another way, their
+// pulses from the follower's feed.
+func (h *BaseHandler) unfollow(c *gin.Context, followerID uint, followeeType string, followeeID uint) {
+	var follow models.Follow
+	if err := h.DB.First(&follow, "follower_id = ? AND followee_type = ? AND followee_id = ?", followerID, followeeType, followeeID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You are not following this account"})
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		return removeFollow(tx, follow)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unfollow"})
+		return
+	}
+
+	if err := feed.RemoveAuthor(h.DB, followerID, follow.AuthorUserID); err != nil {
+		log.Printf("Failed to remove user %d from the feed of user %d: %v", follow.AuthorUserID, followerID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unfollowed successfully"})
+}
+
+// removeFollow deletes the follow and keeps the follower counts in step. The caller takes the
+// author out of the follower's feed once the transaction commits.
+func removeFollow(tx *gorm.DB, follow models.Follow) error {
+	// Hard delete so the unique edge can be recreated by following again
+	result := tx.Unscoped().Delete(&follow)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	if err := tx.Model(&models.User{}).Where("id = ?", follow.FollowerID).
+		UpdateColumn("following_count", gorm.Expr("GREATEST(following_count - 1, 0)")).Error; err != nil {
+		return err
+	}
+	return tx.Table(follow.FolloweeType).Where("id = ?", follow.FolloweeID).
+		UpdateColumn("follower_count", gorm.Expr("GREATEST(follower_count - 1, 0)")).Error
+}
+
+// --- Follow Handlers ---
+
+func (h *BaseHandler) FollowUser(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var target models.User
+	if err := h.DB.First(&target, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if target.ID == userID.(uint) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot follow yourself"})
+		return
+	}
+	if !h.checkNotBlocked(c, userID.(uint), target.ID, "You can't follow this user") {
+		return
+	}
+
+	h.follow(c, userID.(uint), feed.FolloweeUser, target.ID, target.ID)
+}
+
+func (h *BaseHandler) UnfollowUser(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	h.unfollow(c, userID.(uint), feed.FolloweeUser, uint(targetID))
+}
+
+func (h *BaseHandler) FollowTalentProfile(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var profile models.TalentProfile
+	if err := h.DB.First(&profile, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
+		return
+	}
+	if profile.UserID == userID.(uint) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot follow your own profile"})
+		return
+	}
+	if !h.checkNotBlocked(c, userID.(uint), profile.UserID, "You can't follow this profile") {
+		return
+	}
+
+	h.follow(c, userID.(uint), feed.FolloweeTalentProfile, profile.ID, profile.UserID)
+}
+
+func (h *BaseHandler) UnfollowTalentProfile(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	profileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
+		return
+	}
+
+	h.unfollow(c, userID.(uint), feed.FolloweeTalentProfile, uint(profileID))
+}
+
+// GetUserFollowers lists the users following the account, newest first. Page back with
+// ?before=<follow id>.
+func (h *BaseHandler) GetUserFollowers(c *gin.Context) {
+	var user models.User
+	if err := h.DB.Scopes(blocks.Exclude(viewerID(c), "users.id")).First(&user, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	followers, err := h.loadFollowers(c, feed.FolloweeUser, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch followers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": user.FollowerCount, "followers": followers})
+}
+
+// GetTalentProfileFollowers lists the users following the talent profile, newest first. Page
+// back with ?before=<follow id>.
+func (h *BaseHandler) GetTalentProfileFollowers(c *gin.Context) {
+	var profile models.TalentProfile
+	if err := h.DB.Scopes(blocks.Exclude(viewerID(c), "talent_profiles.user_id")).First(&profile, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
+		return
+	}
+
+	followers, err := h.loadFollowers(c, feed.FolloweeTalentProfile, profile.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch followers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": profile.FollowerCount, "followers": followers})
+}
+
+func (h *BaseHandler) loadFollowers(c *gin.Context, followeeType string, followeeID uint) ([]models.Follow, error) {
+	before, limit := pageParams(c, 50)
+
+	query := h.DB.Preload("Follower").Scopes(blocks.Exclude(viewerID(c), "follows.follower_id")).
+		Where("followee_type = ? AND followee_id = ?", followeeType, followeeID)
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	follows := []models.Follow{}
+	err := query.Order("id desc").Limit(limit).Find(&follows).Error
+	return follows, err
+}
+
+// GetUserFollowing lists the accounts and talent profiles the user follows, newest first.
+// Page back with ?before=<follow id>.
+func (h *BaseHandler) GetUserFollowing(c *gin.Context) {
+	var user models.User
+	if err := h.DB.Scopes(blocks.Exclude(viewerID(c), "users.id")).First(&user, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	before, limit := pageParams(c, 50)
+	query := h.DB.Scopes(blocks.Exclude(viewerID(c), "follows.author_user_id")).Where("follower_id = ?", user.ID)
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	var follows []models.Follow
+	if err := query.Order("id desc").Limit(limit).Find(&follows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch followed accounts"})
+		return
+	}
+
+	var userIDs, profileIDs []uint
+	for _, follow := range follows {
+		if follow.FolloweeType == feed.FolloweeTalentProfile {
+			profileIDs = append(profileIDs, follow.FolloweeID)
+		} else {
+			userIDs = append(userIDs, follow.FolloweeID)
+		}
+	}
+	users := map[uint]*models.User{}
+	if len(userIDs) > 0 {
+		var found []models.User
+		h.DB.Where("id IN ?", userIDs).Find(&found)
+		for i := range found {
+			users[found[i].ID] = &found[i]
+		}
+	}
+	profiles := map[uint]*models.TalentProfile{}
+	if len(profileIDs) > 0 {
+		var found []models.TalentProfile
+		h.DB.Where("id IN ?", profileIDs).Find(&found)
+		for i := range found {
+			profiles[found[i].ID] = &found[i]
+		}
+	}
+
+	following := make([]FollowingEntry, 0, len(follows))
+	for _, follow := range follows {
+		entry := FollowingEntry{Follow: follow}
+		if follow.FolloweeType == feed.FolloweeTalentProfile {
+			entry.TalentProfile = profiles[follow.FolloweeID]
+		} else {
+			entry.User = users[follow.FolloweeID]
+		}
+		following = append(following, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": user.FollowingCount, "following": following})
+}
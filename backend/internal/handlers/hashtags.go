@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/feed"
+	"siddu-verse-backend/internal/hashtags"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// maxMentionsPerPulse caps how many users one pulse can notify.
+	maxMentionsPerPulse = 20
+	// minLinkKeyLength stops short hashtags like #go from linking to any movie titled "Go".
+	minLinkKeyLength = 4
+)
+
+// indexPulse records the pulse's hashtags, mentions, and the movies and cricket matches its
+// hashtags refer to, and its links, replacing anything recorded for it before. It returns the users who
+// weren't already mentioned, for notifying.
+func indexPulse(tx *gorm.DB, pulse models.Pulse) ([]uint, error) {
+	tags, usernames := hashtags.Parse(pulse.Content)
+
+	var previouslyMentioned []uint
+	if err := tx.Model(&models.PulseMention{}).Where("pulse_id = ?", pulse.ID).Pluck("user_id", &previouslyMentioned).Error; err != nil {
+		return nil, err
+	}
+	for _, model := range []interface{}{&models.PulseHashtag{}, &models.PulseMention{}, &models.PulseReference{}, &models.PulseLink{}} {
+		if err := tx.Where("pulse_id = ?", pulse.ID).Delete(model).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tags) > 0 {
+		names := make([]string, 0, len(tags))
+		rows := make([]models.Hashtag, 0, len(tags))
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+			rows = append(rows, models.Hashtag{Name: tag.Name, Display: tag.Display})
+		}
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&rows).Error; err != nil {
+			return nil, err
+		}
+		var stored []models.Hashtag
+		if err := tx.Where("name IN ?", names).Find(&stored).Error; err != nil {
+			return nil, err
+		}
+		links := make([]models.PulseHashtag, 0, len(stored))
+		for _, hashtag := range stored {
+			links = append(links, models.PulseHashtag{PulseID: pulse.ID, HashtagID: hashtag.ID, CreatedAt: pulse.CreatedAt})
+		}
+		if err := tx.Create(&links).Error; err != nil {
+			return nil, err
+		}
+
+		references, err := findPulseReferences(tx, pulse.ID, tags)
+		if err != nil {
+			return nil, err
+		}
+		if len(references) > 0 {
+			if err := tx.Create(&references).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := indexLinks(tx, pulse); err != nil {
+		return nil, err
+	}
+
+	if len(usernames) > maxMentionsPerPulse {
+		usernames = usernames[:maxMentionsPerPulse]
+	}
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	lowered := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		lowered = append(lowered, strings.ToLower(username))
+	}
+	var userIDs []uint
+	if err := tx.Model(&models.User{}).Where("LOWER(username) IN ? AND id <> ?", lowered, pulse.UserID).Pluck("id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	mentions := make([]models.PulseMention, 0, len(userIDs))
+	alreadyMentioned := make(map[uint]bool, len(previouslyMentioned))
+	for _, id := range previouslyMentioned {
+		alreadyMentioned[id] = true
+	}
+	var newlyMentioned []uint
+	for _, id := range userIDs {
+		mentions = append(mentions, models.PulseMention{PulseID: pulse.ID, UserID: id})
+		if !alreadyMentioned[id] {
+			newlyMentioned = append(newlyMentioned, id)
+		}
+	}
+	if err := tx.Create(&mentions).Error; err != nil {
+		return nil, err
+	}
+	return newlyMentioned, nil
+}
+
+// findPulseReferences links hashtags to the movies whose titles they spell, like #Oppenheimer,
+// and to cricket matches tagged like #INDvAUS. Match tags recur, so they link to the match
+// between those teams closest to now within the last few days or the coming week.
+func findPulseReferences(tx *gorm.DB, pulseID uint, tags []hashtags.Tag) ([]models.PulseReference, error) {
+	tagByKey := map[string]string{}
+	keys := []string{}
+	for _, tag := range tags {
+		if key := hashtags.Key(tag.Name); len(key) >= minLinkKeyLength {
+			tagByKey[key] = tag.Name
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	references := []models.PulseReference{}
+	var movies []models.Movie
+	if err := tx.Where("regexp_replace(lower(title), '[^a-z0-9]', '', 'g') IN ?", keys).Find(&movies).Error; err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		references = append(references, models.PulseReference{
+			PulseID: pulseID,
+			RefType: "movies",
+			RefID:   movie.ID,
+			Hashtag: tagByKey[hashtags.Key(movie.Title)],
+			Title:   movie.Title,
+		})
+	}
+
+	now := time.Now()
+	var matches []models.CricketMatch
+	if err := tx.Where("date BETWEEN ? AND ?", now.AddDate(0, 0, -3), now.AddDate(0, 0, 7)).Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	closest := map[string]models.CricketMatch{}
+	for _, match := range matches {
+		for _, matchTag := range hashtags.MatchTags(match.Team1, match.Team2) {
+			if _, used := tagByKey[matchTag]; !used {
+				continue
+			}
+			if current, ok := closest[matchTag]; !ok || absDuration(match.Date.Sub(now)) < absDuration(current.Date.Sub(now)) {
+				closest[matchTag] = match
+			}
+		}
+	}
+	linked := map[uint]bool{}
+	for key, match := range closest {
+		if linked[match.ID] {
+			continue
+		}
+		linked[match.ID] = true
+		references = append(references, models.PulseReference{
+			PulseID: pulseID,
+			RefType: "cricket_matches",
+			RefID:   match.ID,
+			Hashtag: tagByKey[key],
+			Title:   match.Team1 + " vs " + match.Team2,
+		})
+	}
+	return references, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// notifyMentions lets users know they were mentioned in a pulse, skipping anyone blocked
+// either way with the author.
+func (h *BaseHandler) notifyMentions(pulse models.Pulse, userIDs []uint) {
+	if len(userIDs) == 0 {
+		return
+	}
+	var author models.User
+	h.DB.First(&author, pulse.UserID)
+
+	link := "/pulses/" + strconv.FormatUint(uint64(pulse.ID), 10)
+	for _, userID := range userIDs {
+		if blocked, err := blocks.Between(h.DB, pulse.UserID, userID); err != nil || blocked {
+			continue
+		}
+		h.notifyUser(userID, notifications.TypeMention, author.Username+" mentioned you in a pulse", snippet(pulse.Content), link)
+	}
+}
+
+// snippet shortens text to show in a notification.
+func snippet(text string) string {
+	if runes := []rune(text); len(runes) > 140 {
+		return string(runes[:140]) + "…"
+	}
+	return text
+}
+
+// --- Hashtag Handlers ---
+
+// GetHashtagPulses lists the pulses using a hashtag, newest first. Page back with
+// ?before=<pulse id>.
+func (h *BaseHandler) GetHashtagPulses(c *gin.Context) {
+	name := strings.ToLower(strings.TrimPrefix(c.Param("tag"), "#"))
+
+	var hashtag models.Hashtag
+	if err := h.DB.First(&hashtag, "name = ?", name).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Hashtag not found"})
+		return
+	}
+
+	before, limit := pageParams(c, 20)
+	query := h.DB.Scopes(feed.Details(viewerID(c)), moderation.VisiblePulses, blocks.Exclude(viewerID(c), "pulses.user_id")).
+		Where("id IN (?)", h.DB.Model(&models.PulseHashtag{}).Select("pulse_id").Where("hashtag_id = ?", hashtag.ID))
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	pulses := []models.Pulse{}
+	if err := query.Order("id desc").Limit(limit).Find(&pulses).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch pulses"})
+		return
+	}
+	h.preparePulses(viewerID(c), pulseRefs(pulses)...)
+
+	c.JSON(http.StatusOK, gin.H{"hashtag": hashtag, "pulses": pulses})
+}
+
+// TrendingHashtag is a hashtag's position in the trending list.
+type TrendingHashtag struct {
+	Name     string  `json:"name"`
+	Display  string  `json:"display"`
+	Authors  int64   `json:"authors"`  // Accounts using it in the window
+	Velocity float64 `json:"velocity"` // How many times its usual rate it is being used
+}
+
+// GetTrendingHashtags ranks hashtags by how quickly their use is rising. ?window= is 1h
+// (default), 6h or 24h; each is compared with a longer baseline before it.
+func (h *BaseHandler) GetTrendingHashtags(c *gin.Context) {
+	window, ok := hashtags.Windows[c.DefaultQuery("window", "1h")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "window must be one of 1h, 6h, 24h"})
+		return
+	}
+	_, limit := pageParams(c, 10)
+
+	now := time.Now()
+	recentStart := now.Add(-window.Recent)
+	// Each account counts once per tag and period, and only pulses that are still visible
+	// count, so one account posting a tag repeatedly or a held pulse can't make it trend.
+	recentAuthors := "COUNT(DISTINCT CASE WHEN pulse_hashtags.created_at >= ? THEN pulses.user_id END)"
+	var activity []hashtags.Activity
+	if err := h.DB.Model(&models.PulseHashtag{}).
+		Select("pulse_hashtags.hashtag_id, "+recentAuthors+` AS recent,
+			COUNT(DISTINCT CASE WHEN pulse_hashtags.created_at < ? THEN pulses.user_id END) AS baseline`, recentStart, recentStart).
+		Joins("JOIN pulses ON pulses.id = pulse_hashtags.pulse_id AND pulses.deleted_at IS NULL").
+		Scopes(moderation.VisiblePulses).
+		Where("pulse_hashtags.created_at >= ?", recentStart.Add(-window.Baseline)).
+		Group("pulse_hashtags.hashtag_id").
+		Having(recentAuthors+" >= ?", recentStart, hashtags.MinRecentAuthors).
+		Scan(&activity).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch trending hashtags"})
+		return
+	}
+
+	ranked := hashtags.Rank(activity, window, limit)
+	ids := make([]uint, 0, len(ranked))
+	for _, r := range ranked {
+		ids = append(ids, r.HashtagID)
+	}
+	byID := map[uint]models.Hashtag{}
+	if len(ids) > 0 {
+		var found []models.Hashtag
+		h.DB.Where("id IN ?", ids).Find(&found)
+		for _, hashtag := range found {
+			byID[hashtag.ID] = hashtag
+		}
+	}
+
+	trending := make([]TrendingHashtag, 0, len(ranked))
+	for _, r := range ranked {
+		hashtag, ok := byID[r.HashtagID]
+		if !ok {
+			continue
+		}
+		trending = append(trending, TrendingHashtag{Name: hashtag.Name, Display: hashtag.Display, Authors: r.Recent, Velocity: r.Velocity})
+	}
+
+	c.JSON(http.StatusOK, trending)
+}
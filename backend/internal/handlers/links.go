@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/linkpreview"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// indexLinks records the links in the pulse's content as preview cards. Links to the site's
+// own pages are recorded with the page they point at, for native cards; other links are
+// queued for the link preview job, sharing the preview cached for the URL unless it has gone
+// stale.
+func indexLinks(tx *gorm.DB, pulse models.Pulse) error {
+	urls := linkpreview.ExtractURLs(pulse.Content)
+	if len(urls) == 0 {
+		return nil
+	}
+
+	frontendURL := utils.GetFrontendURL()
+	links := make([]models.PulseLink, 0, len(urls))
+	var external []string
+	for i, url := range urls {
+		link := models.PulseLink{PulseID: pulse.ID, Position: i, URL: url}
+		if target, ok := linkpreview.ParseInternal(url, frontendURL); ok {
+			link.TargetType, link.TargetID = target.Type, &target.ID
+		} else {
+			external = append(external, url)
+		}
+		links = append(links, link)
+	}
+
+	previewIDs, err := queueLinkPreviews(tx, external)
+	if err != nil {
+		return err
+	}
+	for i := range links {
+		if id, ok := previewIDs[links[i].URL]; ok {
+			links[i].LinkPreviewID = &id
+		}
+	}
+	return tx.Create(&links).Error
+}
+
+// fillNativeCards fills in the cards for links to the site's own pages from the pages as they
+// are now, including links in embedded originals. Pages the viewer can't see, like hidden or
+// blocked profiles and draft casting calls, get no card.
+func (h *BaseHandler) fillNativeCards(viewerID uint, pulses []*models.Pulse) {
+	byTarget := map[string]map[uint][]*models.PulseLink{}
+	for _, pulse := range pulses {
+		shown := []*models.Pulse{pulse}
+		if pulse.OriginalPulse != nil {
+			shown = append(shown, pulse.OriginalPulse)
+		}
+		for _, p := range shown {
+			for i := range p.Links {
+				link := &p.Links[i]
+				if link.TargetType == "" || link.TargetID == nil {
+					continue
+				}
+				if byTarget[link.TargetType] == nil {
+					byTarget[link.TargetType] = map[uint][]*models.PulseLink{}
+				}
+				byTarget[link.TargetType][*link.TargetID] = append(byTarget[link.TargetType][*link.TargetID], link)
+			}
+		}
+	}
+
+	fill := func(targetType string, id uint, title, description, imageURL string) {
+		for _, link := range byTarget[targetType][id] {
+			link.Title, link.Description, link.ImageURL = title, description, imageURL
+		}
+	}
+	if links := byTarget[linkpreview.TargetMovie]; len(links) > 0 {
+		var movies []models.Movie
+		h.DB.Where("id IN ?", targetIDs(links)).Find(&movies)
+		for _, movie := range movies {
+			fill(linkpreview.TargetMovie, movie.ID, movie.Title, snippet(movie.Description), movie.PosterURL)
+		}
+	}
+	if links := byTarget[linkpreview.TargetTalentProfile]; len(links) > 0 {
+		var profiles []models.TalentProfile
+		h.DB.Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(viewerID, "talent_profiles.user_id")).
+			Where("talent_profiles.id IN ?", targetIDs(links)).Find(&profiles)
+		for _, profile := range profiles {
+			fill(linkpreview.TargetTalentProfile, profile.ID, profile.FullName, profile.Headline, profile.AvatarURL)
+		}
+	}
+	if links := byTarget[linkpreview.TargetCastingCall]; len(links) > 0 {
+		var calls []models.CastingCall
+		h.DB.Scopes(moderation.VisibleCastingCalls, blocks.Exclude(viewerID, "casting_calls.posted_by_user_id")).
+			Where("casting_calls.id IN ? AND status IN ?", targetIDs(links), []string{models.CastingStatusPublished, models.CastingStatusClosed}).Find(&calls)
+		for _, call := range calls {
+			fill(linkpreview.TargetCastingCall, call.ID, call.ProjectTitle, snippet(call.Description), "")
+		}
+	}
+}
+
+// targetIDs lists the IDs a set of links points at.
+func targetIDs(links map[uint][]*models.PulseLink) []uint {
+	ids := make([]uint, 0, len(links))
+	for id := range links {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// queueLinkPreviews makes sure each URL has a cached preview, queueing new and stale ones for
+// the link preview job, and returns their IDs by URL.
+func queueLinkPreviews(tx *gorm.DB, urls []string) (map[string]uint, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	rows := make([]models.LinkPreview, 0, len(urls))
+	for _, url := range urls {
+		rows = append(rows, models.LinkPreview{URL: url, Status: linkpreview.StatusPending, NextAttemptAt: now})
+	}
+	if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "url"}}, DoNothing: true}).Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&models.LinkPreview{}).
+		Where("url IN ? AND status <> ? AND fetched_at < ?", urls, linkpreview.StatusPending, now.Add(-linkpreview.TTL)).
+		Updates(map[string]interface{}{"status": linkpreview.StatusPending, "attempts": 0, "next_attempt_at": now}).Error; err != nil {
+		return nil, err
+	}
+
+	var previews []models.LinkPreview
+	if err := tx.Select("id", "url").Where("url IN ?", urls).Find(&previews).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uint, len(previews))
+	for _, preview := range previews {
+		ids[preview.URL] = preview.ID
+	}
+	return ids, nil
+}
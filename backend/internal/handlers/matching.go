@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/matching"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/skills"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SuggestedTalent is a talent profile ranked against a casting call role.
+type SuggestedTalent struct {
+	Profile models.TalentProfile `json:"profile"`
+	Match   matching.Match       `json:"match"`
+}
+
+// SuggestedCall is a casting call role ranked against a talent profile.
+type SuggestedCall struct {
+	CastingCall models.CastingCall     `json:"castingCall"`
+	Role        models.CastingCallRole `json:"role"`
+	Match       matching.Match         `json:"match"`
+}
+
+// maxSuggestionCandidates caps how many talent profiles are scored for one role, and how many
+// casting calls for one profile.
+const maxSuggestionCandidates = 500
+
+// suggestionLimit reads the ?limit= query parameter, defaulting to 20 and capped at 100.
+func suggestionLimit(c *gin.Context) int {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+// GetSuggestedTalentForRole ranks talent profiles against a role's requirements, a page at a
+// time with ?offset= and ?limit=. Only profiles with one of the role's required skills or in
+// its location are scored, and at most maxSuggestionCandidates of them.
+func (h *BaseHandler) GetSuggestedTalentForRole(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	call, ok := h.findTeamCastingCall(c, userID.(uint), CastingRoleViewer)
+	if !ok {
+		return
+	}
+
+	var role models.CastingCallRole
+	if err := h.DB.First(&role, "id = ? AND casting_call_id = ?", c.Param("role_id"), call.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return
+	}
+
+	roleTaxonomy, err := skills.LoadTaxonomy(h.DB, matching.SplitList(role.RequiredSkills))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch talent profiles"})
+		return
+	}
+	query := h.DB.Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(userID.(uint), "talent_profiles.user_id"))
+	if candidates := candidateFilter(h.DB, role, roleTaxonomy); candidates != nil {
+		query = query.Where(candidates)
+	}
+	var profiles []models.TalentProfile
+	if err := query.Preload("Skills").Preload("Experiences").Preload("Portfolio").
+		Order("talent_profiles.updated_at desc").Limit(maxSuggestionCandidates).Find(&profiles).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch talent profiles"})
+		return
+	}
+
+	taxonomy, err := skills.LoadTaxonomy(h.DB, matching.SkillNames(profiles, []models.CastingCallRole{role}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch talent profiles"})
+		return
+	}
+	matches := make([]matching.Match, 0, len(profiles))
+	byID := make(map[uint]models.TalentProfile, len(profiles))
+	for _, profile := range profiles {
+		match := matching.ScoreProfile(profile, role, taxonomy)
+		if match.Score > 0 {
+			matches = append(matches, match)
+			byID[profile.ID] = profile
+		}
+	}
+	matching.SortByScore(matches)
+
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	if offset < 0 || offset > len(matches) {
+		offset = len(matches)
+	}
+	matches = matches[offset:]
+	if limit := suggestionLimit(c); len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	suggestions := make([]SuggestedTalent, 0, len(matches))
+	for _, match := range matches {
+		suggestions = append(suggestions, SuggestedTalent{Profile: byID[match.TalentProfileID], Match: match})
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// candidateFilter narrows the profiles worth scoring for a role to those with one of its
+// required skills, under any of its names in taxonomy, or in its location. It returns nil
+// when any profile could match.
+func candidateFilter(db *gorm.DB, role models.CastingCallRole, taxonomy skills.Taxonomy) *gorm.DB {
+	required := matching.SplitList(role.RequiredSkills)
+	location := strings.ToLower(strings.TrimSpace(role.Location))
+	if matching.AnyLocation(location) || (len(required) == 0 && location == "") {
+		return nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	if len(required) > 0 {
+		// Profile skills added through the taxonomy carry its ID; older ones only their name
+		canonical := make([]string, 0, len(required))
+		for _, name := range required {
+			canonical = append(canonical, taxonomy.CanonicalKey(name))
+		}
+		taxonomyIDs := db.Model(&models.TaxonomySkill{}).Select("id").Where("LOWER(name) IN ?", canonical)
+		conditions = append(conditions, "talent_profiles.id IN (?)")
+		args = append(args, db.Model(&models.Skill{}).Select("talent_profile_id").
+			Where("LOWER(TRIM(name)) IN ? OR taxonomy_skill_id IN (?)", append(canonical, required...), taxonomyIDs))
+	}
+	if location != "" {
+		// The same containment either way as the location score
+		conditions = append(conditions, "(TRIM(talent_profiles.location) <> '' AND "+
+			"(strpos(LOWER(talent_profiles.location), ?) > 0 OR strpos(?, LOWER(TRIM(talent_profiles.location))) > 0))")
+		args = append(args, location, location)
+	}
+	return db.Where(strings.Join(conditions, " OR "), args...)
+}
+
+// roleFilter narrows the casting call roles worth scoring for a profile to those requiring
+// one of its skills, under any of its names in the taxonomy, or in a location it is in,
+// mirroring candidateFilter. Roles asking for neither are kept since other criteria may
+// still match.
+func roleFilter(db *gorm.DB, profile models.TalentProfile, skillSpellings []string) *gorm.DB {
+	conditions := []string{"(TRIM(casting_call_roles.required_skills) = '' AND TRIM(casting_call_roles.location) = '')",
+		"LOWER(TRIM(casting_call_roles.location)) IN ('remote', 'any')"}
+	var args []interface{}
+	if len(skillSpellings) > 0 {
+		// The same normalisation as skills.Key, applied to each comma-separated entry
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM unnest(string_to_array(LOWER(casting_call_roles.required_skills), ',')) AS required(name)
+			WHERE regexp_replace(TRIM(required.name), '\s+', ' ', 'g') IN ?)`)
+		args = append(args, skillSpellings)
+	}
+	if location := strings.ToLower(strings.TrimSpace(profile.Location)); location != "" {
+		conditions = append(conditions, "(TRIM(casting_call_roles.location) <> '' AND "+
+			"(strpos(?, LOWER(TRIM(casting_call_roles.location))) > 0 OR strpos(LOWER(casting_call_roles.location), ?) > 0))")
+		args = append(args, location, location)
+	}
+	return db.Model(&models.CastingCallRole{}).Select("casting_call_id").Where(strings.Join(conditions, " OR "), args...)
+}
+
+// GetSuggestedCallsForProfile ranks open casting call roles against the owner's talent
+// profile. Only the most recently published calls with a role requiring one of the profile's
+// skills or in its location are scored, and at most maxSuggestionCandidates of them.
+func (h *BaseHandler) GetSuggestedCallsForProfile(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	profileID := c.Param("id")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view suggestions for this profile"})
+		return
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.Preload("Skills").Preload("Experiences").Preload("Portfolio").First(&profile, profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
+		return
+	}
+
+	profileSkills := matching.SkillNames([]models.TalentProfile{profile}, nil)
+	profileTaxonomy, err := skills.LoadTaxonomy(h.DB, profileSkills)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch casting calls"})
+		return
+	}
+	spellings, err := skills.Spellings(h.DB, profileTaxonomy, profileSkills)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch casting calls"})
+		return
+	}
+
+	// Only suggest calls the talent can still apply to and hasn't applied to yet
+	now := time.Now()
+	var calls []models.CastingCall
+	if err := h.DB.Preload("Roles").Scopes(moderation.VisibleCastingCalls, blocks.Exclude(profile.UserID, "casting_calls.posted_by_user_id")).
+		Where("status = ? AND (application_deadline IS NULL OR application_deadline > ?)", models.CastingStatusPublished, now).
+		Where("id NOT IN (?)", h.DB.Model(&models.Application{}).Select("casting_call_id").Where("talent_profile_id = ?", profile.ID)).
+		Where("id IN (?)", roleFilter(h.DB, profile, spellings)).
+		Order("published_at desc").Limit(maxSuggestionCandidates).
+		Find(&calls).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch casting calls"})
+		return
+	}
+
+	var roles []models.CastingCallRole
+	for _, call := range calls {
+		roles = append(roles, call.Roles...)
+	}
+	taxonomy, err := skills.LoadTaxonomy(h.DB, matching.SkillNames([]models.TalentProfile{profile}, roles))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch casting calls"})
+		return
+	}
+
+	var suggestions []SuggestedCall
+	for _, call := range calls {
+		for _, role := range call.Roles {
+			match := matching.ScoreProfile(profile, role, taxonomy)
+			if match.Score > 0 {
+				callSummary := call
+				callSummary.Roles = nil
+				suggestions = append(suggestions, SuggestedCall{CastingCall: callSummary, Role: role, Match: match})
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Match.Score > suggestions[j].Match.Score
+	})
+	limit := suggestionLimit(c)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	if suggestions == nil {
+		suggestions = []SuggestedCall{}
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"siddu-verse-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRoleFilter(t *testing.T) {
+	db, _ := dryRunDB(t)
+	profile := models.TalentProfile{Location: " Mumbai "}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&models.CastingCall{}).Where("id IN (?)", roleFilter(tx, profile, []string{"acting", "actor"})).
+			Limit(10).Find(&[]models.CastingCall{})
+	})
+
+	// Test case: Calls are narrowed in SQL by skill spellings or location before scoring
+	assert.Contains(t, sql, `SELECT "casting_call_id" FROM "casting_call_roles"`)
+	assert.Contains(t, sql, `IN ('acting','actor')`)
+	assert.Contains(t, sql, `strpos('mumbai', LOWER(TRIM(casting_call_roles.location)))`)
+	assert.Contains(t, sql, "LIMIT 10")
+
+	// Test case: Without skills or a location only open-ended roles are kept
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return roleFilter(tx, models.TalentProfile{}, nil).Find(&[]models.CastingCallRole{})
+	})
+	assert.NotContains(t, sql, "required(name)")
+	assert.NotContains(t, sql, "strpos")
+}
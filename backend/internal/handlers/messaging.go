@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/realtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// hasAppliedToRecruiter checks if the applicant has applied to a casting call the recruiter
+// posted or is on the team of.
+func hasAppliedToRecruiter(db *gorm.DB, applicantUserID, recruiterUserID uint) bool {
+	var count int64
+	db.Model(&models.Application{}).
+		Joins("JOIN talent_profiles ON talent_profiles.id = applications.talent_profile_id").
+		Joins("JOIN casting_calls ON casting_calls.id = applications.casting_call_id").
+		Where("talent_profiles.user_id = ?", applicantUserID).
+		Where("casting_calls.posted_by_user_id = ? OR casting_calls.id IN (?)", recruiterUserID,
+			db.Model(&models.CastingCallMember{}).Select("casting_call_id").Where("user_id = ?", recruiterUserID)).
+		Count(&count)
+	return count > 0
+}
+
+// isRecruiterOf checks if the talent has applied to a published casting call on which the
+// recruiter is the poster or can review applications.
+func isRecruiterOf(db *gorm.DB, recruiterUserID, talentUserID uint) bool {
+	var count int64
+	db.Model(&models.Application{}).
+		Joins("JOIN talent_profiles ON talent_profiles.id = applications.talent_profile_id").
+		Joins("JOIN casting_calls ON casting_calls.id = applications.casting_call_id").
+		Where("talent_profiles.user_id = ? AND casting_calls.status = ?", talentUserID, models.CastingStatusPublished).
+		Where("casting_calls.posted_by_user_id = ? OR casting_calls.id IN (?)", recruiterUserID,
+			db.Model(&models.CastingCallMember{}).Select("casting_call_id").
+				Where("user_id = ? AND role IN ?", recruiterUserID, []string{CastingRoleOwner, CastingRoleEditor, CastingRoleReviewer})).
+		Count(&count)
+	return count > 0
+}
+
+// canMessage enforces the messaging privacy rules: recruiters can reach out to the applicants
+// of their published casting calls, and talent can message recruiters whose casting calls
+// they applied to.
+func canMessage(db *gorm.DB, senderID, recipientID uint) bool {
+	return isRecruiterOf(db, senderID, recipientID) || hasAppliedToRecruiter(db, senderID, recipientID)
+}
+
+// containsPattern is a LIKE pattern matching text that contains q, with the wildcards in q
+// escaped. Use it with ESCAPE '\'.
+func containsPattern(q string) string {
+	return "%" + likeEscaper.Replace(q) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// findConversation loads the conversation in the :id param and verifies the user is a
+// participant. It writes the error response on failure.
+func (h *BaseHandler) findConversation(c *gin.Context, userID uint) (models.Conversation, bool) {
+	var conversation models.Conversation
+	if err := h.DB.Preload("Participants").First(&conversation, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation not found"})
+		return conversation, false
+	}
+
+	for _, participant := range conversation.Participants {
+		if participant.UserID == userID {
+			return conversation, true
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "Conversation not found"})
+	return conversation, false
+}
+
+// participantIDs returns the user IDs of everyone in the conversation.
+func participantIDs(conversation models.Conversation) []uint {
+	ids := make([]uint, 0, len(conversation.Participants))
+	for _, participant := range conversation.Participants {
+		ids = append(ids, participant.UserID)
+	}
+	return ids
+}
+
+// createMessage stores a message with its attachments, bumps the conversation and marks it
+// read for the sender, then pushes it to the participants' open connections.
+func (h *BaseHandler) createMessage(conversation models.Conversation, senderID uint, input SendMessageInput) (models.Message, error) {
+	message := models.Message{
+		ConversationID: conversation.ID,
+		SenderUserID:   senderID,
+		Content:        input.Content,
+	}
+	for _, attachment := range input.Attachments {
+		message.Attachments = append(message.Attachments, models.MessageAttachment{
+			URL:       attachment.URL,
+			FileName:  attachment.FileName,
+			MediaType: attachment.MediaType,
+		})
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&message).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Conversation{}).Where("id = ?", conversation.ID).Update("last_message_at", message.CreatedAt).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ConversationParticipant{}).
+			Where("conversation_id = ? AND user_id = ?", conversation.ID, senderID).
+			Updates(map[string]interface{}{"last_read_message_id": message.ID, "last_read_at": message.CreatedAt}).Error
+	})
+	if err != nil {
+		return message, err
+	}
+
+	h.DB.Preload("Sender").Preload("Attachments").First(&message, message.ID)
+	h.Realtime.Publish(realtime.Event{Type: "message.created", Data: message}, participantIDs(conversation)...)
+	return message, nil
+}
+
+// --- Conversation Handlers ---
+
+type MessageAttachmentInput struct {
+	URL       string `json:"url" binding:"required,url"`
+	FileName  string `json:"fileName"`
+	MediaType string `json:"mediaType" binding:"omitempty,oneof=image video audio document"`
+}
+
+type SendMessageInput struct {
+	Content     string                   `json:"content" binding:"required"`
+	Attachments []MessageAttachmentInput `json:"attachments" binding:"max=10,dive"`
+}
+
+type StartConversationInput struct {
+	RecipientID   uint  `json:"recipientId"`
+	ApplicationID *uint `json:"applicationId"`
+	SendMessageInput
+}
+
+func (h *BaseHandler) StartConversation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	senderID := userID.(uint)
+
+	var input StartConversationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipientID := input.RecipientID
+	if input.ApplicationID != nil {
+		// Conversations about an application are between the applicant and the casting call's team
+		var application models.Application
+		if err := h.DB.Preload("TalentProfile").First(&application, *input.ApplicationID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+			return
+		}
+
+		castingCallID := strconv.FormatUint(uint64(application.CastingCallID), 10)
+		applicantID := application.TalentProfile.UserID
+		switch {
+		case senderID == applicantID:
+			if recipientID == 0 || !hasCastingCallRole(h.DB, recipientID, castingCallID, CastingRoleViewer) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Recipient is not on this casting call's team"})
+				return
+			}
+		case hasCastingCallRole(h.DB, senderID, castingCallID, CastingRoleReviewer):
+			if recipientID == 0 {
+				recipientID = applicantID
+			}
+			if recipientID != applicantID {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Recipient must be the applicant"})
+				return
+			}
+		default:
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to message about this application"})
+			return
+		}
+	} else {
+		if recipientID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "recipientId or applicationId is required"})
+			return
+		}
+		if !canMessage(h.DB, senderID, recipientID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You can only message recruiters of casting calls you applied to, or applicants to your published casting calls"})
+			return
+		}
+	}
+	if recipientID == senderID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot message yourself"})
+		return
+	}
+	if !h.checkNotBlocked(c, senderID, recipientID, "You can't message this user") {
+		return
+	}
+
+	// Reuse the existing conversation between these two users about the same application
+	var conversation models.Conversation
+	query := h.DB.Preload("Participants").
+		Where("id IN (?)", h.DB.Model(&models.ConversationParticipant{}).
+			Select("conversation_id").
+			Where("user_id IN ?", []uint{senderID, recipientID}).
+			Group("conversation_id").
+			Having("COUNT(DISTINCT user_id) = 2"))
+	if input.ApplicationID != nil {
+		query = query.Where("application_id = ?", *input.ApplicationID)
+	} else {
+		query = query.Where("application_id IS NULL")
+	}
+
+	status := http.StatusOK
+	if err := query.First(&conversation).Error; err != nil {
+		conversation = models.Conversation{
+			ApplicationID: input.ApplicationID,
+			Participants: []models.ConversationParticipant{
+				{UserID: senderID},
+				{UserID: recipientID},
+			},
+		}
+		if err := h.DB.Create(&conversation).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start conversation"})
+			return
+		}
+		status = http.StatusCreated
+	}
+
+	message, err := h.createMessage(conversation, senderID, input.SendMessageInput)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send message"})
+		return
+	}
+
+	c.JSON(status, gin.H{"conversation": conversation, "message": message})
+}
+
+// ConversationSummary is a conversation in the inbox, with its newest message and unread count.
+type ConversationSummary struct {
+	models.Conversation
+	LastMessage *models.Message `json:"lastMessage"`
+	UnreadCount int64           `json:"unreadCount"`
+}
+
+func (h *BaseHandler) GetConversations(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var conversations []models.Conversation
+	if err := h.DB.Preload("Participants.User").
+		Where("id IN (?)", h.DB.Model(&models.ConversationParticipant{}).Select("conversation_id").Where("user_id = ?", userID.(uint))).
+		Order("last_message_at desc").
+		Find(&conversations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch conversations"})
+		return
+	}
+	if len(conversations) == 0 {
+		c.JSON(http.StatusOK, []ConversationSummary{})
+		return
+	}
+
+	ids := make([]uint, len(conversations))
+	for i, conversation := range conversations {
+		ids[i] = conversation.ID
+	}
+
+	var lastMessages []models.Message
+	if err := h.DB.Where("id IN (?)", h.DB.Model(&models.Message{}).Select("MAX(id)").Where("conversation_id IN ?", ids).Group("conversation_id")).
+		Find(&lastMessages).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch conversations"})
+		return
+	}
+
+	var unreadRows []struct {
+		ConversationID uint
+		Total          int64
+	}
+	if err := h.DB.Model(&models.Message{}).
+		Select("messages.conversation_id, COUNT(*) AS total").
+		Joins("JOIN conversation_participants ON conversation_participants.conversation_id = messages.conversation_id AND conversation_participants.user_id = ?", userID.(uint)).
+		Where("messages.id > conversation_participants.last_read_message_id AND messages.sender_user_id <> ?", userID.(uint)).
+		Where("messages.conversation_id IN ?", ids).
+		Group("messages.conversation_id").
+		Scan(&unreadRows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch conversations"})
+		return
+	}
+
+	summaries := make([]ConversationSummary, len(conversations))
+	byID := make(map[uint]*ConversationSummary, len(conversations))
+	for i, conversation := range conversations {
+		summaries[i] = ConversationSummary{Conversation: conversation}
+		byID[conversation.ID] = &summaries[i]
+	}
+	for i := range lastMessages {
+		byID[lastMessages[i].ConversationID].LastMessage = &lastMessages[i]
+	}
+	for _, row := range unreadRows {
+		byID[row.ConversationID].UnreadCount = row.Total
+	}
+
+	c.JSON(http.StatusOK, summaries)
+}
+
+func (h *BaseHandler) GetConversationByID(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	conversation, ok := h.findConversation(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	// Participants carry the read receipts
+	h.DB.Preload("Participants.User").Preload("Application").First(&conversation, conversation.ID)
+	c.JSON(http.StatusOK, conversation)
+}
+
+// GetConversationMessages returns messages newest first. Use ?before=<message id> to page
+// back and ?q= to search the conversation.
+func (h *BaseHandler) GetConversationMessages(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	conversation, ok := h.findConversation(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	query := h.DB.Preload("Sender").Preload("Attachments").Where("conversation_id = ?", conversation.ID)
+	if before := c.Query("before"); before != "" {
+		query = query.Where("id < ?", before)
+	}
+	if q := c.Query("q"); q != "" {
+		query = query.Where(`content ILIKE ? ESCAPE '\'`, containsPattern(q))
+	}
+
+	var messages []models.Message
+	if err := query.Order("id desc").Limit(limit).Find(&messages).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch messages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+func (h *BaseHandler) SendMessage(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	conversation, ok := h.findConversation(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	var input SendMessageInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, participantID := range participantIDs(conversation) {
+		if participantID != userID.(uint) && !h.checkNotBlocked(c, userID.(uint), participantID, "You can't message this user") {
+			return
+		}
+	}
+
+	message, err := h.createMessage(conversation, userID.(uint), input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send message"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// MarkConversationRead moves the user's read receipt to the newest message in the conversation.
+func (h *BaseHandler) MarkConversationRead(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	conversation, ok := h.findConversation(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	var latest models.Message
+	if err := h.DB.Where("conversation_id = ?", conversation.ID).Order("id desc").First(&latest).Error; err != nil {
+		c.JSON(http.StatusOK, gin.H{"message": "Nothing to read"})
+		return
+	}
+
+	now := time.Now()
+	if err := h.DB.Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversation.ID, userID.(uint)).
+		Updates(map[string]interface{}{"last_read_message_id": latest.ID, "last_read_at": now}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark conversation as read"})
+		return
+	}
+
+	receipt := gin.H{"conversationId": conversation.ID, "userId": userID.(uint), "lastReadMessageId": latest.ID, "readAt": now}
+	h.Realtime.Publish(realtime.Event{Type: "conversation.read", Data: receipt}, participantIDs(conversation)...)
+
+	c.JSON(http.StatusOK, receipt)
+}
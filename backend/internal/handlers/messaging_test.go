@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsPattern(t *testing.T) {
+	assert.Equal(t, "%audition%", containsPattern("audition"))
+
+	// Test case: Wildcards and the escape character in the query match literally
+	assert.Equal(t, `%100\% sure%`, containsPattern("100% sure"))
+	assert.Equal(t, `%first\_name%`, containsPattern("first_name"))
+	assert.Equal(t, `%C:\\path%`, containsPattern(`C:\path`))
+}
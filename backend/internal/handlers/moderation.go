@@ -0,0 +1,645 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errSubjectNotFound = errors.New("subject not found")
+
+// subjectAuthorColumns maps each reportable subject type to the column holding who posted it.
+// Subject types are also their table names.
+var subjectAuthorColumns = map[string]string{
+	moderation.SubjectPulse:         "user_id",
+	moderation.SubjectComment:       "user_id",
+	moderation.SubjectTalentProfile: "user_id",
+	moderation.SubjectCastingCall:   "posted_by_user_id",
+	moderation.SubjectAgency:        "created_by_user_id",
+	moderation.SubjectUser:          "id",
+}
+
+// subjectOwner returns the user who posted the reported content, or the reported account.
+func subjectOwner(db *gorm.DB, subjectType string, id uint) (uint, error) {
+	column, ok := subjectAuthorColumns[subjectType]
+	if !ok {
+		return 0, errSubjectNotFound
+	}
+	var owners []uint
+	if err := db.Table(subjectType).Where("id = ? AND deleted_at IS NULL", id).Pluck(column, &owners).Error; err != nil {
+		return 0, err
+	}
+	if len(owners) == 0 {
+		return 0, errSubjectNotFound
+	}
+	return owners[0], nil
+}
+
+// moderationNotices are what the affected user is told about each decision.
+var moderationNotices = map[string]string{
+	moderation.ActionHide:          "Some of your content was hidden by our moderators",
+	moderation.ActionRestore:       "Your content has been restored",
+	moderation.ActionWarn:          "You have received a warning from our moderators",
+	moderation.ActionSuspend:       "Your account has been suspended",
+	moderation.ActionBan:           "Your account has been banned",
+	moderation.ActionReinstate:     "Your account has been reinstated",
+	moderation.ActionAppealGranted: "Your appeal was granted",
+	moderation.ActionAppealDenied:  "Your appeal was not granted",
+}
+
+// notifyModerationDecision tells the affected user about a decision, pointing them at their
+// moderation status where they can appeal.
+func (h *BaseHandler) notifyModerationDecision(action models.ModerationAction) {
+	title, ok := moderationNotices[action.Action]
+	if !ok {
+		return
+	}
+	body := action.Note
+	if body == "" {
+		body = moderation.Reasons[action.Reason]
+	}
+	h.notifyUser(action.TargetUserID, "moderation_"+action.Action, title, body, "/moderation/status")
+}
+
+// updateStanding sets the user's account status from the suspensions and bans in force.
+func updateStanding(tx *gorm.DB, userID uint, now time.Time) error {
+	var actions []models.ModerationAction
+	if err := tx.Where("target_user_id = ? AND action IN ?", userID, []string{moderation.ActionSuspend, moderation.ActionBan}).Find(&actions).Error; err != nil {
+		return err
+	}
+	status, until := moderation.Standing(actions, now)
+	return tx.Model(&models.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"status": status, "suspended_until": until}).Error
+}
+
+// setHidden hides or unhides a piece of content.
+func setHidden(tx *gorm.DB, subjectType string, id uint, hiddenAt *time.Time) error {
+	return tx.Table(subjectType).Where("id = ?", id).Update("hidden_at", hiddenAt).Error
+}
+
+// applyModerationAction carries out a newly recorded action.
+func applyModerationAction(tx *gorm.DB, action models.ModerationAction, now time.Time) error {
+	switch action.Action {
+	case moderation.ActionHide:
+		return setHidden(tx, action.SubjectType, action.SubjectID, &now)
+	case moderation.ActionRestore:
+		if err := tx.Model(&models.ModerationAction{}).
+			Where("subject_type = ? AND subject_id = ? AND action = ? AND reversed_at IS NULL", action.SubjectType, action.SubjectID, moderation.ActionHide).
+			Update("reversed_at", now).Error; err != nil {
+			return err
+		}
+		return setHidden(tx, action.SubjectType, action.SubjectID, nil)
+	case moderation.ActionSuspend, moderation.ActionBan:
+		return updateStanding(tx, action.TargetUserID, now)
+	case moderation.ActionReinstate:
+		if err := tx.Model(&models.ModerationAction{}).
+			Where("target_user_id = ? AND action IN ? AND reversed_at IS NULL", action.TargetUserID, []string{moderation.ActionSuspend, moderation.ActionBan}).
+			Update("reversed_at", now).Error; err != nil {
+			return err
+		}
+		return updateStanding(tx, action.TargetUserID, now)
+	}
+	return nil
+}
+
+// reverseModerationAction undoes an action after its appeal is granted. Other actions against
+// the user stay in force.
+func reverseModerationAction(tx *gorm.DB, action models.ModerationAction, now time.Time) error {
+	if err := tx.Model(&models.ModerationAction{}).Where("id = ?", action.ID).Update("reversed_at", now).Error; err != nil {
+		return err
+	}
+	switch action.Action {
+	case moderation.ActionHide:
+		return setHidden(tx, action.SubjectType, action.SubjectID, nil)
+	case moderation.ActionSuspend, moderation.ActionBan:
+		return updateStanding(tx, action.TargetUserID, now)
+	}
+	return nil
+}
+
+// --- Report Handlers ---
+
+// GetReportReasons lists the reason codes reports can be filed under.
+func (h *BaseHandler) GetReportReasons(c *gin.Context) {
+	c.JSON(http.StatusOK, moderation.Reasons)
+}
+
+type CreateReportInput struct {
+	SubjectType string `json:"subjectType" binding:"required"` // pulses, comments, talent_profiles, casting_calls, agencies, users
+	SubjectID   uint   `json:"subjectId" binding:"required"`
+	Reason      string `json:"reason" binding:"required"`
+	Details     string `json:"details" binding:"max=2000"`
+}
+
+// CreateReport reports content or an account to the moderators.
+func (h *BaseHandler) CreateReport(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input CreateReportInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !moderation.IsReason(input.Reason) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown report reason"})
+		return
+	}
+
+	ownerID, err := subjectOwner(h.DB, input.SubjectType, input.SubjectID)
+	if errors.Is(err, errSubjectNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reported content not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create report"})
+		return
+	}
+	if ownerID == userID.(uint) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot report your own content"})
+		return
+	}
+
+	report := models.Report{
+		ReporterID:    userID.(uint),
+		SubjectType:   input.SubjectType,
+		SubjectID:     input.SubjectID,
+		SubjectUserID: ownerID,
+		Reason:        input.Reason,
+		Details:       input.Details,
+		Status:        moderation.ReportOpen,
+	}
+	result := h.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&report)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create report"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "You have already reported this"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// --- Moderation Queue Handlers (admin) ---
+
+// ModerationQueueEntry is a reported subject awaiting a decision, with its pending reports
+// rolled up.
+type ModerationQueueEntry struct {
+	SubjectType     string    `json:"subjectType"`
+	SubjectID       uint      `json:"subjectId"`
+	SubjectUserID   uint      `json:"subjectUserId"`
+	Reports         int64     `json:"reports"`
+	Reasons         []string  `json:"reasons"`
+	InReview        bool      `json:"inReview"` // A moderator has claimed at least one of the reports
+	FirstReportedAt time.Time `json:"firstReportedAt"`
+	LastReportedAt  time.Time `json:"lastReportedAt"`
+}
+
+// GetModerationQueue lists reported subjects with unresolved reports, most reported first.
+// Filter with ?status=open or in_review and ?subjectType=.
+func (h *BaseHandler) GetModerationQueue(c *gin.Context) {
+	_, limit := pageParams(c, 50)
+
+	query := h.DB.Model(&models.Report{}).
+		Select(`subject_type, subject_id, MAX(subject_user_id) AS subject_user_id, COUNT(*) AS reports,
+			STRING_AGG(DISTINCT reason, ',') AS reasons, BOOL_OR(status = ?) AS in_review,
+			MIN(created_at) AS first_reported_at, MAX(created_at) AS last_reported_at`, moderation.ReportInReview).
+		Where("resolved_at IS NULL").
+		Group("subject_type, subject_id")
+	if subjectType := c.Query("subjectType"); subjectType != "" {
+		query = query.Where("subject_type = ?", subjectType)
+	}
+	switch c.Query("status") {
+	case moderation.ReportOpen:
+		query = query.Having("NOT BOOL_OR(status = ?)", moderation.ReportInReview)
+	case moderation.ReportInReview:
+		query = query.Having("BOOL_OR(status = ?)", moderation.ReportInReview)
+	}
+
+	var rows []struct {
+		SubjectType     string
+		SubjectID       uint
+		SubjectUserID   uint
+		Reports         int64
+		Reasons         string
+		InReview        bool
+		FirstReportedAt time.Time
+		LastReportedAt  time.Time
+	}
+	if err := query.Order("reports desc, first_reported_at").Limit(limit).Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch moderation queue"})
+		return
+	}
+
+	queue := make([]ModerationQueueEntry, 0, len(rows))
+	for _, row := range rows {
+		queue = append(queue, ModerationQueueEntry{
+			SubjectType:     row.SubjectType,
+			SubjectID:       row.SubjectID,
+			SubjectUserID:   row.SubjectUserID,
+			Reports:         row.Reports,
+			Reasons:         strings.Split(row.Reasons, ","),
+			InReview:        row.InReview,
+			FirstReportedAt: row.FirstReportedAt,
+			LastReportedAt:  row.LastReportedAt,
+		})
+	}
+	c.JSON(http.StatusOK, queue)
+}
+
+// GetSubjectReports lists every report on a subject, given by ?subjectType= and ?subjectId=,
+// along with the moderation decisions already taken on it.
+func (h *BaseHandler) GetSubjectReports(c *gin.Context) {
+	subjectType, subjectID := c.Query("subjectType"), c.Query("subjectId")
+	if subjectType == "" || subjectID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "subjectType and subjectId are required"})
+		return
+	}
+
+	reports := []models.Report{}
+	if err := h.DB.Preload("Reporter").Where("subject_type = ? AND subject_id = ?", subjectType, subjectID).Order("id desc").Find(&reports).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch reports"})
+		return
+	}
+	actions := []models.ModerationAction{}
+	if err := h.DB.Preload("Moderator").Where("subject_type = ? AND subject_id = ?", subjectType, subjectID).Order("id desc").Find(&actions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch moderation history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reports": reports, "actions": actions})
+}
+
+type TriageReportInput struct {
+	Status string `json:"status" binding:"required,oneof=open in_review"`
+}
+
+// TriageReport claims a report for review, or hands it back to the queue. Reports are resolved
+// by taking a moderation action on their subject.
+func (h *BaseHandler) TriageReport(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input TriageReportInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var report models.Report
+	if err := h.DB.First(&report, c.Param("report_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		return
+	}
+	if !moderation.CanTransition(report.Status, input.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Report cannot move from " + report.Status + " to " + input.Status})
+		return
+	}
+
+	var assignee *uint
+	if input.Status == moderation.ReportInReview {
+		id := userID.(uint)
+		assignee = &id
+	}
+	if err := h.DB.Model(&report).Updates(map[string]interface{}{"status": input.Status, "assigned_to_id": assignee}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+type ModerationActionInput struct {
+	Action        string `json:"action" binding:"required,oneof=hide restore warn suspend ban reinstate dismiss"`
+	SubjectType   string `json:"subjectType" binding:"required"`
+	SubjectID     uint   `json:"subjectId" binding:"required"`
+	Reason        string `json:"reason"`                        // Reason code, required for enforcement actions
+	Note          string `json:"note"`                          // Shown to the affected user
+	DurationHours int    `json:"durationHours" binding:"min=0"` // Required for suspensions
+}
+
+// TakeModerationAction records a moderator's decision on a subject and carries it out. Account
+// actions taken on content apply to its author. Enforcement actions and dismissals resolve the
+// subject's pending reports.
+func (h *BaseHandler) TakeModerationAction(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input ModerationActionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (input.Reason != "" || moderation.Appealable(input.Action)) && !moderation.IsReason(input.Reason) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A valid reason code is required"})
+		return
+	}
+	if (input.Action == moderation.ActionHide || input.Action == moderation.ActionRestore) && !moderation.IsContent(input.SubjectType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only content can be hidden or restored"})
+		return
+	}
+	duration := time.Duration(input.DurationHours) * time.Hour
+	if input.Action == moderation.ActionSuspend && (duration <= 0 || duration > moderation.MaxSuspension) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Suspensions need a duration of up to a year"})
+		return
+	}
+
+	targetUserID, err := subjectOwner(h.DB, input.SubjectType, input.SubjectID)
+	if errors.Is(err, errSubjectNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Subject not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to take moderation action"})
+		return
+	}
+	if input.Action == moderation.ActionSuspend || input.Action == moderation.ActionBan {
+		var target models.User
+		if err := h.DB.First(&target, targetUserID).Error; err == nil && target.Role == "admin" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Admins cannot be suspended or banned"})
+			return
+		}
+	}
+
+	now := time.Now()
+	action := models.ModerationAction{
+		ModeratorID:  userID.(uint),
+		Action:       input.Action,
+		SubjectType:  input.SubjectType,
+		SubjectID:    input.SubjectID,
+		TargetUserID: targetUserID,
+		Reason:       input.Reason,
+		Note:         input.Note,
+	}
+	if input.Action == moderation.ActionSuspend {
+		expiresAt := now.Add(duration)
+		action.ExpiresAt = &expiresAt
+	}
+
+	var reporterIDs []uint
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&action).Error; err != nil {
+			return err
+		}
+		if err := applyModerationAction(tx, action, now); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ScreeningResult{}).
+			Where("content_type = ? AND content_id = ? AND reviewed_at IS NULL", action.SubjectType, action.SubjectID).
+			Updates(map[string]interface{}{"reviewed_by_id": action.ModeratorID, "reviewed_at": now}).Error; err != nil {
+			return err
+		}
+		status, resolves := moderation.ReportOutcome(action.Action)
+		if !resolves {
+			return nil
+		}
+		pending := tx.Model(&models.Report{}).Where("subject_type = ? AND subject_id = ? AND resolved_at IS NULL", action.SubjectType, action.SubjectID)
+		if err := pending.Session(&gorm.Session{}).Pluck("reporter_id", &reporterIDs).Error; err != nil {
+			return err
+		}
+		return pending.Updates(map[string]interface{}{
+			"status":         status,
+			"resolved_by_id": action.ModeratorID,
+			"resolved_at":    now,
+			"action_id":      action.ID,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to take moderation action"})
+		return
+	}
+
+	h.notifyModerationDecision(action)
+	if len(reporterIDs) > 0 {
+		title := "We reviewed your report and took action"
+		if action.Action == moderation.ActionDismiss {
+			title = "We reviewed your report and found no violation"
+		}
+		if err := notifications.Send(h.DB, reporterIDs, "report_resolved", title, "", ""); err != nil {
+			log.Printf("Failed to notify reporters of moderation action %d: %v", action.ID, err)
+		}
+	}
+
+	c.JSON(http.StatusCreated, action)
+}
+
+// --- Appeal Handlers ---
+
+// GetModerationStatus shows the user their account standing and the appealable actions taken
+// against them, with their appeals. It stays reachable while the account is suspended or banned.
+func (h *BaseHandler) GetModerationStatus(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var user models.User
+	if err := h.DB.First(&user, userID.(uint)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	actions := []models.ModerationAction{}
+	if err := h.DB.Where("target_user_id = ? AND action IN ?", user.ID, []string{moderation.ActionHide, moderation.ActionWarn, moderation.ActionSuspend, moderation.ActionBan}).
+		Order("id desc").Limit(100).Find(&actions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch moderation history"})
+		return
+	}
+	for i := range actions {
+		actions[i].ModeratorID = 0 // Moderators stay anonymous to the people they act on
+	}
+	appeals := []models.Appeal{}
+	if err := h.DB.Where("user_id = ?", user.ID).Order("id desc").Find(&appeals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch appeals"})
+		return
+	}
+	for i := range appeals {
+		appeals[i].ReviewerID = nil
+	}
+
+	status := moderation.AccountStatus(user, time.Now())
+	response := gin.H{"status": status, "actions": actions, "appeals": appeals}
+	if status == moderation.AccountSuspended {
+		response["suspendedUntil"] = user.SuspendedUntil
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+type CreateAppealInput struct {
+	ActionID  uint   `json:"actionId" binding:"required"`
+	Statement string `json:"statement" binding:"required,max=5000"`
+}
+
+// CreateAppeal asks the moderators to reconsider an action taken against the user. Each action
+// can be appealed once, within the appeal window.
+func (h *BaseHandler) CreateAppeal(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input CreateAppealInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var action models.ModerationAction
+	if err := h.DB.First(&action, "id = ? AND target_user_id = ?", input.ActionID, userID.(uint)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Moderation action not found"})
+		return
+	}
+	if !moderation.Appealable(action.Action) || action.ReversedAt != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This action cannot be appealed"})
+		return
+	}
+	if time.Since(action.CreatedAt) > moderation.AppealWindow {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The window for appealing this action has closed"})
+		return
+	}
+
+	appeal := models.Appeal{ActionID: action.ID, UserID: userID.(uint), Statement: input.Statement, Status: "pending"}
+	result := h.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&appeal)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create appeal"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "You have already appealed this action"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, appeal)
+}
+
+// GetAppealQueue lists appeals by ?status= (pending by default), oldest first.
+func (h *BaseHandler) GetAppealQueue(c *gin.Context) {
+	status := c.DefaultQuery("status", "pending")
+
+	appeals := []models.Appeal{}
+	if err := h.DB.Preload("Action").Preload("User").Where("status = ?", status).Order("created_at asc").Find(&appeals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch appeals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, appeals)
+}
+
+type ReviewAppealInput struct {
+	Response string `json:"response"`
+}
+
+// GrantAppeal reverses the appealed action.
+func (h *BaseHandler) GrantAppeal(c *gin.Context) {
+	var input ReviewAppealInput
+	_ = c.ShouldBindJSON(&input) // The response is optional when granting
+	h.decideAppeal(c, true, input.Response)
+}
+
+func (h *BaseHandler) DenyAppeal(c *gin.Context) {
+	var input ReviewAppealInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.Response == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A response is required to deny an appeal"})
+		return
+	}
+	h.decideAppeal(c, false, input.Response)
+}
+
+// decideAppeal records the decision on the pending appeal in :appeal_id in the transparency
+// log and tells the user.
+func (h *BaseHandler) decideAppeal(c *gin.Context, grant bool, response string) {
+	userID, _ := c.Get("userID")
+
+	var appeal models.Appeal
+	if err := h.DB.Preload("Action").First(&appeal, "id = ? AND status = ?", c.Param("appeal_id"), "pending").Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pending appeal not found"})
+		return
+	}
+
+	status, decision := "denied", moderation.ActionAppealDenied
+	if grant {
+		status, decision = "granted", moderation.ActionAppealGranted
+	}
+	now := time.Now()
+	record := models.ModerationAction{
+		ModeratorID:  userID.(uint),
+		Action:       decision,
+		SubjectType:  appeal.Action.SubjectType,
+		SubjectID:    appeal.Action.SubjectID,
+		TargetUserID: appeal.UserID,
+		Reason:       appeal.Action.Reason,
+		Note:         response,
+		AppealID:     &appeal.ID,
+	}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&appeal).Updates(map[string]interface{}{
+			"status":      status,
+			"reviewer_id": userID.(uint),
+			"response":    response,
+			"decided_at":  now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+		if !grant {
+			return nil
+		}
+		return reverseModerationAction(tx, appeal.Action, now)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decide appeal"})
+		return
+	}
+
+	h.notifyModerationDecision(record)
+	c.JSON(http.StatusOK, appeal)
+}
+
+// --- Transparency Log ---
+
+// TransparencyEntry is a moderation decision as published in the transparency log, without the
+// people involved.
+type TransparencyEntry struct {
+	ID          uint      `json:"id"`
+	Action      string    `json:"action"`
+	SubjectType string    `json:"subjectType"`
+	Reason      string    `json:"reason"`
+	Reversed    bool      `json:"reversed"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// GetTransparencyLog publishes every moderation decision, newest first. Filter with ?action=
+// and page back with ?before=<entry id>.
+func (h *BaseHandler) GetTransparencyLog(c *gin.Context) {
+	before, limit := pageParams(c, 50)
+	query := h.DB.Model(&models.ModerationAction{})
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+
+	var actions []models.ModerationAction
+	if err := query.Order("id desc").Limit(limit).Find(&actions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch transparency log"})
+		return
+	}
+
+	entries := make([]TransparencyEntry, 0, len(actions))
+	for _, action := range actions {
+		entries = append(entries, TransparencyEntry{
+			ID:          action.ID,
+			Action:      action.Action,
+			SubjectType: action.SubjectType,
+			Reason:      action.Reason,
+			Reversed:    action.ReversedAt != nil,
+			CreatedAt:   action.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, entries)
+}
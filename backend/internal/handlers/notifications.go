@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/notifications"
+	"siddu-verse-backend/internal/webpush"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// engagementSubjects name the content types users are notified about comments and reactions
+// on, and where each lives in the frontend.
+var engagementSubjects = map[string]struct{ noun, path string }{
+	"pulses":        {"pulse", "/pulses/"},
+	"reviews":       {"review", "/reviews/"},
+	"casting_calls": {"casting call", "/talent/casting-calls/"},
+}
+
+// notifyEngagement tells the owner of an item that someone engaged with it, e.g. "alice liked
+// your pulse". Nobody is notified of their own activity, or about items without an owner.
+func (h *BaseHandler) notifyEngagement(actorID, ownerUserID uint, notificationType, verb, ownerType string, ownerID uint, body string) {
+	subject, ok := engagementSubjects[ownerType]
+	if !ok || ownerUserID == 0 || ownerUserID == actorID {
+		return
+	}
+	var actor models.User
+	if err := h.DB.First(&actor, actorID).Error; err != nil {
+		return
+	}
+	link := subject.path + strconv.FormatUint(uint64(ownerID), 10)
+	h.notifyUser(ownerUserID, notificationType, actor.Username+" "+verb+" "+subject.noun, body, link)
+}
+
+// --- Notification Inbox Handlers ---
+
+// GetNotifications lists the user's in-app notifications, newest first, with their unread
+// count. Pass ?unread=true for unread ones only, and page back with ?before=<notification id>.
+func (h *BaseHandler) GetNotifications(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	before, limit := pageParams(c, 20)
+
+	query := h.DB.Where("user_id = ?", userID.(uint))
+	if unread, _ := strconv.ParseBool(c.Query("unread")); unread {
+		query = query.Where("read_at IS NULL")
+	}
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	inbox := []models.Notification{}
+	if err := query.Order("id desc").Limit(limit).Find(&inbox).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch notifications"})
+		return
+	}
+
+	var unreadCount int64
+	if err := h.DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID.(uint)).Count(&unreadCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unreadCount": unreadCount, "notifications": inbox})
+}
+
+// findNotification loads the user's notification in the :id param. It writes the error
+// response on failure.
+func (h *BaseHandler) findNotification(c *gin.Context, userID uint) (models.Notification, bool) {
+	var notification models.Notification
+	if err := h.DB.First(&notification, "id = ? AND user_id = ?", c.Param("id"), userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return notification, false
+	}
+	return notification, true
+}
+
+func (h *BaseHandler) MarkNotificationRead(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	notification, ok := h.findNotification(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		if err := h.DB.Model(&notification).Update("read_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+func (h *BaseHandler) MarkNotificationUnread(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	notification, ok := h.findNotification(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	notification.ReadAt = nil
+	if err := h.DB.Model(&notification).Update("read_at", nil).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+		return
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+func (h *BaseHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	result := h.DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID.(uint)).Update("read_at", time.Now())
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notifications"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marked": result.RowsAffected})
+}
+
+// --- Notification Preference Handlers ---
+
+// NotificationPreference is one type's channels in the user's notification settings.
+type NotificationPreference struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	notifications.Channels
+}
+
+// GetNotificationPreferences lists every configurable notification type with the channels the
+// user receives it on.
+func (h *BaseHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var saved []models.NotificationPreference
+	if err := h.DB.Where("user_id = ?", userID.(uint)).Find(&saved).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch notification preferences"})
+		return
+	}
+	byType := map[string]models.NotificationPreference{}
+	for _, preference := range saved {
+		byType[preference.Type] = preference
+	}
+
+	preferences := make([]NotificationPreference, 0, len(notifications.Types))
+	for notificationType, info := range notifications.Types {
+		channels := info.Default
+		if preference, ok := byType[notificationType]; ok {
+			channels = notifications.Channels{InApp: preference.InApp, Email: preference.Email, Push: preference.Push}
+		}
+		preferences = append(preferences, NotificationPreference{Type: notificationType, Description: info.Description, Channels: channels})
+	}
+	sort.Slice(preferences, func(i, j int) bool { return preferences[i].Type < preferences[j].Type })
+
+	c.JSON(http.StatusOK, gin.H{"preferences": preferences, "pushKey": h.Pusher.PublicKey()})
+}
+
+type NotificationPreferenceInput struct {
+	Type  string `json:"type" binding:"required"`
+	InApp bool   `json:"inApp"`
+	Email bool   `json:"email"`
+	Push  bool   `json:"push"`
+}
+
+type UpdateNotificationPreferencesInput struct {
+	Preferences []NotificationPreferenceInput `json:"preferences" binding:"required,dive"`
+}
+
+// UpdateNotificationPreferences sets the channels for the given types, leaving the others as
+// they are.
+func (h *BaseHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input UpdateNotificationPreferencesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(input.Preferences))
+	for _, preference := range input.Preferences {
+		if _, ok := notifications.Types[preference.Type]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown notification type: " + preference.Type})
+			return
+		}
+		preferences = append(preferences, models.NotificationPreference{
+			UserID: userID.(uint),
+			Type:   preference.Type,
+			InApp:  preference.InApp,
+			Email:  preference.Email,
+			Push:   preference.Push,
+		})
+	}
+
+	if len(preferences) > 0 {
+		if err := h.DB.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"in_app", "email", "push", "updated_at"}),
+		}).Create(&preferences).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save notification preferences"})
+			return
+		}
+	}
+
+	h.GetNotificationPreferences(c)
+}
+
+// --- Web Push Subscription Handlers ---
+
+type PushSubscriptionInput struct {
+	Endpoint string `json:"endpoint" binding:"required,url"`
+	Keys     struct {
+		P256dh string `json:"p256dh" binding:"required"`
+		Auth   string `json:"auth" binding:"required"`
+	} `json:"keys"`
+}
+
+// SubscribePush registers a browser for web push. The body is the browser's
+// PushSubscription.toJSON(). An endpoint another user has subscribed can't be taken over.
+func (h *BaseHandler) SubscribePush(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input PushSubscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !webpush.ValidEndpoint(input.Endpoint) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "endpoint must be an https URL"})
+		return
+	}
+
+	subscription := models.PushSubscription{
+		UserID:    userID.(uint),
+		Endpoint:  input.Endpoint,
+		P256dh:    input.Keys.P256dh,
+		Auth:      input.Keys.Auth,
+		UserAgent: c.Request.UserAgent(),
+	}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Only the user who subscribed the endpoint can refresh its keys
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "endpoint"}},
+			DoUpdates: clause.AssignmentColumns([]string{"p256dh", "auth", "user_agent", "updated_at"}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "push_subscriptions.user_id = excluded.user_id"}}},
+		}).Create(&subscription).Error; err != nil {
+			return err
+		}
+		return tx.First(&subscription, "endpoint = ?", input.Endpoint).Error
+	})
+	if err == nil && subscription.UserID != userID.(uint) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This push endpoint is registered to another account"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save push subscription"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+func (h *BaseHandler) UnsubscribePush(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	result := h.DB.Unscoped().Where("id = ? AND user_id = ?", c.Param("id"), userID.(uint)).Delete(&models.PushSubscription{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove push subscription"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Push subscription not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Push subscription removed"})
+}
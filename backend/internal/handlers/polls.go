@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/polls"
+	"siddu-verse-backend/internal/realtime"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errAlreadyVoted = errors.New("already voted")
+
+// PollInput attaches a poll to a new pulse.
+type PollInput struct {
+	Options        []string  `json:"options" binding:"required"`
+	ClosesAt       time.Time `json:"closesAt" binding:"required"`
+	MultipleChoice bool      `json:"multipleChoice"`
+	HideResults    bool      `json:"hideResults"` // Withhold the results until the poll closes
+}
+
+// pollOptionOrder lists a poll's options in the order the author gave them.
+func pollOptionOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+// createPoll adds the poll, with options already validated, to the pulse.
+func createPoll(tx *gorm.DB, pulseID uint, input PollInput, options []string) error {
+	poll := models.Poll{
+		PulseID:        pulseID,
+		ClosesAt:       input.ClosesAt,
+		MultipleChoice: input.MultipleChoice,
+		HideResults:    input.HideResults,
+	}
+	for i, text := range options {
+		poll.Options = append(poll.Options, models.PollOption{Position: i, Text: text})
+	}
+	return tx.Create(&poll).Error
+}
+
+// pulsePolls collects the polls shown with the pulses, including those of the originals they
+// repost or quote.
+func pulsePolls(pulses []*models.Pulse) []*models.Poll {
+	var found []*models.Poll
+	for _, pulse := range pulses {
+		if pulse.Poll != nil {
+			found = append(found, pulse.Poll)
+		}
+		if pulse.OriginalPulse != nil && pulse.OriginalPulse.Poll != nil {
+			found = append(found, pulse.OriginalPulse.Poll)
+		}
+	}
+	return found
+}
+
+// presentPoll fills in what every viewer sees the same way: whether the poll has closed and
+// each option's share of the vote, or no tallies while the poll hides them.
+func presentPoll(poll *models.Poll, now time.Time) {
+	poll.Closed = polls.IsClosed(poll.ClosesAt, now)
+	poll.ResultsHidden = polls.ResultsHidden(poll.HideResults, poll.ClosesAt, now)
+	for i := range poll.Options {
+		if poll.ResultsHidden {
+			poll.Options[i].VoteCount = 0
+			poll.Options[i].Percent = 0
+		} else {
+			poll.Options[i].Percent = polls.Percent(poll.Options[i].VoteCount, poll.VoterCount)
+		}
+	}
+}
+
+// preparePolls presents the polls for the viewer, including which options they voted for.
+func (h *BaseHandler) preparePolls(viewerID uint, shown ...*models.Poll) {
+	if len(shown) == 0 {
+		return
+	}
+
+	mine := map[uint][]uint{}
+	if viewerID != 0 {
+		ids := make([]uint, 0, len(shown))
+		for _, poll := range shown {
+			ids = append(ids, poll.ID)
+		}
+		var votes []models.PollVote
+		h.DB.Where("poll_id IN ? AND user_id = ?", ids, viewerID).Find(&votes)
+		for _, vote := range votes {
+			mine[vote.PollID] = append(mine[vote.PollID], vote.OptionID)
+		}
+	}
+
+	now := time.Now()
+	for _, poll := range shown {
+		presentPoll(poll, now)
+		poll.MyVotes = mine[poll.ID]
+		if poll.MyVotes == nil {
+			poll.MyVotes = []uint{}
+		}
+	}
+}
+
+// pollTopic is the real-time topic a poll's result updates are published on.
+func pollTopic(pollID uint) string {
+	return "polls:" + strconv.FormatUint(uint64(pollID), 10)
+}
+
+// findPulsePoll loads the poll of the pulse in the :id param, if the viewer can see the pulse.
+// It writes the error response on failure.
+func (h *BaseHandler) findPulsePoll(c *gin.Context) (models.Poll, bool) {
+	var poll models.Poll
+	var pulse models.Pulse
+	if err := h.DB.Scopes(moderation.VisiblePulses, blocks.Exclude(viewerID(c), "pulses.user_id")).First(&pulse, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
+		return poll, false
+	}
+	if err := h.DB.Preload("Options", pollOptionOrder).First(&poll, "pulse_id = ?", pulse.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "This pulse has no poll"})
+		return poll, false
+	}
+	return poll, true
+}
+
+// --- Poll Handlers ---
+
+func (h *BaseHandler) GetPoll(c *gin.Context) {
+	poll, ok := h.findPulsePoll(c)
+	if !ok {
+		return
+	}
+	h.preparePolls(viewerID(c), &poll)
+	c.JSON(http.StatusOK, poll)
+}
+
+type PollVoteInput struct {
+	OptionIDs []uint `json:"optionIds" binding:"required"`
+}
+
+// VoteInPoll casts the user's ballot in the pulse's poll: one option, or several in a
+// multiple-choice poll. Each user votes once. The new results are pushed to everyone watching
+// the poll.
+func (h *BaseHandler) VoteInPoll(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	poll, ok := h.findPulsePoll(c)
+	if !ok {
+		return
+	}
+	if polls.IsClosed(poll.ClosesAt, time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This poll has closed"})
+		return
+	}
+
+	var input PollVoteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	optionIDs := make([]uint, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		optionIDs = append(optionIDs, option.ID)
+	}
+	ballot, err := polls.CheckBallot(input.OptionIDs, optionIDs, poll.MultipleChoice)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the poll so a user's concurrent ballots can't both be counted
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Poll{}, poll.ID).Error; err != nil {
+			return err
+		}
+		var voted int64
+		if err := tx.Model(&models.PollVote{}).Where("poll_id = ? AND user_id = ?", poll.ID, userID.(uint)).Count(&voted).Error; err != nil {
+			return err
+		}
+		if voted > 0 {
+			return errAlreadyVoted
+		}
+
+		votes := make([]models.PollVote, 0, len(ballot))
+		for _, optionID := range ballot {
+			votes = append(votes, models.PollVote{PollID: poll.ID, UserID: userID.(uint), OptionID: optionID})
+		}
+		if err := tx.Create(&votes).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PollOption{}).Where("id IN ?", ballot).
+			UpdateColumn("vote_count", gorm.Expr("vote_count + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Poll{}).Where("id = ?", poll.ID).
+			UpdateColumn("voter_count", gorm.Expr("voter_count + 1")).Error
+	})
+	if errors.Is(err, errAlreadyVoted) {
+		c.JSON(http.StatusConflict, gin.H{"error": "You have already voted in this poll"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record vote"})
+		return
+	}
+
+	if err := h.DB.Preload("Options", pollOptionOrder).First(&poll, poll.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch poll results"})
+		return
+	}
+	presentPoll(&poll, time.Now())
+	h.Realtime.PublishTopic(realtime.Event{Type: "poll.results", Data: poll}, pollTopic(poll.ID))
+
+	poll.MyVotes = ballot
+	c.JSON(http.StatusCreated, poll)
+}
+
+// StreamPollResults keeps a Server-Sent Events connection open and pushes the poll's results
+// each time someone votes, withholding the tallies if the poll hides them until it closes.
+func (h *BaseHandler) StreamPollResults(c *gin.Context) {
+	poll, ok := h.findPulsePoll(c)
+	if !ok {
+		return
+	}
+
+	events, unsubscribe := h.Realtime.SubscribeTopic(pollTopic(poll.ID))
+	defer unsubscribe()
+	streamEvents(c, events)
+}
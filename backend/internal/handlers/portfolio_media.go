@@ -0,0 +1,700 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/media"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/storage"
+	"siddu-verse-backend/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errChunkOffset = errors.New("chunk does not start at the received offset")
+
+const (
+	// uploadSessionTTL is how long a resumable upload can sit idle before it expires.
+	uploadSessionTTL = 24 * time.Hour
+	// maxChunkBytes bounds a single chunk of a resumable upload.
+	maxChunkBytes = 16 << 20
+	// maxOpenUploads is how many resumable uploads a user can have in progress at once.
+	maxOpenUploads = 3
+	// multipartOverhead allows for the form fields and boundaries around a multipart file.
+	multipartOverhead = 1 << 20
+)
+
+// portfolioOrder sorts preloaded portfolio items into their display order.
+func portfolioOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// nextPortfolioPosition is the position that places a new item after the profile's others.
+func nextPortfolioPosition(db *gorm.DB, profileID uint) (int, error) {
+	var last struct{ Position *int }
+	if err := db.Model(&models.PortfolioItem{}).Select("MAX(position) AS position").
+		Where("talent_profile_id = ?", profileID).Scan(&last).Error; err != nil {
+		return 0, err
+	}
+	if last.Position == nil {
+		return 0, nil
+	}
+	return *last.Position + 1, nil
+}
+
+// createPortfolioItem adds the item at the end of its profile's portfolio.
+func createPortfolioItem(db *gorm.DB, item *models.PortfolioItem) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		position, err := nextPortfolioPosition(tx, item.TalentProfileID)
+		if err != nil {
+			return err
+		}
+		item.Position = position
+		return tx.Create(item).Error
+	})
+}
+
+// parseContentRange reads a "bytes start-end/total" Content-Range header.
+func parseContentRange(header string) (start, end, total int64, err error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes ")
+	if !ok {
+		return 0, 0, 0, errors.New("Content-Range must be in the form \"bytes start-end/total\"")
+	}
+	span, totalPart, ok := strings.Cut(spec, "/")
+	startPart, endPart, ok2 := strings.Cut(span, "-")
+	if !ok || !ok2 {
+		return 0, 0, 0, errors.New("Content-Range must be in the form \"bytes start-end/total\"")
+	}
+
+	if start, err = strconv.ParseInt(startPart, 10, 64); err != nil {
+		return 0, 0, 0, errors.New("Content-Range has an invalid start")
+	}
+	if end, err = strconv.ParseInt(endPart, 10, 64); err != nil {
+		return 0, 0, 0, errors.New("Content-Range has an invalid end")
+	}
+	if total, err = strconv.ParseInt(totalPart, 10, 64); err != nil {
+		return 0, 0, 0, errors.New("Content-Range has an invalid total")
+	}
+	if start < 0 || end < start || end >= total {
+		return 0, 0, 0, errors.New("Content-Range is out of bounds")
+	}
+	return start, end, total, nil
+}
+
+// storePortfolioMedia validates the file, stores it and its thumbnails, and creates the
+// portfolio item at the end of the profile's portfolio. It writes the error response on
+// failure, and removes anything it stored if the item can't be created.
+func (h *BaseHandler) storePortfolioMedia(c *gin.Context, profileID uint, mediaType, title, description string, r io.ReadSeeker, size int64) (models.PortfolioItem, bool) {
+	var item models.PortfolioItem
+	if err := media.CheckSize(mediaType, size); err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return item, false
+	}
+	contentType, err := media.Sniff(r, mediaType)
+	if err != nil {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": fmt.Sprintf("Expected a %s file but received %s", mediaType, contentType)})
+		return item, false
+	}
+
+	token, err := utils.GenerateToken(12)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store upload"})
+		return item, false
+	}
+	base := fmt.Sprintf("portfolio/%d/%s", profileID, token)
+
+	item = models.PortfolioItem{
+		TalentProfileID: profileID,
+		Title:           title,
+		Description:     description,
+		MediaType:       mediaType,
+		ContentType:     contentType,
+		SizeBytes:       size,
+		StorageKey:      base + media.Extension(contentType),
+	}
+
+	var stored []string
+	cleanup := func() {
+		for _, key := range stored {
+			if err := h.Storage.Delete(c.Request.Context(), key); err != nil {
+				log.Printf("portfolio: failed to remove %s: %v", key, err)
+			}
+		}
+	}
+	put := func(key string, data io.Reader, size int64, contentType string) (string, error) {
+		url, err := h.Storage.Put(c.Request.Context(), key, data, size, contentType)
+		if err == nil {
+			stored = append(stored, key)
+		}
+		return url, err
+	}
+
+	if mediaType == media.TypeImage {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read upload"})
+			return item, false
+		}
+		processed, err := media.ProcessImage(data, contentType)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return item, false
+		}
+
+		item.Width, item.Height, item.SizeBytes = processed.Width, processed.Height, int64(len(processed.Data))
+		if item.MediaURL, err = put(item.StorageKey, bytes.NewReader(processed.Data), item.SizeBytes, contentType); err != nil {
+			log.Printf("portfolio: failed to store %s: %v", item.StorageKey, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store upload"})
+			return item, false
+		}
+
+		thumbnailType := media.ThumbnailContentType(contentType)
+		for _, thumbnail := range processed.Thumbnails {
+			key := base + "-" + thumbnail.Name + media.Extension(thumbnailType)
+			url, err := put(key, bytes.NewReader(thumbnail.Data), int64(len(thumbnail.Data)), thumbnailType)
+			if err != nil {
+				log.Printf("portfolio: failed to store %s: %v", key, err)
+				cleanup()
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store upload"})
+				return item, false
+			}
+			item.Thumbnails = append(item.Thumbnails, models.PortfolioThumbnail{
+				Name:       thumbnail.Name,
+				Width:      thumbnail.Width,
+				Height:     thumbnail.Height,
+				URL:        url,
+				StorageKey: key,
+			})
+		}
+	} else {
+		if item.MediaURL, err = put(item.StorageKey, r, size, contentType); err != nil {
+			log.Printf("portfolio: failed to store %s: %v", item.StorageKey, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store upload"})
+			return item, false
+		}
+	}
+
+	if err := createPortfolioItem(h.DB, &item); err != nil {
+		cleanup()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add portfolio item"})
+		return item, false
+	}
+	return item, true
+}
+
+// removePortfolioFiles deletes the stored files of a portfolio item. Items added by URL have
+// none. Failures are logged rather than returned since the item itself is already gone.
+func (h *BaseHandler) removePortfolioFiles(c *gin.Context, item models.PortfolioItem) {
+	keys := []string{}
+	if item.StorageKey != "" {
+		keys = append(keys, item.StorageKey)
+	}
+	for _, thumbnail := range item.Thumbnails {
+		keys = append(keys, thumbnail.StorageKey)
+	}
+	for _, key := range keys {
+		if err := h.Storage.Delete(c.Request.Context(), key); err != nil {
+			log.Printf("portfolio: failed to remove %s: %v", key, err)
+		}
+	}
+}
+
+// --- Portfolio Upload Handlers ---
+
+// UploadPortfolioMedia adds a portfolio item from a multipart form with a "file" field.
+func (h *BaseHandler) UploadPortfolioMedia(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to modify this profile"})
+		return
+	}
+
+	// Cap the body before the form is parsed, since parsing spools the whole file to disk. The
+	// limit for the declared media type is checked once the file's size is known.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, media.MaxUploadBytes()+multipartOverhead)
+	if _, err := c.MultipartForm(); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Expected a multipart form"})
+		return
+	}
+
+	if _, ok := media.LimitsFor(c.PostForm("mediaType")); !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mediaType must be one of image, video, audio"})
+		return
+	}
+	title := strings.TrimSpace(c.PostForm("title"))
+	if title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "title is required"})
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read upload"})
+		return
+	}
+	defer file.Close()
+
+	profileIDUint, _ := strconv.ParseUint(profileID, 10, 32)
+	screeningText := portfolioScreeningText(title, c.PostForm("description"))
+	screened, ok := h.screenEdit(c, userID.(uint), "talent_profiles", uint(profileIDUint), screeningText)
+	if !ok {
+		return
+	}
+
+	item, ok := h.storePortfolioMedia(c, uint(profileIDUint), c.PostForm("mediaType"), title, c.PostForm("description"), file, header.Size)
+	if !ok {
+		return
+	}
+	h.holdForReview(userID.(uint), "talent_profiles", item.TalentProfileID, screeningText, screened)
+
+	c.JSON(screenedStatus(screened, http.StatusCreated), item)
+}
+
+type CreatePortfolioUploadInput struct {
+	MediaType   string `json:"mediaType" binding:"required,oneof=image video audio"`
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	FileName    string `json:"fileName"`
+	TotalSize   int64  `json:"totalSize" binding:"required,min=1"`
+}
+
+// CreatePortfolioUpload starts a resumable upload. The client then PUTs the file in chunks
+// with a Content-Range header, and can ask for the received offset to resume after a failure.
+// A user can have maxOpenUploads uploads in progress at once.
+func (h *BaseHandler) CreatePortfolioUpload(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to modify this profile"})
+		return
+	}
+
+	var input CreatePortfolioUploadInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := media.CheckSize(input.MediaType, input.TotalSize); err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+
+	var open int64
+	if err := h.DB.Model(&models.PortfolioUpload{}).
+		Where("user_id = ? AND status = ? AND expires_at > ?", userID, "uploading", time.Now()).
+		Count(&open).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start upload"})
+		return
+	}
+	if open >= maxOpenUploads {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("You can have %d uploads in progress at once; finish or cancel one first", maxOpenUploads)})
+		return
+	}
+
+	// Screened now so rejected text fails before the file is sent, and again once it arrives
+	profileIDUint, _ := strconv.ParseUint(profileID, 10, 32)
+	if _, ok := h.screenEdit(c, userID.(uint), "talent_profiles", uint(profileIDUint), portfolioScreeningText(input.Title, input.Description)); !ok {
+		return
+	}
+
+	upload := models.PortfolioUpload{
+		TalentProfileID: uint(profileIDUint),
+		UserID:          userID.(uint),
+		MediaType:       input.MediaType,
+		FileName:        input.FileName,
+		Title:           input.Title,
+		Description:     input.Description,
+		TotalSize:       input.TotalSize,
+		Status:          "uploading",
+		ExpiresAt:       time.Now().Add(uploadSessionTTL),
+	}
+	if err := h.DB.Create(&upload).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start upload"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, upload)
+}
+
+// findPortfolioUpload loads the user's upload in the :upload_id param for the profile in :id.
+// It writes the error response on failure.
+func (h *BaseHandler) findPortfolioUpload(c *gin.Context, userID uint) (models.PortfolioUpload, bool) {
+	var upload models.PortfolioUpload
+	if err := h.DB.First(&upload, "id = ? AND talent_profile_id = ? AND user_id = ?", c.Param("upload_id"), c.Param("id"), userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+		return upload, false
+	}
+	return upload, true
+}
+
+// GetPortfolioUpload reports the upload's progress. ReceivedSize is the offset to resume from.
+func (h *BaseHandler) GetPortfolioUpload(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	upload, ok := h.findPortfolioUpload(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, upload)
+}
+
+// CancelPortfolioUpload abandons an upload in progress and discards the parts stored so far,
+// freeing its place among the user's open uploads.
+func (h *BaseHandler) CancelPortfolioUpload(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	upload, ok := h.findPortfolioUpload(c, userID.(uint))
+	if !ok {
+		return
+	}
+	result := h.DB.Model(&models.PortfolioUpload{}).Where("id = ? AND status = ?", upload.ID, "uploading").Update("status", "cancelled")
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel upload"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload is " + upload.Status})
+		return
+	}
+	h.abortPortfolioUpload(c, upload)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Upload cancelled"})
+}
+
+// UploadPortfolioChunk stores one chunk of a resumable upload as the next part of its multipart
+// upload. Chunks must arrive in order and, except for the last, be at least
+// storage.MinPartSize bytes: a chunk that doesn't start at the received offset gets a 409 with
+// the offset to resume from. The first chunk is sniffed so a file of the wrong type is refused
+// before the rest is sent, and the chunk that completes the file creates the portfolio item.
+func (h *BaseHandler) UploadPortfolioChunk(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	upload, ok := h.findPortfolioUpload(c, userID.(uint))
+	if !ok {
+		return
+	}
+	if upload.Status != "uploading" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload is " + upload.Status})
+		return
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		c.JSON(http.StatusGone, gin.H{"error": "Upload has expired, please start again"})
+		return
+	}
+
+	start, end, total, err := parseContentRange(c.GetHeader("Content-Range"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if total != upload.TotalSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Content-Range total does not match the upload size"})
+		return
+	}
+	if start != upload.ReceivedSize {
+		c.JSON(http.StatusConflict, gin.H{"error": "Chunk does not start at the received offset", "receivedSize": upload.ReceivedSize})
+		return
+	}
+	length := end - start + 1
+	if length > maxChunkBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Chunks cannot exceed %d bytes", maxChunkBytes)})
+		return
+	}
+	if end+1 < total && length < storage.MinPartSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Chunks other than the last must be at least %d bytes", storage.MinPartSize)})
+		return
+	}
+
+	chunk, err := io.ReadAll(io.LimitReader(c.Request.Body, length+1))
+	if err != nil || int64(len(chunk)) != length {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Chunk length does not match Content-Range"})
+		return
+	}
+
+	if upload.StorageUploadID == "" {
+		if upload, ok = h.startPortfolioUploadStorage(c, upload, chunk); !ok {
+			return
+		}
+	}
+
+	// Claim the offset with a row lock before storing the part, so concurrent chunks for the
+	// same range can't both be stored
+	ctx := c.Request.Context()
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&upload, upload.ID).Error; err != nil {
+			return err
+		}
+		if upload.Status != "uploading" || upload.ReceivedSize != start {
+			return errChunkOffset
+		}
+
+		var parts int64
+		if err := tx.Model(&models.PortfolioUploadPart{}).Where("portfolio_upload_id = ?", upload.ID).Count(&parts).Error; err != nil {
+			return err
+		}
+		part := models.PortfolioUploadPart{PortfolioUploadID: upload.ID, Number: int(parts) + 1, Size: length}
+		if part.ETag, err = h.Storage.PutPart(ctx, upload.StorageKey, upload.StorageUploadID, part.Number, bytes.NewReader(chunk), length); err != nil {
+			log.Printf("portfolio: failed to store part %d of upload %d: %v", part.Number, upload.ID, err)
+			return err
+		}
+		if err := tx.Create(&part).Error; err != nil {
+			return err
+		}
+
+		upload.ReceivedSize = end + 1
+		return tx.Model(&upload).Updates(map[string]interface{}{"received_size": upload.ReceivedSize, "expires_at": time.Now().Add(uploadSessionTTL)}).Error
+	})
+	switch {
+	case errors.Is(err, errChunkOffset):
+		c.JSON(http.StatusConflict, gin.H{"error": "Chunk does not start at the received offset", "receivedSize": upload.ReceivedSize})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save chunk"})
+		return
+	}
+
+	if upload.ReceivedSize < upload.TotalSize {
+		c.JSON(http.StatusOK, upload)
+		return
+	}
+
+	h.completePortfolioUpload(c, upload)
+}
+
+// startPortfolioUploadStorage sniffs the first chunk of an upload and starts the multipart
+// upload its parts are stored in. Images are joined under a staging key since they are
+// re-encoded before being kept; video and audio are joined where they are served from. It
+// writes the error response on failure.
+func (h *BaseHandler) startPortfolioUploadStorage(c *gin.Context, upload models.PortfolioUpload, chunk []byte) (models.PortfolioUpload, bool) {
+	contentType, err := media.Sniff(bytes.NewReader(chunk), upload.MediaType)
+	if err != nil {
+		h.DB.Model(&upload).Updates(map[string]interface{}{"status": "failed", "error": "The file does not match the media type"})
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": fmt.Sprintf("Expected a %s file but received %s", upload.MediaType, contentType)})
+		return upload, false
+	}
+
+	token, err := utils.GenerateToken(12)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save chunk"})
+		return upload, false
+	}
+	key := fmt.Sprintf("portfolio/%d/%s%s", upload.TalentProfileID, token, media.Extension(contentType))
+	if upload.MediaType == media.TypeImage {
+		key = fmt.Sprintf("portfolio-uploads/%d/%s%s", upload.ID, token, media.Extension(contentType))
+	}
+
+	ctx := c.Request.Context()
+	storageUploadID, err := h.Storage.CreateMultipart(ctx, key, contentType)
+	if err != nil {
+		log.Printf("portfolio: failed to start multipart upload for upload %d: %v", upload.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save chunk"})
+		return upload, false
+	}
+
+	// A concurrent first chunk may have started one already; keep whichever was saved first
+	result := h.DB.Model(&models.PortfolioUpload{}).Where("id = ? AND storage_upload_id = ''", upload.ID).
+		Updates(map[string]interface{}{"content_type": contentType, "storage_key": key, "storage_upload_id": storageUploadID})
+	if result.Error != nil || result.RowsAffected == 0 {
+		if err := h.Storage.AbortMultipart(ctx, key, storageUploadID); err != nil {
+			log.Printf("portfolio: failed to abort multipart upload for upload %d: %v", upload.ID, err)
+		}
+	}
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save chunk"})
+		return upload, false
+	}
+	if err := h.DB.First(&upload, upload.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save chunk"})
+		return upload, false
+	}
+	return upload, true
+}
+
+// abortPortfolioUpload discards the parts of an upload that won't be completed. Failures are
+// logged since the upload is already marked as ended.
+func (h *BaseHandler) abortPortfolioUpload(c *gin.Context, upload models.PortfolioUpload) {
+	if upload.StorageUploadID == "" {
+		return
+	}
+	if err := h.Storage.AbortMultipart(c.Request.Context(), upload.StorageKey, upload.StorageUploadID); err != nil {
+		log.Printf("portfolio: failed to abort multipart upload for upload %d: %v", upload.ID, err)
+	}
+	h.DB.Where("portfolio_upload_id = ?", upload.ID).Delete(&models.PortfolioUploadPart{})
+}
+
+// completePortfolioUpload joins the parts of a fully received upload and turns the file into a
+// portfolio item. Images are read back and processed like a direct upload, then the joined
+// original is removed.
+func (h *BaseHandler) completePortfolioUpload(c *gin.Context, upload models.PortfolioUpload) {
+	fail := func(reason string) {
+		h.DB.Model(&upload).Updates(map[string]interface{}{"status": "failed", "error": reason})
+	}
+
+	screeningText := portfolioScreeningText(upload.Title, upload.Description)
+	screened, ok := h.screenEdit(c, upload.UserID, "talent_profiles", upload.TalentProfileID, screeningText)
+	if !ok {
+		fail("The title or description was rejected")
+		h.abortPortfolioUpload(c, upload)
+		return
+	}
+
+	var parts []models.PortfolioUploadPart
+	if err := h.DB.Where("portfolio_upload_id = ?", upload.ID).Order("number").Find(&parts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete upload"})
+		return
+	}
+	storedParts := make([]storage.Part, 0, len(parts))
+	for _, part := range parts {
+		storedParts = append(storedParts, storage.Part{Number: part.Number, ETag: part.ETag})
+	}
+	ctx := c.Request.Context()
+	url, err := h.Storage.CompleteMultipart(ctx, upload.StorageKey, upload.StorageUploadID, storedParts)
+	if err != nil {
+		log.Printf("portfolio: failed to complete multipart upload for upload %d: %v", upload.ID, err)
+		fail("The file could not be assembled")
+		h.abortPortfolioUpload(c, upload)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete upload, please start again"})
+		return
+	}
+
+	var item models.PortfolioItem
+	if upload.MediaType == media.TypeImage {
+		defer func() {
+			if err := h.Storage.Delete(ctx, upload.StorageKey); err != nil {
+				log.Printf("portfolio: failed to remove %s: %v", upload.StorageKey, err)
+			}
+		}()
+		data, err := h.readStoredFile(c, upload.StorageKey, upload.TotalSize)
+		if err != nil {
+			log.Printf("portfolio: failed to read back upload %d: %v", upload.ID, err)
+			fail("The file could not be read back")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete upload, please start again"})
+			return
+		}
+		if item, ok = h.storePortfolioMedia(c, upload.TalentProfileID, upload.MediaType, upload.Title, upload.Description, bytes.NewReader(data), int64(len(data))); !ok {
+			fail("The file was rejected during processing")
+			return
+		}
+	} else {
+		item = models.PortfolioItem{
+			TalentProfileID: upload.TalentProfileID,
+			Title:           upload.Title,
+			Description:     upload.Description,
+			MediaType:       upload.MediaType,
+			ContentType:     upload.ContentType,
+			SizeBytes:       upload.TotalSize,
+			StorageKey:      upload.StorageKey,
+			MediaURL:        url,
+		}
+		if err := createPortfolioItem(h.DB, &item); err != nil {
+			h.removePortfolioFiles(c, item)
+			fail("The portfolio item could not be created")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add portfolio item"})
+			return
+		}
+	}
+	h.holdForReview(upload.UserID, "talent_profiles", item.TalentProfileID, screeningText, screened)
+
+	h.DB.Model(&upload).Updates(map[string]interface{}{"status": "completed", "portfolio_item_id": item.ID})
+	h.DB.Where("portfolio_upload_id = ?", upload.ID).Delete(&models.PortfolioUploadPart{})
+	c.JSON(screenedStatus(screened, http.StatusCreated), item)
+}
+
+// readStoredFile reads back a stored file of the expected size.
+func (h *BaseHandler) readStoredFile(c *gin.Context, key string, size int64) ([]byte, error) {
+	file, err := h.Storage.Get(c.Request.Context(), key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, size+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) != size {
+		return nil, fmt.Errorf("stored file is %d bytes, expected %d", len(data), size)
+	}
+	return data, nil
+}
+
+type ReorderPortfolioInput struct {
+	ItemIDs []uint `json:"itemIds" binding:"required,min=1"`
+}
+
+// ReorderPortfolio sets the display order of the profile's portfolio. itemIds must list every
+// item on the profile exactly once.
+func (h *BaseHandler) ReorderPortfolio(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to modify this profile"})
+		return
+	}
+
+	var input ReorderPortfolioInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var existing []uint
+	if err := h.DB.Model(&models.PortfolioItem{}).Where("talent_profile_id = ?", profileID).Pluck("id", &existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder portfolio"})
+		return
+	}
+	remaining := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		remaining[id] = true
+	}
+	for _, id := range input.ItemIDs {
+		if !remaining[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "itemIds must list each of the profile's portfolio items once"})
+			return
+		}
+		delete(remaining, id)
+	}
+	if len(remaining) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "itemIds must list each of the profile's portfolio items once"})
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		for position, id := range input.ItemIDs {
+			if err := tx.Model(&models.PortfolioItem{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder portfolio"})
+		return
+	}
+
+	var items []models.PortfolioItem
+	h.DB.Scopes(portfolioOrder).Preload("Thumbnails").Where("talent_profile_id = ?", profileID).Find(&items)
+	c.JSON(http.StatusOK, items)
+}
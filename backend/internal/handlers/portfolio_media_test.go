@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContentRange(t *testing.T) {
+	// Test case: A chunk in the middle of the file
+	start, end, total, err := parseContentRange("bytes 100-199/1000")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), start)
+	assert.Equal(t, int64(199), end)
+	assert.Equal(t, int64(1000), total)
+
+	// Test case: The final byte of the file
+	start, end, _, err = parseContentRange("bytes 999-999/1000")
+	assert.NoError(t, err)
+	assert.Equal(t, start, end)
+
+	// Test case: Malformed or out-of-bounds ranges are rejected
+	for _, header := range []string{"", "100-199/1000", "bytes 100-199", "bytes 200-100/1000", "bytes 0-1000/1000", "bytes -1-5/10", "bytes 0-9/*"} {
+		_, _, _, err := parseContentRange(header)
+		assert.Error(t, err, header)
+	}
+}
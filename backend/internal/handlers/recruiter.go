@@ -0,0 +1,484 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApplicationReview is the recruiter-side view of an application, with the
+// aggregated ratings and tags of everyone reviewing the casting call.
+type ApplicationReview struct {
+	models.Application
+	AverageRating float64  `json:"averageRating"`
+	RatingCount   int64    `json:"ratingCount"`
+	MyRating      int      `json:"myRating"`
+	Tags          []string `json:"tags"`
+	NoteCount     int64    `json:"noteCount"`
+}
+
+// applicationReviewFilter holds the query options for listing applications as a recruiter.
+type applicationReviewFilter struct {
+	Status    string
+	Tag       string
+	AgencyID  string
+	MinRating float64
+	SortBy    string // created_at, rating, status
+	SortOrder string // asc, desc
+}
+
+// normalizeTag lower-cases and trims a tag so "Callback " and "callback" are the same tag.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// loadApplicationReviews fetches the applications for a casting call along with their
+// ratings, tags and note counts, then applies the filter and sort order.
+func loadApplicationReviews(db *gorm.DB, castingCallID string, viewerID uint, filter applicationReviewFilter) ([]ApplicationReview, error) {
+	query := db.Preload("TalentProfile").Preload("Agency").Where("casting_call_id = ?", castingCallID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Tag != "" {
+		query = query.Where("id IN (?)", db.Model(&models.ApplicationTag{}).Select("application_id").Where("name = ?", filter.Tag))
+	}
+	if filter.AgencyID != "" {
+		query = query.Where("agency_id = ?", filter.AgencyID)
+	}
+
+	var applications []models.Application
+	if err := query.Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	if len(applications) == 0 {
+		return []ApplicationReview{}, nil
+	}
+
+	ids := make([]uint, len(applications))
+	for i, application := range applications {
+		ids[i] = application.ID
+	}
+
+	// Aggregate ratings per application
+	var ratingRows []struct {
+		ApplicationID uint
+		Average       float64
+		Total         int64
+	}
+	if err := db.Model(&models.ApplicationRating{}).
+		Select("application_id, AVG(score) AS average, COUNT(*) AS total").
+		Where("application_id IN ?", ids).
+		Group("application_id").
+		Scan(&ratingRows).Error; err != nil {
+		return nil, err
+	}
+
+	var myRatings []models.ApplicationRating
+	if err := db.Where("application_id IN ? AND rater_user_id = ?", ids, viewerID).Find(&myRatings).Error; err != nil {
+		return nil, err
+	}
+
+	var tags []models.ApplicationTag
+	if err := db.Where("application_id IN ?", ids).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var noteRows []struct {
+		ApplicationID uint
+		Total         int64
+	}
+	if err := db.Model(&models.ApplicationNote{}).
+		Select("application_id, COUNT(*) AS total").
+		Where("application_id IN ?", ids).
+		Group("application_id").
+		Scan(&noteRows).Error; err != nil {
+		return nil, err
+	}
+
+	reviews := make([]ApplicationReview, 0, len(applications))
+	byID := make(map[uint]*ApplicationReview, len(applications))
+	for _, application := range applications {
+		reviews = append(reviews, ApplicationReview{Application: application, Tags: []string{}})
+	}
+	for i := range reviews {
+		byID[reviews[i].ID] = &reviews[i]
+	}
+	for _, row := range ratingRows {
+		byID[row.ApplicationID].AverageRating = row.Average
+		byID[row.ApplicationID].RatingCount = row.Total
+	}
+	for _, rating := range myRatings {
+		byID[rating.ApplicationID].MyRating = rating.Score
+	}
+	for _, tag := range tags {
+		byID[tag.ApplicationID].Tags = append(byID[tag.ApplicationID].Tags, tag.Name)
+	}
+	for _, row := range noteRows {
+		byID[row.ApplicationID].NoteCount = row.Total
+	}
+
+	if filter.MinRating > 0 {
+		filtered := reviews[:0]
+		for _, review := range reviews {
+			if review.AverageRating >= filter.MinRating {
+				filtered = append(filtered, review)
+			}
+		}
+		reviews = filtered
+	}
+
+	sortApplicationReviews(reviews, filter.SortBy, filter.SortOrder == "asc")
+	return reviews, nil
+}
+
+// sortApplicationReviews orders reviews by the given field, newest first by default.
+func sortApplicationReviews(reviews []ApplicationReview, sortBy string, ascending bool) {
+	less := func(a, b ApplicationReview) bool {
+		switch sortBy {
+		case "rating":
+			return a.AverageRating < b.AverageRating
+		case "status":
+			return a.Status < b.Status
+		default:
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	}
+	sort.SliceStable(reviews, func(i, j int) bool {
+		if ascending {
+			return less(reviews[i], reviews[j])
+		}
+		return less(reviews[j], reviews[i])
+	})
+}
+
+// findRecruiterApplication loads the application in the :app_id param and verifies the
+// user holds at least minRole on its casting call team. It writes the error response on failure.
+func (h *BaseHandler) findRecruiterApplication(c *gin.Context, userID uint, minRole string) (models.Application, bool) {
+	var application models.Application
+	if err := h.DB.First(&application, c.Param("app_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found."})
+		return application, false
+	}
+
+	castingCallIDStr := strconv.FormatUint(uint64(application.CastingCallID), 10)
+	if !hasCastingCallRole(h.DB, userID, castingCallIDStr, minRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to review this application."})
+		return application, false
+	}
+	return application, true
+}
+
+// --- Recruiter Note Handlers ---
+
+type ApplicationNoteInput struct {
+	Content string `json:"content" binding:"required"`
+}
+
+func (h *BaseHandler) AddApplicationNote(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	application, ok := h.findRecruiterApplication(c, userID.(uint), CastingRoleReviewer)
+	if !ok {
+		return
+	}
+
+	var input ApplicationNoteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note := models.ApplicationNote{
+		ApplicationID: application.ID,
+		AuthorUserID:  userID.(uint),
+		Content:       input.Content,
+	}
+	if err := h.DB.Create(&note).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add note."})
+		return
+	}
+
+	h.DB.Preload("Author").First(&note, note.ID)
+	c.JSON(http.StatusCreated, note)
+}
+
+func (h *BaseHandler) GetApplicationNotes(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	application, ok := h.findRecruiterApplication(c, userID.(uint), CastingRoleViewer)
+	if !ok {
+		return
+	}
+
+	var notes []models.ApplicationNote
+	if err := h.DB.Preload("Author").Where("application_id = ?", application.ID).Order("created_at asc").Find(&notes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch notes."})
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+func (h *BaseHandler) DeleteApplicationNote(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	application, ok := h.findRecruiterApplication(c, userID.(uint), CastingRoleReviewer)
+	if !ok {
+		return
+	}
+
+	var note models.ApplicationNote
+	if err := h.DB.First(&note, "id = ? AND application_id = ?", c.Param("note_id"), application.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Note not found."})
+		return
+	}
+
+	// Only the author can remove their own note
+	if note.AuthorUserID != userID.(uint) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own notes."})
+		return
+	}
+
+	if err := h.DB.Delete(&note).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete note."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Note deleted successfully"})
+}
+
+// --- Rating Handlers ---
+
+type RateApplicationInput struct {
+	Score int `json:"score" binding:"required,min=1,max=5"`
+}
+
+func (h *BaseHandler) RateApplication(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	application, ok := h.findRecruiterApplication(c, userID.(uint), CastingRoleReviewer)
+	if !ok {
+		return
+	}
+
+	var input RateApplicationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Each recruiter has a single rating per application; rating again replaces it
+	var rating models.ApplicationRating
+	err := h.DB.Where("application_id = ? AND rater_user_id = ?", application.ID, userID.(uint)).First(&rating).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rate application."})
+		return
+	}
+
+	rating.ApplicationID = application.ID
+	rating.RaterUserID = userID.(uint)
+	rating.Score = input.Score
+	if err := h.DB.Save(&rating).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rate application."})
+		return
+	}
+
+	c.JSON(http.StatusOK, rating)
+}
+
+// --- Tag Handlers ---
+
+type ApplicationTagInput struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+func (h *BaseHandler) AddApplicationTag(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	application, ok := h.findRecruiterApplication(c, userID.(uint), CastingRoleReviewer)
+	if !ok {
+		return
+	}
+
+	var input ApplicationTagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := normalizeTag(input.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tag name cannot be empty."})
+		return
+	}
+
+	// Tagging twice, even concurrently, leaves the one tag
+	tag := models.ApplicationTag{ApplicationID: application.ID, Name: name}
+	err := h.DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "application_id"}, {Name: "name"}}, DoNothing: true}).Create(&tag).Error
+	if err == nil && tag.ID == 0 {
+		err = h.DB.First(&tag, "application_id = ? AND name = ?", application.ID, name).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add tag."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+func (h *BaseHandler) RemoveApplicationTag(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	application, ok := h.findRecruiterApplication(c, userID.(uint), CastingRoleReviewer)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.Unscoped().Where("application_id = ? AND name = ?", application.ID, normalizeTag(c.Param("tag"))).Delete(&models.ApplicationTag{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove tag."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag removed successfully"})
+}
+
+// --- Bulk Actions ---
+
+type BulkUpdateApplicationStatusInput struct {
+	ApplicationIDs []uint `json:"applicationIds" binding:"required,min=1"`
+	Status         string `json:"status" binding:"required,oneof=shortlisted rejected hired pending"`
+}
+
+// errApplicationsMismatch is returned when a bulk action references applications outside the casting call.
+var errApplicationsMismatch = errors.New("applications do not belong to this casting call")
+
+func (h *BaseHandler) BulkUpdateApplicationStatus(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleReviewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to update applications for this casting call."})
+		return
+	}
+
+	var input BulkUpdateApplicationStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// De-duplicate so the ownership count below is exact
+	seen := make(map[uint]bool, len(input.ApplicationIDs))
+	ids := make([]uint, 0, len(input.ApplicationIDs))
+	for _, id := range input.ApplicationIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var updated int64
+	var changedIDs []uint
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Application{}).Where("id IN ? AND casting_call_id = ?", ids, castingCallID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(ids)) {
+			return errApplicationsMismatch
+		}
+		// Only applicants whose status actually changes are notified
+		if err := tx.Model(&models.Application{}).Where("id IN ? AND status <> ?", ids, input.Status).Pluck("id", &changedIDs).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Application{}).Where("id IN ?", ids).Update("status", input.Status)
+		if result.Error != nil {
+			return result.Error
+		}
+		updated = result.RowsAffected
+
+		for _, id := range ids {
+			if err := recordApplicationEvent(tx, id, userID.(uint), nil, "status_changed", input.Status); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errApplicationsMismatch) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "One or more applications do not belong to this casting call."})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update application statuses."})
+		return
+	}
+
+	if len(changedIDs) > 0 {
+		var applications []models.Application
+		h.DB.Where("id IN ?", changedIDs).Find(&applications)
+		for _, application := range applications {
+			h.notifyApplicationStatus(application)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated, "status": input.Status})
+}
+
+// ExportShortlistCSV streams the shortlisted applications of a casting call as a CSV file.
+func (h *BaseHandler) ExportShortlistCSV(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	castingCallID := c.Param("id")
+
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to export applications for this casting call."})
+		return
+	}
+
+	filter := applicationReviewFilter{
+		Status:    c.DefaultQuery("status", "shortlisted"),
+		Tag:       normalizeTag(c.Query("tag")),
+		AgencyID:  c.Query("agency_id"),
+		SortBy:    "rating",
+		SortOrder: "desc",
+	}
+	reviews, err := loadApplicationReviews(h.DB, castingCallID, userID.(uint), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch applications."})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=casting-call-%s-shortlist.csv", castingCallID))
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"Application ID", "Talent Profile ID", "Full Name", "Headline", "Status", "Average Rating", "Ratings", "Tags", "Agency", "Applied At"})
+	for _, review := range reviews {
+		agency := ""
+		if review.Agency != nil {
+			agency = review.Agency.Name
+		}
+		writer.Write([]string{
+			strconv.FormatUint(uint64(review.ID), 10),
+			strconv.FormatUint(uint64(review.TalentProfileID), 10),
+			review.TalentProfile.FullName,
+			review.TalentProfile.Headline,
+			review.Status,
+			strconv.FormatFloat(review.AverageRating, 'f', 2, 64),
+			strconv.FormatInt(review.RatingCount, 10),
+			strings.Join(review.Tags, ";"),
+			agency,
+			review.CreatedAt.Format("2006-01-02"),
+		})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"siddu-verse-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	assert.Equal(t, "callback", normalizeTag("  Callback "))
+	assert.Equal(t, "", normalizeTag("   "))
+}
+
+func TestSortApplicationReviews(t *testing.T) {
+	now := time.Now()
+	reviews := []ApplicationReview{
+		{Application: models.Application{Status: "pending"}, AverageRating: 3},
+		{Application: models.Application{Status: "hired"}, AverageRating: 5},
+		{Application: models.Application{Status: "rejected"}, AverageRating: 1},
+	}
+	reviews[0].CreatedAt = now.Add(-2 * time.Hour)
+	reviews[1].CreatedAt = now.Add(-1 * time.Hour)
+	reviews[2].CreatedAt = now
+
+	// Test case: Highest rated first
+	sortApplicationReviews(reviews, "rating", false)
+	assert.Equal(t, []float64{5, 3, 1}, []float64{reviews[0].AverageRating, reviews[1].AverageRating, reviews[2].AverageRating})
+
+	// Test case: Oldest application first
+	sortApplicationReviews(reviews, "created_at", true)
+	assert.Equal(t, "pending", reviews[0].Status)
+	assert.Equal(t, "rejected", reviews[2].Status)
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/feed"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Pulse kinds.
+const (
+	PulseKindPost   = "post"
+	PulseKindRepost = "repost"
+	PulseKindQuote  = "quote"
+)
+
+var errAlreadyReposted = errors.New("already reposted")
+
+// findShareablePulse loads the pulse in the :id param to repost or quote. Sharing a repost
+// shares the pulse it reposted, so reposts never nest. It writes the error response on failure.
+func (h *BaseHandler) findShareablePulse(c *gin.Context) (models.Pulse, bool) {
+	var pulse models.Pulse
+	if err := h.DB.Scopes(moderation.VisiblePulses, blocks.Exclude(viewerID(c), "pulses.user_id")).First(&pulse, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
+		return pulse, false
+	}
+	if pulse.Kind == PulseKindRepost && pulse.OriginalPulseID != nil {
+		if err := h.DB.Scopes(moderation.VisiblePulses, blocks.Exclude(viewerID(c), "pulses.user_id")).First(&pulse, *pulse.OriginalPulseID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "The reposted pulse is no longer available"})
+			return pulse, false
+		}
+	}
+	return pulse, true
+}
+
+// notifyShared lets the original author know their pulse was reposted or quoted.
+func (h *BaseHandler) notifyShared(original models.Pulse, sharerID uint, notificationType, verb string) {
+	if original.UserID == sharerID {
+		return
+	}
+	var sharer models.User
+	h.DB.First(&sharer, sharerID)
+	link := "/pulses/" + strconv.FormatUint(uint64(original.ID), 10)
+	h.notifyUser(original.UserID, notificationType, sharer.Username+" "+verb+" your pulse", "", link)
+}
+
+// --- Repost Handlers ---
+
+// RepostPulse shares a pulse as-is to the user's followers. Each user can repost a pulse once.
+func (h *BaseHandler) RepostPulse(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	original, ok := h.findShareablePulse(c)
+	if !ok {
+		return
+	}
+
+	repost := models.Pulse{UserID: userID.(uint), Kind: PulseKindRepost, OriginalPulseID: &original.ID}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&repost)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errAlreadyReposted
+		}
+		return tx.Model(&models.Pulse{}).Where("id = ?", original.ID).
+			UpdateColumn("repost_count", gorm.Expr("repost_count + 1")).Error
+	})
+	if errors.Is(err, errAlreadyReposted) {
+		c.JSON(http.StatusConflict, gin.H{"error": "You have already reposted this pulse"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to repost"})
+		return
+	}
+
+	h.fanOutPulse(repost)
+	h.notifyShared(original, userID.(uint), notifications.TypeRepost, "reposted")
+
+	h.DB.Scopes(feed.Details(repost.UserID)).First(&repost, repost.ID)
+	h.preparePulses(repost.UserID, &repost)
+	c.JSON(http.StatusCreated, repost)
+}
+
+// UndoRepost removes the user's repost of the pulse in :id.
+func (h *BaseHandler) UndoRepost(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var repost models.Pulse
+	if err := h.DB.First(&repost, "user_id = ? AND original_pulse_id = ? AND kind = ?", userID.(uint), c.Param("id"), PulseKindRepost).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You have not reposted this pulse"})
+		return
+	}
+
+	if err := h.DB.Transaction(func(tx *gorm.DB) error { return deletePulse(tx, repost) }); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to undo repost"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Repost removed"})
+}
+
+// QuotePulse shares a pulse with the user's own commentary. The quote is a pulse in its own
+// right, so it is undone by deleting it.
+func (h *BaseHandler) QuotePulse(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input CreatePulseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Poll != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Quotes can't include a poll"})
+		return
+	}
+
+	original, ok := h.findShareablePulse(c)
+	if !ok {
+		return
+	}
+	screened, ok := h.screenContent(c, userID.(uint), "pulses", input.Content, input.MediaURL)
+	if !ok {
+		return
+	}
+
+	quote := models.Pulse{
+		UserID:          userID.(uint),
+		Content:         input.Content,
+		MediaURL:        input.MediaURL,
+		MediaType:       input.MediaType,
+		Kind:            PulseKindQuote,
+		OriginalPulseID: &original.ID,
+		HiddenAt:        heldAt(screened),
+	}
+	var mentioned []uint
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&quote).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Pulse{}).Where("id = ?", original.ID).
+			UpdateColumn("quote_count", gorm.Expr("quote_count + 1")).Error; err != nil {
+			return err
+		}
+		var err error
+		mentioned, err = indexPulse(tx, quote)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to quote pulse"})
+		return
+	}
+
+	h.recordScreening(quote.UserID, "pulses", quote.ID, quote.Content, screened)
+	if quote.HiddenAt == nil {
+		h.notifyMentions(quote, mentioned)
+		h.notifyShared(original, userID.(uint), notifications.TypeQuote, "quoted")
+	}
+	h.fanOutPulse(quote)
+
+	h.DB.Scopes(feed.Details(quote.UserID)).First(&quote, quote.ID)
+	h.preparePulses(quote.UserID, &quote)
+	c.JSON(screenedStatus(screened, http.StatusCreated), quote)
+}
+
+// GetPulseQuotes lists the quotes of a pulse, newest first. Page back with ?before=<pulse id>.
+func (h *BaseHandler) GetPulseQuotes(c *gin.Context) {
+	var original models.Pulse
+	if err := h.DB.First(&original, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
+		return
+	}
+
+	before, limit := pageParams(c, 20)
+	query := h.DB.Scopes(feed.Details(viewerID(c)), moderation.VisiblePulses, blocks.Exclude(viewerID(c), "pulses.user_id")).Where("original_pulse_id = ? AND kind = ?", original.ID, PulseKindQuote)
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	quotes := []models.Pulse{}
+	if err := query.Order("id desc").Limit(limit).Find(&quotes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch quotes"})
+		return
+	}
+	h.preparePulses(viewerID(c), pulseRefs(quotes)...)
+	c.JSON(http.StatusOK, quotes)
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/resume"
+	"siddu-verse-backend/internal/skills"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// --- Résumé Handlers ---
+
+// GetResumeTemplates lists the templates a résumé can be rendered with.
+func (h *BaseHandler) GetResumeTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"default": resume.DefaultTemplate, "templates": resume.Templates()})
+}
+
+// GetTalentProfileResume renders the profile as a résumé: printable HTML by default, or a
+// PDF with ?format=pdf. Templates only apply to HTML. ?download=1 serves it as an attachment
+// instead of inline.
+func (h *BaseHandler) GetTalentProfileResume(c *gin.Context) {
+	format := c.DefaultQuery("format", "html")
+	if format != "html" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown résumé format", "formats": []string{"html", "pdf"}})
+		return
+	}
+	templateName := c.DefaultQuery("template", resume.DefaultTemplate)
+	if !resume.HasTemplate(templateName) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown résumé template", "templates": resume.Templates()})
+		return
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.Preload("Skills").Preload("Experiences").Preload("Portfolio", portfolioOrder).
+		Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(viewerID(c), "talent_profiles.user_id")).First(&profile, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		return
+	}
+
+	var out bytes.Buffer
+	var err error
+	contentType := "text/html; charset=utf-8"
+	if format == "pdf" {
+		contentType = "application/pdf"
+		err = resume.RenderPDF(&out, profile)
+	} else {
+		err = resume.Render(&out, templateName, profile)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render résumé"})
+		return
+	}
+
+	if c.Query("download") == "1" {
+		fileName := strings.Join(strings.Fields(profile.FullName), "-") + "-resume." + format
+		c.Header("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	}
+	c.Data(http.StatusOK, contentType, out.Bytes())
+}
+
+// ImportJSONResume merges a JSON Resume (jsonresume.org) into the profile's skills and
+// experience. With ?dry_run=true the diff is returned without changing anything.
+func (h *BaseHandler) ImportJSONResume(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to update this profile"})
+		return
+	}
+
+	var input resume.JSONResume
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if unknown := input.UnknownLevels(); len(unknown) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown proficiency", "levels": unknown, "proficiencies": skills.Proficiencies})
+		return
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.Preload("Skills").Preload("Experiences").First(&profile, profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		return
+	}
+
+	// Map imported skill names through the taxonomy so they merge with existing skills
+	canonical := map[string]*models.TaxonomySkill{}
+	for i, imported := range input.Skills {
+		match, err := skills.Resolve(h.DB, imported.Name)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import résumé"})
+			return
+		}
+		if match != nil {
+			input.Skills[i].Name = match.Name
+			canonical[match.Name] = match
+		}
+	}
+
+	plan := resume.PlanImport(profile, input)
+	for i, change := range plan.SkillsAdded {
+		if match, ok := canonical[change.Skill.Name]; ok {
+			plan.SkillsAdded[i].Skill.TaxonomySkillID = &match.ID
+		}
+	}
+
+	if c.Query("dry_run") == "true" {
+		c.JSON(http.StatusOK, gin.H{"dryRun": true, "plan": plan})
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		for _, change := range plan.SkillsAdded {
+			if err := tx.Create(&change.Skill).Error; err != nil {
+				return err
+			}
+		}
+		for _, change := range plan.SkillsUpdated {
+			if err := tx.Model(&change.Skill).Update("proficiency", change.Skill.Proficiency).Error; err != nil {
+				return err
+			}
+		}
+		for _, change := range plan.ExperiencesAdded {
+			if err := tx.Create(&change.Experience).Error; err != nil {
+				return err
+			}
+		}
+		for _, change := range plan.ExperiencesUpdated {
+			if err := tx.Model(&change.Experience).Updates(map[string]interface{}{
+				"start_date":  change.Experience.StartDate,
+				"end_date":    change.Experience.EndDate,
+				"description": change.Experience.Description,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import résumé"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dryRun": false, "plan": plan})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Review Handlers ---
+
+type CreateReviewInput struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=10"`
+	Title   string `json:"title"`
+	Content string `json:"content" binding:"required"`
+}
+
+func (h *BaseHandler) CreateMovieReview(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var movie models.Movie
+	if err := h.DB.First(&movie, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+		return
+	}
+
+	var input CreateReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var existing int64
+	h.DB.Model(&models.Review{}).Where("movie_id = ? AND user_id = ?", movie.ID, userID.(uint)).Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "You have already reviewed this movie"})
+		return
+	}
+
+	review := models.Review{
+		MovieID: movie.ID,
+		UserID:  userID.(uint),
+		Rating:  input.Rating,
+		Title:   input.Title,
+		Content: input.Content,
+	}
+	if err := h.DB.Create(&review).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create review"})
+		return
+	}
+
+	h.DB.Preload("User").First(&review, review.ID)
+	c.JSON(http.StatusCreated, review)
+}
+
+// GetMovieReviews lists a movie's reviews, newest first. Page back with ?before=<review id>.
+func (h *BaseHandler) GetMovieReviews(c *gin.Context) {
+	var movie models.Movie
+	if err := h.DB.First(&movie, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+		return
+	}
+
+	before, limit := pageParams(c, 20)
+	query := h.DB.Preload("User").Scopes(moderation.NotBanned("reviews", "user_id")).Where("movie_id = ?", movie.ID)
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+	reviews := []models.Review{}
+	if err := query.Order("id desc").Limit(limit).Find(&reviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch reviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+func (h *BaseHandler) GetReviewByID(c *gin.Context) {
+	var review models.Review
+	if err := h.DB.Preload("User").Preload("Movie").Scopes(moderation.NotBanned("reviews", "user_id")).First(&review, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"siddu-verse-backend/internal/alerts"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// findSavedSearch loads the user's saved search in the :id param. It writes the error
+// response on failure.
+func (h *BaseHandler) findSavedSearch(c *gin.Context, userID uint) (models.SavedSearch, bool) {
+	var search models.SavedSearch
+	if err := h.DB.First(&search, "id = ? AND user_id = ?", c.Param("id"), userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return search, false
+	}
+	return search, true
+}
+
+// --- Saved Search Handlers ---
+
+type SavedSearchInput struct {
+	Name           string `json:"name" binding:"required"`
+	ProjectType    string `json:"projectType"`
+	Keywords       string `json:"keywords"`
+	Location       string `json:"location"`
+	RequiredSkills string `json:"requiredSkills"`
+	EmailDigest    string `json:"emailDigest" binding:"omitempty,oneof=none daily weekly"`
+}
+
+func (h *BaseHandler) CreateSavedSearch(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input SavedSearchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.EmailDigest == "" {
+		input.EmailDigest = alerts.DigestNone
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save search"})
+		return
+	}
+
+	search := models.SavedSearch{
+		UserID:           userID.(uint),
+		Name:             input.Name,
+		ProjectType:      input.ProjectType,
+		Keywords:         input.Keywords,
+		Location:         input.Location,
+		RequiredSkills:   input.RequiredSkills,
+		EmailDigest:      input.EmailDigest,
+		UnsubscribeToken: token,
+	}
+	if err := h.DB.Create(&search).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save search"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, search)
+}
+
+func (h *BaseHandler) GetSavedSearches(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var searches []models.SavedSearch
+	if err := h.DB.Where("user_id = ?", userID.(uint)).Order("created_at desc").Find(&searches).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch saved searches"})
+		return
+	}
+
+	c.JSON(http.StatusOK, searches)
+}
+
+func (h *BaseHandler) UpdateSavedSearch(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	search, ok := h.findSavedSearch(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	var input SavedSearchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.EmailDigest == "" {
+		input.EmailDigest = search.EmailDigest
+	}
+
+	if err := h.DB.Model(&search).Updates(map[string]interface{}{
+		"name":            input.Name,
+		"project_type":    input.ProjectType,
+		"keywords":        input.Keywords,
+		"location":        input.Location,
+		"required_skills": input.RequiredSkills,
+		"email_digest":    input.EmailDigest,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update saved search"})
+		return
+	}
+	if err := h.DB.First(&search, search.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update saved search"})
+		return
+	}
+
+	c.JSON(http.StatusOK, search)
+}
+
+func (h *BaseHandler) DeleteSavedSearch(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	search, ok := h.findSavedSearch(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	if err := h.DB.Delete(&search).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete saved search"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Saved search deleted successfully"})
+}
+
+// GetSavedSearchMatches lists the casting calls the search has matched, newest first.
+func (h *BaseHandler) GetSavedSearchMatches(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	search, ok := h.findSavedSearch(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	var matches []models.SavedSearchMatch
+	if err := h.DB.Preload("CastingCall").Where("saved_search_id = ?", search.ID).
+		Where("casting_call_id IN (?)", h.DB.Model(&models.CastingCall{}).Select("casting_calls.id").
+			Scopes(moderation.VisibleCastingCalls, blocks.Exclude(search.UserID, "casting_calls.posted_by_user_id"))).
+		Order("created_at desc").Find(&matches).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch matches"})
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+// UnsubscribeSavedSearch stops the email digest for one saved search. It is reached from the
+// link in each digest, so it authenticates by token rather than session. In-app
+// notifications for the search continue until it is deleted.
+func (h *BaseHandler) UnsubscribeSavedSearch(c *gin.Context) {
+	var search models.SavedSearch
+	if err := h.DB.First(&search, "unsubscribe_token = ?", c.Param("token")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unsubscribe link is invalid"})
+		return
+	}
+
+	if err := h.DB.Model(&search).Update("email_digest", alerts.DigestNone).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unsubscribe"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "You will no longer receive emails for \"" + search.Name + "\""})
+}
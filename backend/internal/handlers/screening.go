@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/screening"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxScreeningExcerpt is how much of screened text is kept with a screening result.
+const maxScreeningExcerpt = 280
+
+// screenContent runs user content past the content screener before it is stored. Rejected
+// content gets an error response and false. If the screener itself fails the content is let
+// through, so posting doesn't depend on it.
+func (h *BaseHandler) screenContent(c *gin.Context, userID uint, contentType, text string, mediaURLs ...string) (screening.Result, bool) {
+	return h.screenEdit(c, userID, contentType, 0, text, mediaURLs...)
+}
+
+// screenEdit is screenContent for an edit of the stored content with the given ID, which the
+// spam heuristics don't count against the edit.
+func (h *BaseHandler) screenEdit(c *gin.Context, userID uint, contentType string, contentID uint, text string, mediaURLs ...string) (screening.Result, bool) {
+	allowed := screening.Result{Verdict: screening.Allow}
+	if h.Screener == nil {
+		return allowed, true
+	}
+	result, err := h.Screener.Screen(c.Request.Context(), screening.Submission{
+		UserID:      userID,
+		ContentType: contentType,
+		ContentID:   contentID,
+		Text:        text,
+		MediaURLs:   mediaURLs,
+	})
+	if err != nil {
+		log.Printf("Failed to screen %s from user %d: %v", contentType, userID, err)
+		return allowed, true
+	}
+	if result.Verdict == screening.Reject {
+		h.recordScreening(userID, contentType, contentID, text, result)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "This can't be posted because it breaks our community guidelines", "reasons": result.Rules()})
+		return result, false
+	}
+	return result, true
+}
+
+// heldAt is the time to hide content the screener held for review, or nil to publish it.
+func heldAt(result screening.Result) *time.Time {
+	if result.Verdict != screening.Hold {
+		return nil
+	}
+	now := time.Now()
+	return &now
+}
+
+// screenedStatus is the status to respond with once screened content is stored: 202 when it
+// is held for review, status otherwise.
+func screenedStatus(result screening.Result, status int) int {
+	if result.Verdict == screening.Hold {
+		return http.StatusAccepted
+	}
+	return status
+}
+
+// holdForReview records the screening of text added to a talent profile, casting call or
+// agency, such as a portfolio item or role. Held text hides the whole profile, call or agency
+// until a moderator restores it, as a held edit of it would.
+func (h *BaseHandler) holdForReview(userID uint, contentType string, contentID uint, text string, result screening.Result) {
+	if hiddenAt := heldAt(result); hiddenAt != nil {
+		if err := setHidden(h.DB, contentType, contentID, hiddenAt); err != nil {
+			log.Printf("Failed to hold %s %d for review: %v", contentType, contentID, err)
+		}
+	}
+	h.recordScreening(userID, contentType, contentID, text, result)
+}
+
+// recordScreening keeps why content was held or rejected for moderators. contentID is 0 for
+// rejected new content, which isn't stored.
+func (h *BaseHandler) recordScreening(userID uint, contentType string, contentID uint, text string, result screening.Result) {
+	if result.Verdict == screening.Allow {
+		return
+	}
+	reasons := make([]string, 0, len(result.Reasons))
+	for _, reason := range result.Reasons {
+		reasons = append(reasons, reason.Rule+": "+reason.Detail)
+	}
+	excerpt := text
+	if runes := []rune(excerpt); len(runes) > maxScreeningExcerpt {
+		excerpt = string(runes[:maxScreeningExcerpt]) + "…"
+	}
+
+	record := models.ScreeningResult{
+		UserID:      userID,
+		ContentType: contentType,
+		ContentID:   contentID,
+		Verdict:     result.Verdict,
+		Reasons:     strings.Join(reasons, "\n"),
+		Excerpt:     excerpt,
+	}
+	if err := h.DB.Create(&record).Error; err != nil {
+		log.Printf("Failed to record screening of %s from user %d: %v", contentType, userID, err)
+	}
+}
+
+// --- Screening Handlers (admin) ---
+
+// GetScreeningQueue lists content screening held or rejected, newest first: ?verdict= is hold
+// (default) or reject. Held content is approved by restoring it with a moderation action, which
+// marks its screening reviewed; ?reviewed=true lists those instead. Page back with ?before=.
+func (h *BaseHandler) GetScreeningQueue(c *gin.Context) {
+	verdict := c.DefaultQuery("verdict", screening.Hold)
+	before, limit := pageParams(c, 50)
+
+	query := h.DB.Where("verdict = ?", verdict)
+	if c.Query("reviewed") == "true" {
+		query = query.Where("reviewed_at IS NOT NULL")
+	} else {
+		query = query.Where("reviewed_at IS NULL")
+	}
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+
+	results := []models.ScreeningResult{}
+	if err := query.Order("id desc").Limit(limit).Find(&results).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch screening queue"})
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,465 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"siddu-verse-backend/internal/skills"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// attachEndorsementCounts fills in EndorsementCount on each of the profile's loaded skills.
+func attachEndorsementCounts(db *gorm.DB, profile *models.TalentProfile) error {
+	if len(profile.Skills) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(profile.Skills))
+	for _, skill := range profile.Skills {
+		ids = append(ids, skill.ID)
+	}
+
+	var rows []struct {
+		SkillID uint
+		Total   int64
+	}
+	if err := db.Model(&models.SkillEndorsement{}).Select("skill_id, COUNT(*) AS total").
+		Where("skill_id IN ?", ids).Group("skill_id").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.SkillID] = row.Total
+	}
+	for i := range profile.Skills {
+		profile.Skills[i].EndorsementCount = counts[profile.Skills[i].ID]
+	}
+	return nil
+}
+
+// findProfileSkill loads the skill in the :skill_id param, which must belong to the profile
+// in the :id param. It writes the error response on failure.
+func (h *BaseHandler) findProfileSkill(c *gin.Context) (models.Skill, bool) {
+	var skill models.Skill
+	if err := h.DB.First(&skill, "id = ? AND talent_profile_id = ?", c.Param("skill_id"), c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Skill not found on this profile"})
+		return skill, false
+	}
+	return skill, true
+}
+
+// --- Skill Endorsement Handlers ---
+
+type EndorseSkillInput struct {
+	Comment string `json:"comment" binding:"max=280"`
+}
+
+func (h *BaseHandler) EndorseSkill(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input EndorseSkillInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.Scopes(moderation.VisibleTalentProfiles).First(&profile, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		return
+	}
+	if profile.UserID == userID.(uint) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot endorse your own skills"})
+		return
+	}
+	if !h.checkNotBlocked(c, userID.(uint), profile.UserID, "You can't endorse this profile") {
+		return
+	}
+
+	skill, ok := h.findProfileSkill(c)
+	if !ok {
+		return
+	}
+
+	var existing int64
+	h.DB.Model(&models.SkillEndorsement{}).Where("skill_id = ? AND endorser_user_id = ?", skill.ID, userID.(uint)).Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "You have already endorsed this skill"})
+		return
+	}
+
+	endorsement := models.SkillEndorsement{SkillID: skill.ID, EndorserUserID: userID.(uint), Comment: input.Comment}
+	if err := h.DB.Create(&endorsement).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to endorse skill"})
+		return
+	}
+
+	link := "/talent/profiles/" + strconv.FormatUint(uint64(profile.ID), 10)
+	if err := notifications.Send(h.DB, []uint{profile.UserID}, notifications.TypeSkillEndorsed, "Your "+skill.Name+" skill was endorsed", input.Comment, link); err != nil {
+		log.Printf("Failed to notify talent profile %d of endorsement: %v", profile.ID, err)
+	}
+
+	c.JSON(http.StatusCreated, endorsement)
+}
+
+func (h *BaseHandler) RemoveSkillEndorsement(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	skill, ok := h.findProfileSkill(c)
+	if !ok {
+		return
+	}
+
+	// Unscoped so the unique endorser index allows endorsing again later
+	result := h.DB.Unscoped().Where("skill_id = ? AND endorser_user_id = ?", skill.ID, userID.(uint)).Delete(&models.SkillEndorsement{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove endorsement"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You have not endorsed this skill"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Endorsement removed successfully"})
+}
+
+// GetSkillEndorsements lists who endorsed a skill, newest first.
+func (h *BaseHandler) GetSkillEndorsements(c *gin.Context) {
+	skill, ok := h.findProfileSkill(c)
+	if !ok {
+		return
+	}
+
+	var endorsements []models.SkillEndorsement
+	if err := h.DB.Preload("Endorser").Scopes(blocks.Exclude(viewerID(c), "skill_endorsements.endorser_user_id")).
+		Where("skill_id = ?", skill.ID).Order("created_at desc").Find(&endorsements).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch endorsements"})
+		return
+	}
+
+	c.JSON(http.StatusOK, endorsements)
+}
+
+// --- Skill Taxonomy Handlers ---
+
+// GetSkillTaxonomy lists the curated skills with their aliases, grouped by category. With
+// ?q= it returns only skills whose name or an alias contains the query, for autocomplete.
+func (h *BaseHandler) GetSkillTaxonomy(c *gin.Context) {
+	query := h.DB.Preload("Aliases").Order("name")
+	if q := skills.Key(c.Query("q")); q != "" {
+		like := containsPattern(q)
+		query = query.Where(`LOWER(name) LIKE ? ESCAPE '\'`, like).
+			Or("id IN (?)", h.DB.Model(&models.SkillAlias{}).Select("taxonomy_skill_id").Where(`name LIKE ? ESCAPE '\'`, like))
+	}
+
+	var taxonomy []models.TaxonomySkill
+	if err := query.Find(&taxonomy).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch skill taxonomy"})
+		return
+	}
+
+	var categories []models.SkillCategory
+	if err := h.DB.Order("name").Find(&categories).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch skill categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"categories":    categories,
+		"skills":        taxonomy,
+		"proficiencies": skills.Proficiencies,
+	})
+}
+
+// --- Admin Skill Taxonomy Handlers ---
+
+type CreateSkillCategoryInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+func (h *BaseHandler) CreateSkillCategory(c *gin.Context) {
+	var input CreateSkillCategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category := models.SkillCategory{Name: input.Name, Description: input.Description}
+	if err := h.DB.Create(&category).Error; err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "A category with this name already exists"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+type CreateTaxonomySkillInput struct {
+	Name        string   `json:"name" binding:"required"`
+	CategoryID  *uint    `json:"categoryId"`
+	Description string   `json:"description"`
+	Aliases     []string `json:"aliases"`
+	Synonyms    []string `json:"synonyms"`
+}
+
+// errAliasTaken is returned when an alias already resolves to a different taxonomy skill.
+var errAliasTaken = errors.New("alias already belongs to another skill")
+
+// addAliases records each name as an alias of the taxonomy skill, skipping its own name and
+// aliases it already has.
+func addAliases(tx *gorm.DB, skill models.TaxonomySkill, names []string, kind string) error {
+	for _, name := range names {
+		key := skills.Key(name)
+		if key == "" || key == skills.Key(skill.Name) {
+			continue
+		}
+
+		var existing models.SkillAlias
+		err := tx.Where("name = ?", key).First(&existing).Error
+		if err == nil {
+			if existing.TaxonomySkillID != skill.ID {
+				return errAliasTaken
+			}
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := tx.Create(&models.SkillAlias{TaxonomySkillID: skill.ID, Name: key, Kind: kind}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *BaseHandler) CreateTaxonomySkill(c *gin.Context) {
+	var input CreateTaxonomySkillInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if existing, err := skills.Resolve(h.DB, input.Name); err != nil || existing != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "This name already resolves to a skill in the taxonomy"})
+		return
+	}
+
+	skill := models.TaxonomySkill{Name: input.Name, CategoryID: input.CategoryID, Description: input.Description}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&skill).Error; err != nil {
+			return err
+		}
+		if err := addAliases(tx, skill, input.Aliases, "alias"); err != nil {
+			return err
+		}
+		return addAliases(tx, skill, input.Synonyms, "synonym")
+	})
+	if errors.Is(err, errAliasTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": "One or more aliases already belong to another skill"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create skill"})
+		return
+	}
+
+	h.DB.Preload("Aliases").First(&skill, skill.ID)
+	c.JSON(http.StatusCreated, skill)
+}
+
+type AddSkillAliasInput struct {
+	Name string `json:"name" binding:"required"`
+	Kind string `json:"kind" binding:"omitempty,oneof=alias synonym"`
+}
+
+func (h *BaseHandler) AddSkillAlias(c *gin.Context) {
+	var input AddSkillAliasInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Kind == "" {
+		input.Kind = "alias"
+	}
+
+	var skill models.TaxonomySkill
+	if err := h.DB.First(&skill, c.Param("skill_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Skill not found"})
+		return
+	}
+
+	err := addAliases(h.DB, skill, []string{input.Name}, input.Kind)
+	if errors.Is(err, errAliasTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This alias already belongs to another skill"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add alias"})
+		return
+	}
+
+	h.DB.Preload("Aliases").First(&skill, skill.ID)
+	c.JSON(http.StatusCreated, skill)
+}
+
+func (h *BaseHandler) RemoveSkillAlias(c *gin.Context) {
+	result := h.DB.Unscoped().Where("id = ? AND taxonomy_skill_id = ?", c.Param("alias_id"), c.Param("skill_id")).Delete(&models.SkillAlias{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove alias"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Alias not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alias removed successfully"})
+}
+
+// GetUnmappedSkills lists free-text skill names on profiles that don't resolve to the
+// taxonomy, most used first, so admins know what to curate or merge next.
+func (h *BaseHandler) GetUnmappedSkills(c *gin.Context) {
+	var rows []struct {
+		Name     string `json:"name"`
+		Profiles int64  `json:"profiles"`
+	}
+	if err := h.DB.Model(&models.Skill{}).
+		Select("LOWER(TRIM(name)) AS name, COUNT(DISTINCT talent_profile_id) AS profiles").
+		Where("taxonomy_skill_id IS NULL").
+		Group("LOWER(TRIM(name))").
+		Order("profiles desc, name").
+		Limit(100).
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch unmapped skills"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
+
+type MergeSkillsInput struct {
+	SourceSkillIDs []uint   `json:"sourceSkillIds"`
+	Names          []string `json:"names"`
+}
+
+// MergeSkills folds duplicate taxonomy skills and free-text skill names into the skill in
+// :skill_id. Their names become aliases, profile skills are repointed and renamed, and a
+// profile left holding the skill twice keeps one entry with the endorsements of both.
+func (h *BaseHandler) MergeSkills(c *gin.Context) {
+	var input MergeSkillsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(input.SourceSkillIDs) == 0 && len(input.Names) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide sourceSkillIds or names to merge"})
+		return
+	}
+
+	var target models.TaxonomySkill
+	if err := h.DB.First(&target, c.Param("skill_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Skill not found"})
+		return
+	}
+	for _, id := range input.SourceSkillIDs {
+		if id == target.ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A skill cannot be merged into itself"})
+			return
+		}
+	}
+
+	var removed int
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		var sources []models.TaxonomySkill
+		if len(input.SourceSkillIDs) > 0 {
+			if err := tx.Find(&sources, input.SourceSkillIDs).Error; err != nil {
+				return err
+			}
+			if len(sources) != len(input.SourceSkillIDs) {
+				return gorm.ErrRecordNotFound
+			}
+		}
+
+		names := append([]string{}, input.Names...)
+		for _, source := range sources {
+			if err := tx.Model(&models.SkillAlias{}).Where("taxonomy_skill_id = ?", source.ID).Update("taxonomy_skill_id", target.ID).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Skill{}).Where("taxonomy_skill_id = ?", source.ID).Update("taxonomy_skill_id", target.ID).Error; err != nil {
+				return err
+			}
+			// Unscoped so the source's name is free to become an alias
+			if err := tx.Unscoped().Delete(&source).Error; err != nil {
+				return err
+			}
+			names = append(names, source.Name)
+		}
+
+		// Free-text names may already be aliases of the merged sources; those now point at the target
+		if err := addAliases(tx, target, names, "alias"); err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(names))
+		for _, name := range names {
+			keys = append(keys, skills.Key(name))
+		}
+		if err := tx.Model(&models.Skill{}).Where("taxonomy_skill_id IS NULL AND LOWER(TRIM(name)) IN ?", keys).
+			Update("taxonomy_skill_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Skill{}).Where("taxonomy_skill_id = ?", target.ID).Update("name", target.Name).Error; err != nil {
+			return err
+		}
+
+		var entries []models.Skill
+		if err := tx.Where("taxonomy_skill_id = ?", target.ID).Find(&entries).Error; err != nil {
+			return err
+		}
+		keep, duplicates := skills.DuplicateSkills(entries)
+		for _, duplicate := range duplicates {
+			kept := keep[duplicate.TalentProfileID]
+			if err := tx.Model(&models.SkillEndorsement{}).
+				Where("skill_id = ? AND endorser_user_id NOT IN (?)", duplicate.ID,
+					tx.Model(&models.SkillEndorsement{}).Select("endorser_user_id").Where("skill_id = ?", kept.ID)).
+				Update("skill_id", kept.ID).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("skill_id = ?", duplicate.ID).Delete(&models.SkillEndorsement{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&duplicate).Error; err != nil {
+				return err
+			}
+		}
+		removed = len(duplicates)
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "One or more source skills were not found"})
+		return
+	}
+	if errors.Is(err, errAliasTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": "One or more names already belong to another skill"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge skills"})
+		return
+	}
+
+	h.DB.Preload("Aliases").First(&target, target.ID)
+	c.JSON(http.StatusOK, gin.H{"skill": target, "duplicatesRemoved": removed})
+}
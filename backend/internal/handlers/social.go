@@ -1,18 +1,30 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/engagement"
+	"siddu-verse-backend/internal/feed"
 	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"siddu-verse-backend/internal/polls"
+	"siddu-verse-backend/internal/screening"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // --- Pulse Handlers ---
 
 type CreatePulseInput struct {
-	Content   string `json:"content" binding:"required"`
-	MediaURL  string `json:"mediaUrl"`
-	MediaType string `json:"mediaType"`
+	Content   string     `json:"content" binding:"required"`
+	MediaURL  string     `json:"mediaUrl"`
+	MediaType string     `json:"mediaType"`
+	Poll      *PollInput `json:"poll"`
 }
 
 func (h *BaseHandler) CreatePulse(c *gin.Context) {
@@ -28,33 +40,282 @@ func (h *BaseHandler) CreatePulse(c *gin.Context) {
 		return
 	}
 
+	screenedText := input.Content
+	var pollOptions []string
+	if input.Poll != nil {
+		var err error
+		if pollOptions, err = polls.Validate(input.Poll.Options, input.Poll.ClosesAt, time.Now()); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		screenedText += "\n" + strings.Join(pollOptions, "\n")
+	}
+
+	screened, ok := h.screenContent(c, userID.(uint), "pulses", screenedText, input.MediaURL)
+	if !ok {
+		return
+	}
+
 	pulse := models.Pulse{
 		UserID:    userID.(uint),
 		Content:   input.Content,
 		MediaURL:  input.MediaURL,
 		MediaType: input.MediaType,
+		Kind:      PulseKindPost,
+		HiddenAt:  heldAt(screened),
 	}
 
-	if result := h.DB.Create(&pulse); result.Error != nil {
+	var mentioned []uint
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&pulse).Error; err != nil {
+			return err
+		}
+		if input.Poll != nil {
+			if err := createPoll(tx, pulse.ID, *input.Poll, pollOptions); err != nil {
+				return err
+			}
+		}
+		var err error
+		mentioned, err = indexPulse(tx, pulse)
+		return err
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create pulse"})
 		return
 	}
+	h.recordScreening(pulse.UserID, "pulses", pulse.ID, screenedText, screened)
+	if pulse.HiddenAt == nil {
+		h.notifyMentions(pulse, mentioned)
+	}
+
+	h.fanOutPulse(pulse)
+
+	h.DB.Scopes(feed.Details(pulse.UserID)).First(&pulse, pulse.ID)
+	h.preparePulses(pulse.UserID, &pulse)
+	c.JSON(screenedStatus(screened, http.StatusCreated), pulse)
+}
+
+// fanOutPulse writes a new pulse into followers' feeds. It runs in the background so posting
+// stays fast for authors with many followers.
+func (h *BaseHandler) fanOutPulse(pulse models.Pulse) {
+	go func() {
+		if err := feed.FanOut(h.DB, pulse); err != nil {
+			log.Printf("Failed to fan out pulse %d: %v", pulse.ID, err)
+		}
+	}()
+}
+
+// preparePulses fills in what depends on the viewer and the time before pulses are returned:
+// reposts and quotes whose original pulse has been deleted or taken down are flagged, so
+// clients can show a placeholder instead of the embedded original; polls are presented for the
+// viewer; native link cards are filled in from what the viewer can see; and the pulses the
+// viewer bookmarked are marked.
+func (h *BaseHandler) preparePulses(viewerID uint, pulses ...*models.Pulse) {
+	for _, pulse := range pulses {
+		pulse.OriginalUnavailable = pulse.OriginalPulseID != nil && pulse.OriginalPulse == nil
+	}
+	h.preparePolls(viewerID, pulsePolls(pulses)...)
+	h.fillNativeCards(viewerID, pulses)
+	h.markBookmarked(viewerID, pulses)
+}
+
+// pulseRefs points at each pulse in a listing, for preparePulses.
+func pulseRefs(pulses []models.Pulse) []*models.Pulse {
+	refs := make([]*models.Pulse, len(pulses))
+	for i := range pulses {
+		refs[i] = &pulses[i]
+	}
+	return refs
+}
+
+// findOwnPulse loads the user's pulse in the :id param. It writes the error response on failure.
+func (h *BaseHandler) findOwnPulse(c *gin.Context, userID uint) (models.Pulse, bool) {
+	var pulse models.Pulse
+	if err := h.DB.First(&pulse, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
+		return pulse, false
+	}
+	if pulse.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only change your own pulses"})
+		return pulse, false
+	}
+	return pulse, true
+}
+
+// UpdatePulse edits the user's pulse, keeping the previous version in its edit history.
+// Reposts have no content of their own and can't be edited.
+func (h *BaseHandler) UpdatePulse(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	pulse, ok := h.findOwnPulse(c, userID.(uint))
+	if !ok {
+		return
+	}
+	if pulse.Kind == PulseKindRepost {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reposts cannot be edited"})
+		return
+	}
+
+	var input CreatePulseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Poll != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Polls can't be changed after posting"})
+		return
+	}
+
+	screened, ok := h.screenEdit(c, pulse.UserID, "pulses", pulse.ID, input.Content, input.MediaURL)
+	if !ok {
+		return
+	}
+	updates := map[string]interface{}{
+		"content":    input.Content,
+		"media_url":  input.MediaURL,
+		"media_type": input.MediaType,
+		"edited_at":  time.Now(),
+	}
+	// An edit held for review hides the pulse; an allowed one leaves a moderator's hide in place
+	if hiddenAt := heldAt(screened); hiddenAt != nil {
+		updates["hidden_at"] = hiddenAt
+	}
+
+	var mentioned []uint
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		previous := models.PulseEdit{PulseID: pulse.ID, Content: pulse.Content, MediaURL: pulse.MediaURL, MediaType: pulse.MediaType}
+		if err := tx.Create(&previous).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&pulse).Updates(updates).Error; err != nil {
+			return err
+		}
+		var err error
+		mentioned, err = indexPulse(tx, pulse)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update pulse"})
+		return
+	}
+	h.recordScreening(pulse.UserID, "pulses", pulse.ID, input.Content, screened)
+	if screened.Verdict != screening.Hold {
+		h.notifyMentions(pulse, mentioned)
+	}
+
+	h.DB.Scopes(feed.Details(pulse.UserID)).First(&pulse, pulse.ID)
+	h.preparePulses(pulse.UserID, &pulse)
+	c.JSON(screenedStatus(screened, http.StatusOK), pulse)
+}
+
+// GetPulseEdits lists a pulse's previous versions, most recent first.
+func (h *BaseHandler) GetPulseEdits(c *gin.Context) {
+	var pulse models.Pulse
+	if err := h.DB.Scopes(moderation.VisiblePulses, blocks.Exclude(viewerID(c), "pulses.user_id")).First(&pulse, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
+		return
+	}
 
-	c.JSON(http.StatusCreated, pulse)
+	edits := []models.PulseEdit{}
+	if err := h.DB.Where("pulse_id = ?", pulse.ID).Order("id desc").Find(&edits).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch edit history"})
+		return
+	}
+	c.JSON(http.StatusOK, edits)
+}
+
+// DeletePulse deletes the user's pulse. Deleting an original also removes its plain reposts;
+// quotes of it stay up with the original marked unavailable.
+func (h *BaseHandler) DeletePulse(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	pulse, ok := h.findOwnPulse(c, userID.(uint))
+	if !ok {
+		return
+	}
+
+	if err := h.DB.Transaction(func(tx *gorm.DB) error { return deletePulse(tx, pulse) }); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete pulse"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Pulse deleted successfully"})
+}
+
+// deletePulse soft-deletes a pulse and its plain reposts, takes them out of feeds and
+// trending, and updates the share counts of the pulse it shared, if any.
+func deletePulse(tx *gorm.DB, pulse models.Pulse) error {
+	ids := []uint{pulse.ID}
+	if pulse.Kind != PulseKindRepost {
+		var reposts []uint
+		if err := tx.Model(&models.Pulse{}).Where("original_pulse_id = ? AND kind = ?", pulse.ID, PulseKindRepost).Pluck("id", &reposts).Error; err != nil {
+			return err
+		}
+		ids = append(ids, reposts...)
+	}
+
+	if err := tx.Where("id IN ?", ids).Delete(&models.Pulse{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("pulse_id IN ?", ids).Delete(&models.FeedEntry{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("pulse_id IN ?", ids).Delete(&models.PulseHashtag{}).Error; err != nil {
+		return err
+	}
+
+	if pulse.OriginalPulseID == nil {
+		return nil
+	}
+	column := "quote_count"
+	if pulse.Kind == PulseKindRepost {
+		column = "repost_count"
+	}
+	return tx.Unscoped().Model(&models.Pulse{}).Where("id = ?", *pulse.OriginalPulseID).
+		UpdateColumn(column, gorm.Expr("GREATEST("+column+" - 1, 0)")).Error
 }
 
 func (h *BaseHandler) GetPulses(c *gin.Context) {
 	var pulses []models.Pulse
-	// Preload user data to include it in the response
-	if result := h.DB.Preload("User").Order("created_at desc").Find(&pulses); result.Error != nil {
+	// Preload user data, hashtags, mentions and links to include them in the response
+	// Pulses from users the viewer blocked, muted or was blocked by are left out
+	if result := h.DB.Scopes(feed.Details(viewerID(c)), moderation.VisiblePulses, blocks.ExcludeFromFeed(viewerID(c), "pulses.user_id")).Order("created_at desc").Find(&pulses); result.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch pulses"})
 		return
 	}
+	h.preparePulses(viewerID(c), pulseRefs(pulses)...)
+	c.JSON(http.StatusOK, pulses)
+}
+
+func (h *BaseHandler) GetPulseByID(c *gin.Context) {
+	var pulse models.Pulse
+	if err := h.DB.Scopes(feed.Details(viewerID(c)), moderation.VisiblePulses, blocks.Exclude(viewerID(c), "pulses.user_id")).First(&pulse, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
+		return
+	}
+	h.preparePulses(viewerID(c), &pulse)
+	c.JSON(http.StatusOK, pulse)
+}
+
+// GetPulseFeed returns the user's home timeline: pulses from the accounts and talent profiles
+// they follow, plus their own, newest first. Page back with ?before=<pulse id>.
+func (h *BaseHandler) GetPulseFeed(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	before, limit := pageParams(c, 20)
+
+	pulses, err := feed.Timeline(h.DB, userID.(uint), before, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch feed"})
+		return
+	}
+	h.preparePulses(viewerID(c), pulseRefs(pulses)...)
 	c.JSON(http.StatusOK, pulses)
 }
 
 // --- Like Handlers ---
 
+// LikePulse toggles a like on the pulse. Other reaction kinds go through the pulse's
+// reactions endpoints; liking a pulse the user reacted to differently changes it to a like.
 func (h *BaseHandler) LikePulse(c *gin.Context) {
 	pulseID := c.Param("id")
 
@@ -64,34 +325,29 @@ func (h *BaseHandler) LikePulse(c *gin.Context) {
 		return
 	}
 
-	like := models.Like{
-		UserID:    userID.(uint),
-		OwnerID:   0, // This will be set based on pulseID
-		OwnerType: "pulses",
-	}
-	// Convert pulseID to uint
-	if err := h.DB.Model(&models.Pulse{}).Where("id = ?", pulseID).First(&models.Pulse{}).Error; err != nil {
+	var pulse models.Pulse
+	if err := h.DB.Scopes(moderation.VisiblePulses, blocks.Exclude(userID.(uint), "pulses.user_id")).First(&pulse, pulseID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
 		return
 	}
-	// A bit of a hack to get the ID as uint
-	var pulse models.Pulse
-	h.DB.First(&pulse, pulseID)
-	like.OwnerID = pulse.ID
 
 	// Check if the user has already liked this pulse
 	var existingLike models.Like
-	if h.DB.Where("user_id = ? AND owner_id = ? AND owner_type = ?", like.UserID, like.OwnerID, like.OwnerType).First(&existingLike).Error == nil {
+	if h.DB.Where("user_id = ? AND owner_id = ? AND owner_type = ? AND kind = ?", userID.(uint), pulse.ID, "pulses", engagement.KindLike).First(&existingLike).Error == nil {
 		// User has already liked, so we unlike it
 		h.DB.Delete(&existingLike)
 		c.JSON(http.StatusOK, gin.H{"message": "Pulse unliked"})
 		return
 	}
 
-	if result := h.DB.Create(&like); result.Error != nil {
+	created, err := setReaction(h.DB, userID.(uint), "pulses", pulse.ID, engagement.KindLike)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like pulse"})
 		return
 	}
+	if created {
+		h.notifyEngagement(userID.(uint), pulse.UserID, notifications.TypeLike, "liked your", "pulses", pulse.ID, "")
+	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Pulse liked"})
 }
@@ -99,7 +355,8 @@ func (h *BaseHandler) LikePulse(c *gin.Context) {
 // --- Comment Handlers ---
 
 type CreateCommentInput struct {
-	Content string `json:"content" binding:"required"`
+	Content  string `json:"content" binding:"required"`
+	ParentID *uint  `json:"parentId"` // Set to reply to a comment on the same pulse
 }
 
 func (h *BaseHandler) CommentOnPulse(c *gin.Context) {
@@ -119,25 +376,10 @@ func (h *BaseHandler) CommentOnPulse(c *gin.Context) {
 
 	// A bit of a hack to get the ID as uint
 	var pulse models.Pulse
-	if err := h.DB.First(&pulse, pulseID).Error; err != nil {
+	if err := h.DB.Scopes(moderation.VisiblePulses, blocks.Exclude(userID.(uint), "pulses.user_id")).First(&pulse, pulseID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Pulse not found"})
 		return
 	}
 
-	comment := models.Comment{
-		UserID:    userID.(uint),
-		Content:   input.Content,
-		OwnerID:   pulse.ID,
-		OwnerType: "pulses",
-	}
-
-	if result := h.DB.Create(&comment); result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create comment"})
-		return
-	}
-
-	// Preload user for the response
-	h.DB.Preload("User").First(&comment, comment.ID)
-
-	c.JSON(http.StatusCreated, comment)
+	h.createComment(c, userID.(uint), "pulses", pulse.ID, pulse.UserID, input.Content, input.ParentID)
 }
@@ -1,9 +1,19 @@
 package handlers
 
 import (
+	"fmt"
+	"log"
 	"net/http"
+	"siddu-verse-backend/internal/alerts"
+	"siddu-verse-backend/internal/analytics"
+	"siddu-verse-backend/internal/blocks"
 	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"siddu-verse-backend/internal/skills"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -23,26 +33,18 @@ func isProfileOwner(db *gorm.DB, userID uint, profileID string) bool {
 	return profile.UserID == userID
 }
 
-// isCastingCallOwner checks if the authenticated user is the one who posted the casting call.
-func isCastingCallOwner(db *gorm.DB, userID uint, castingCallID string) bool {
-	var call models.CastingCall
-	callIDUint, err := strconv.ParseUint(castingCallID, 10, 32)
-	if err != nil {
-		return false
-	}
-
-	if err := db.First(&call, uint(callIDUint)).Error; err != nil {
-		return false
-	}
-	return call.PostedByUserID == userID
-}
-
 // --- Talent Profile Handlers ---
 
 type CreateTalentProfileInput struct {
 	FullName string `json:"fullName" binding:"required"`
 	Headline string `json:"headline"`
 	Bio      string `json:"bio"`
+	Location string `json:"location"`
+}
+
+// screeningText is the profile text checked by the content screener.
+func (input CreateTalentProfileInput) screeningText() string {
+	return strings.Join([]string{input.FullName, input.Headline, input.Bio, input.Location}, "\n")
 }
 
 func (h *BaseHandler) CreateTalentProfile(c *gin.Context) {
@@ -65,19 +67,27 @@ func (h *BaseHandler) CreateTalentProfile(c *gin.Context) {
 		return
 	}
 
+	screened, ok := h.screenContent(c, userID.(uint), "talent_profiles", input.screeningText())
+	if !ok {
+		return
+	}
+
 	profile := models.TalentProfile{
 		UserID:   userID.(uint),
 		FullName: input.FullName,
 		Headline: input.Headline,
 		Bio:      input.Bio,
+		Location: input.Location,
+		HiddenAt: heldAt(screened),
 	}
 
 	if result := h.DB.Create(&profile); result.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create talent profile"})
 		return
 	}
+	h.recordScreening(profile.UserID, "talent_profiles", profile.ID, input.screeningText(), screened)
 
-	c.JSON(http.StatusCreated, profile)
+	c.JSON(screenedStatus(screened, http.StatusCreated), profile)
 }
 
 func (h *BaseHandler) UpdateTalentProfile(c *gin.Context) {
@@ -101,18 +111,52 @@ func (h *BaseHandler) UpdateTalentProfile(c *gin.Context) {
 		return
 	}
 
+	screened, ok := h.screenEdit(c, profile.UserID, "talent_profiles", profile.ID, input.screeningText())
+	if !ok {
+		return
+	}
+
+	// A held update hides the profile until it is reviewed
 	h.DB.Model(&profile).Updates(models.TalentProfile{
 		FullName: input.FullName,
 		Headline: input.Headline,
 		Bio:      input.Bio,
+		Location: input.Location,
+		HiddenAt: heldAt(screened),
 	})
+	h.recordScreening(profile.UserID, "talent_profiles", profile.ID, input.screeningText(), screened)
 
-	c.JSON(http.StatusOK, profile)
+	c.JSON(screenedStatus(screened, http.StatusOK), profile)
 }
 
 func (h *BaseHandler) GetTalentProfiles(c *gin.Context) {
+	query := h.DB.Model(&models.TalentProfile{}).Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(viewerID(c), "talent_profiles.user_id"))
+	if verified := c.Query("verified"); verified != "" {
+		isVerified, err := strconv.ParseBool(verified)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "verified must be true or false"})
+			return
+		}
+		query = query.Where("is_verified = ?", isVerified)
+	}
+	if name := c.Query("skill"); name != "" {
+		// Search by taxonomy skill so every alias finds the same talent
+		skillQuery := h.DB.Model(&models.Skill{}).Select("talent_profile_id")
+		canonical, err := skills.Resolve(h.DB, name)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch talent profiles"})
+			return
+		}
+		if canonical != nil {
+			skillQuery = skillQuery.Where("taxonomy_skill_id = ?", canonical.ID)
+		} else {
+			skillQuery = skillQuery.Where("LOWER(name) = ?", skills.Key(name))
+		}
+		query = query.Where("id IN (?)", skillQuery)
+	}
+
 	var profiles []models.TalentProfile
-	if result := h.DB.Find(&profiles); result.Error != nil {
+	if result := query.Find(&profiles); result.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch talent profiles"})
 		return
 	}
@@ -122,15 +166,27 @@ func (h *BaseHandler) GetTalentProfiles(c *gin.Context) {
 func (h *BaseHandler) GetTalentProfileByID(c *gin.Context) {
 	id := c.Param("id")
 	var profile models.TalentProfile
-	if result := h.DB.Preload("Skills").Preload("Experiences").Preload("Portfolio").First(&profile, id); result.Error != nil {
+	if result := h.DB.Preload("Skills").Preload("Experiences").Preload("Portfolio", portfolioOrder).Preload("Portfolio.Thumbnails").Scopes(moderation.VisibleTalentProfiles, blocks.Exclude(viewerID(c), "talent_profiles.user_id")).First(&profile, id); result.Error != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Talent profile not found"})
 		return
 	}
+	if err := attachEndorsementCounts(h.DB, &profile); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch skill endorsements"})
+		return
+	}
+	h.recordView(c, analytics.SubjectTalentProfile, profile.ID)
 	c.JSON(http.StatusOK, profile)
 }
 
 // --- Skills Handlers ---
 
+type AddSkillInput struct {
+	Name        string `json:"name" binding:"required"`
+	Proficiency string `json:"proficiency"`
+}
+
+// AddSkillToProfile maps the free-text name through the skill taxonomy, so "acting" and
+// "Actor" are both stored as the curated "Acting", and normalizes the proficiency.
 func (h *BaseHandler) AddSkillToProfile(c *gin.Context) {
 	profileID := c.Param("id")
 	userID, _ := c.Get("userID")
@@ -140,21 +196,60 @@ func (h *BaseHandler) AddSkillToProfile(c *gin.Context) {
 		return
 	}
 
-	var skill models.Skill
-	if err := c.ShouldBindJSON(&skill); err != nil {
+	var input AddSkillInput
+	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	profileIDUint, _ := strconv.ParseUint(profileID, 10, 32)
-	skill.TalentProfileID = uint(profileIDUint)
+	skill := models.Skill{
+		TalentProfileID: uint(profileIDUint),
+		Name:            strings.Join(strings.Fields(input.Name), " "),
+	}
+
+	if input.Proficiency != "" {
+		proficiency, ok := skills.NormalizeProficiency(input.Proficiency)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown proficiency", "proficiencies": skills.Proficiencies})
+			return
+		}
+		skill.Proficiency = proficiency
+	}
+
+	canonical, err := skills.Resolve(h.DB, input.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add skill"})
+		return
+	}
+	duplicate := h.DB.Model(&models.Skill{}).Where("talent_profile_id = ?", skill.TalentProfileID)
+	if canonical != nil {
+		skill.Name = canonical.Name
+		skill.TaxonomySkillID = &canonical.ID
+		duplicate = duplicate.Where("taxonomy_skill_id = ? OR LOWER(name) = ?", canonical.ID, skills.Key(canonical.Name))
+	} else {
+		duplicate = duplicate.Where("LOWER(name) = ?", skills.Key(skill.Name))
+	}
+
+	var count int64
+	duplicate.Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "This skill is already on the profile"})
+		return
+	}
+
+	screened, ok := h.screenEdit(c, userID.(uint), "talent_profiles", skill.TalentProfileID, skill.Name)
+	if !ok {
+		return
+	}
 
 	if err := h.DB.Create(&skill).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add skill"})
 		return
 	}
+	h.holdForReview(userID.(uint), "talent_profiles", skill.TalentProfileID, skill.Name, screened)
 
-	c.JSON(http.StatusCreated, skill)
+	c.JSON(screenedStatus(screened, http.StatusCreated), skill)
 }
 
 func (h *BaseHandler) RemoveSkillFromProfile(c *gin.Context) {
@@ -177,6 +272,11 @@ func (h *BaseHandler) RemoveSkillFromProfile(c *gin.Context) {
 
 // --- Experience Handlers ---
 
+// experienceScreeningText is the experience text checked by the content screener.
+func experienceScreeningText(experience models.Experience) string {
+	return strings.Join([]string{experience.Title, experience.CompanyName, experience.Description}, "\n")
+}
+
 func (h *BaseHandler) AddExperienceToProfile(c *gin.Context) {
 	profileID := c.Param("id")
 	userID, _ := c.Get("userID")
@@ -195,12 +295,18 @@ func (h *BaseHandler) AddExperienceToProfile(c *gin.Context) {
 	profileIDUint, _ := strconv.ParseUint(profileID, 10, 32)
 	experience.TalentProfileID = uint(profileIDUint)
 
+	screened, ok := h.screenEdit(c, userID.(uint), "talent_profiles", experience.TalentProfileID, experienceScreeningText(experience))
+	if !ok {
+		return
+	}
+
 	if err := h.DB.Create(&experience).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add experience"})
 		return
 	}
+	h.holdForReview(userID.(uint), "talent_profiles", experience.TalentProfileID, experienceScreeningText(experience), screened)
 
-	c.JSON(http.StatusCreated, experience)
+	c.JSON(screenedStatus(screened, http.StatusCreated), experience)
 }
 
 func (h *BaseHandler) UpdateExperienceOnProfile(c *gin.Context) {
@@ -214,7 +320,7 @@ func (h *BaseHandler) UpdateExperienceOnProfile(c *gin.Context) {
 	}
 
 	var experience models.Experience
-	if err := h.DB.First(&experience, expID).Error; err != nil {
+	if err := h.DB.First(&experience, "id = ? AND talent_profile_id = ?", expID, profileID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Experience not found"})
 		return
 	}
@@ -225,8 +331,14 @@ func (h *BaseHandler) UpdateExperienceOnProfile(c *gin.Context) {
 		return
 	}
 
+	screened, ok := h.screenEdit(c, userID.(uint), "talent_profiles", experience.TalentProfileID, experienceScreeningText(input))
+	if !ok {
+		return
+	}
+
 	h.DB.Model(&experience).Updates(input)
-	c.JSON(http.StatusOK, experience)
+	h.holdForReview(userID.(uint), "talent_profiles", experience.TalentProfileID, experienceScreeningText(input), screened)
+	c.JSON(screenedStatus(screened, http.StatusOK), experience)
 }
 
 func (h *BaseHandler) RemoveExperienceFromProfile(c *gin.Context) {
@@ -249,6 +361,11 @@ func (h *BaseHandler) RemoveExperienceFromProfile(c *gin.Context) {
 
 // --- Portfolio Handlers ---
 
+// portfolioScreeningText is the portfolio item text checked by the content screener.
+func portfolioScreeningText(title, description string) string {
+	return title + "\n" + description
+}
+
 func (h *BaseHandler) AddPortfolioItem(c *gin.Context) {
 	profileID := c.Param("id")
 	userID, _ := c.Get("userID")
@@ -266,13 +383,22 @@ func (h *BaseHandler) AddPortfolioItem(c *gin.Context) {
 
 	profileIDUint, _ := strconv.ParseUint(profileID, 10, 32)
 	item.TalentProfileID = uint(profileIDUint)
+	// Stored file metadata is only set by the upload endpoints
+	item.StorageKey, item.ContentType, item.SizeBytes, item.Width, item.Height, item.Thumbnails = "", "", 0, 0, 0, nil
 
-	if err := h.DB.Create(&item).Error; err != nil {
+	screeningText := portfolioScreeningText(item.Title, item.Description)
+	screened, ok := h.screenEdit(c, userID.(uint), "talent_profiles", item.TalentProfileID, screeningText, item.MediaURL)
+	if !ok {
+		return
+	}
+
+	if err := createPortfolioItem(h.DB, &item); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add portfolio item"})
 		return
 	}
+	h.holdForReview(userID.(uint), "talent_profiles", item.TalentProfileID, screeningText, screened)
 
-	c.JSON(http.StatusCreated, item)
+	c.JSON(screenedStatus(screened, http.StatusCreated), item)
 }
 
 func (h *BaseHandler) RemovePortfolioItem(c *gin.Context) {
@@ -285,10 +411,23 @@ func (h *BaseHandler) RemovePortfolioItem(c *gin.Context) {
 		return
 	}
 
-	if err := h.DB.Delete(&models.PortfolioItem{}, itemID).Error; err != nil {
+	var item models.PortfolioItem
+	if err := h.DB.Preload("Thumbnails").First(&item, "id = ? AND talent_profile_id = ?", itemID, profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Portfolio item not found"})
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("portfolio_item_id = ?", item.ID).Delete(&models.PortfolioThumbnail{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&item).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove portfolio item"})
 		return
 	}
+	h.removePortfolioFiles(c, item)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Portfolio item removed successfully"})
 }
@@ -296,9 +435,12 @@ func (h *BaseHandler) RemovePortfolioItem(c *gin.Context) {
 // --- Casting Call Handlers ---
 
 type CreateCastingCallInput struct {
-	ProjectTitle string `json:"projectTitle" binding:"required"`
-	ProjectType  string `json:"projectType"`
-	Description  string `json:"description"`
+	ProjectTitle        string     `json:"projectTitle" binding:"required"`
+	ProjectType         string     `json:"projectType"`
+	Description         string     `json:"description"`
+	Status              string     `json:"status" binding:"omitempty,oneof=draft published"`
+	ApplicationDeadline *time.Time `json:"applicationDeadline"`
+	StartDate           *time.Time `json:"startDate"`
 }
 
 func (h *BaseHandler) CreateCastingCall(c *gin.Context) {
@@ -314,37 +456,109 @@ func (h *BaseHandler) CreateCastingCall(c *gin.Context) {
 		return
 	}
 
-	castingCall := models.CastingCall{
-		PostedByUserID: userID.(uint),
-		ProjectTitle:   input.ProjectTitle,
-		ProjectType:    input.ProjectType,
-		Description:    input.Description,
+	// Calls are published straight away unless saved as a draft
+	if input.Status == "" {
+		input.Status = models.CastingStatusPublished
+	}
+	if input.ApplicationDeadline != nil && !input.ApplicationDeadline.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Application deadline must be in the future"})
+		return
 	}
 
-	if result := h.DB.Create(&castingCall); result.Error != nil {
+	castingCall := models.CastingCall{
+		PostedByUserID:      userID.(uint),
+		ProjectTitle:        input.ProjectTitle,
+		ProjectType:         input.ProjectType,
+		Description:         input.Description,
+		Status:              input.Status,
+		ApplicationDeadline: input.ApplicationDeadline,
+		StartDate:           input.StartDate,
+	}
+	if castingCall.Status == models.CastingStatusPublished {
+		now := time.Now()
+		castingCall.PublishedAt = &now
+	}
+
+	screeningText := strings.Join([]string{input.ProjectTitle, input.ProjectType, input.Description}, "\n")
+	screened, ok := h.screenContent(c, castingCall.PostedByUserID, "casting_calls", screeningText)
+	if !ok {
+		return
+	}
+	castingCall.HiddenAt = heldAt(screened)
+
+	// Create the call together with the poster's owner membership
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&castingCall).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.CastingCallMember{
+			CastingCallID:   castingCall.ID,
+			UserID:          castingCall.PostedByUserID,
+			Role:            CastingRoleOwner,
+			InvitedByUserID: castingCall.PostedByUserID,
+		}).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create casting call"})
 		return
 	}
+	h.recordScreening(castingCall.PostedByUserID, "casting_calls", castingCall.ID, screeningText, screened)
 
-	c.JSON(http.StatusCreated, castingCall)
+	c.JSON(screenedStatus(screened, http.StatusCreated), castingCall)
 }
 
 func (h *BaseHandler) GetCastingCalls(c *gin.Context) {
+	// Drafts and archived calls are never listed publicly
+	status := c.DefaultQuery("status", models.CastingStatusPublished)
+	if status != models.CastingStatusPublished && status != models.CastingStatusClosed {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be published or closed"})
+		return
+	}
+
 	var calls []models.CastingCall
-	if result := h.DB.Preload("PostedByUser").Find(&calls); result.Error != nil {
+	if result := h.DB.Preload("PostedByUser").Preload("Roles").Scopes(moderation.VisibleCastingCalls, blocks.Exclude(viewerID(c), "casting_calls.posted_by_user_id")).Where("status = ?", status).Find(&calls); result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch casting calls"})
+		return
+	}
+
+	// The same criteria as saved searches, so a search can be saved straight from the listing
+	filter := models.SavedSearch{
+		ProjectType:    c.Query("project_type"),
+		Keywords:       c.Query("keywords"),
+		Location:       c.Query("location"),
+		RequiredSkills: c.Query("skills"),
+	}
+	taxonomy, err := skills.LoadTaxonomy(h.DB, alerts.SkillNames([]models.SavedSearch{filter}, calls))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch casting calls"})
 		return
 	}
-	c.JSON(http.StatusOK, calls)
+	filtered := make([]models.CastingCall, 0, len(calls))
+	for _, call := range calls {
+		if alerts.Matches(filter, call, taxonomy) {
+			filtered = append(filtered, call)
+		}
+	}
+	c.JSON(http.StatusOK, filtered)
 }
 
 func (h *BaseHandler) GetCastingCallByID(c *gin.Context) {
 	id := c.Param("id")
 	var call models.CastingCall
-	if result := h.DB.Preload("PostedByUser").Preload("Roles").First(&call, id); result.Error != nil {
+	if result := h.DB.Preload("PostedByUser").Preload("Roles").Scopes(moderation.VisibleCastingCalls, blocks.Exclude(viewerID(c), "casting_calls.posted_by_user_id")).First(&call, id); result.Error != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Casting call not found"})
 		return
 	}
+
+	// Drafts and archived calls are only visible to the casting call's team
+	if call.Status == models.CastingStatusDraft || call.Status == models.CastingStatusArchived {
+		userID, exists := c.Get("userID")
+		if !exists || !hasCastingCallRole(h.DB, userID.(uint), id, CastingRoleViewer) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Casting call not found"})
+			return
+		}
+	}
+	h.recordView(c, analytics.SubjectCastingCall, call.ID)
 	c.JSON(http.StatusOK, call)
 }
 
@@ -364,56 +578,122 @@ func (h *BaseHandler) ApplyToCastingCall(c *gin.Context) {
 		return
 	}
 
-	// 1. Find the user's talent profile
+	// Find the user's talent profile
 	var talentProfile models.TalentProfile
 	if err := h.DB.First(&talentProfile, "user_id = ?", userID.(uint)).Error; err != nil {
 		c.JSON(http.StatusForbidden, gin.H{"error": "User must have a talent profile to apply."})
 		return
 	}
 
-	castingCallID, _ := strconv.ParseUint(castingCallIDStr, 10, 32)
+	application, ok := h.submitApplication(c, talentProfile, castingCallIDStr, input.CoverLetter, userID.(uint), nil)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusCreated, application)
+}
+
+// submitApplication applies the profile to the casting call, either for the talent themself
+// or, when agencyID is set, by an agent on their behalf. The submission is recorded in the
+// application's audit trail. It writes the error response on failure.
+func (h *BaseHandler) submitApplication(c *gin.Context, profile models.TalentProfile, castingCallID, coverLetter string, submittedBy uint, agencyID *uint) (models.Application, bool) {
+	// Make sure the casting call is open for applications
+	var castingCall models.CastingCall
+	if err := h.DB.Scopes(moderation.VisibleCastingCalls).First(&castingCall, castingCallID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Casting call not found."})
+		return models.Application{}, false
+	}
+	if !isAcceptingApplications(castingCall, time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This casting call is not accepting applications."})
+		return models.Application{}, false
+	}
+	if !h.checkNotBlocked(c, profile.UserID, castingCall.PostedByUserID, "You can't apply to this casting call.") {
+		return models.Application{}, false
+	}
 
-	// 2. Check if already applied
+	// Check if already applied
 	var existingApplication models.Application
-	err := h.DB.First(&existingApplication, "talent_profile_id = ? AND casting_call_id = ?", talentProfile.ID, uint(castingCallID)).Error
-	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "You have already applied to this casting call."})
-		return
+	if err := h.DB.First(&existingApplication, "talent_profile_id = ? AND casting_call_id = ?", profile.ID, castingCall.ID).Error; err == nil {
+		if agencyID != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "This talent has already applied to this casting call."})
+		} else {
+			c.JSON(http.StatusConflict, gin.H{"error": "You have already applied to this casting call."})
+		}
+		return models.Application{}, false
 	}
 
-	// 3. Create new application
 	application := models.Application{
-		TalentProfileID: talentProfile.ID,
-		CastingCallID:   uint(castingCallID),
-		CoverLetter:     input.CoverLetter,
-		Status:          "pending",
+		TalentProfileID:   profile.ID,
+		CastingCallID:     castingCall.ID,
+		CoverLetter:       coverLetter,
+		Status:            "pending",
+		SubmittedByUserID: submittedBy,
+		AgencyID:          agencyID,
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&application).Error; err != nil {
+			return err
+		}
+		return recordApplicationEvent(tx, application.ID, submittedBy, agencyID, "submitted", "")
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit application."})
+		return models.Application{}, false
 	}
 
-	if result := h.DB.Create(&application); result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit application."})
-		return
+	link := fmt.Sprintf("/talent/applications/%d", application.ID)
+	if err := notifications.NotifyCastingCallTeam(h.DB, castingCall.ID, notifications.TypeNewApplication,
+		"New application for "+castingCall.ProjectTitle, profile.FullName+" applied.", link); err != nil {
+		log.Printf("Failed to notify the team of casting call %d of application %d: %v", castingCall.ID, application.ID, err)
 	}
+	return application, true
+}
 
-	c.JSON(http.StatusCreated, application)
+// recordApplicationEvent adds an entry to the application's audit trail.
+func recordApplicationEvent(tx *gorm.DB, applicationID, actorUserID uint, agencyID *uint, action, detail string) error {
+	return tx.Create(&models.ApplicationEvent{
+		ApplicationID: applicationID,
+		ActorUserID:   actorUserID,
+		AgencyID:      agencyID,
+		Action:        action,
+		Detail:        detail,
+	}).Error
 }
 
 func (h *BaseHandler) GetApplicationsForCastingCall(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	castingCallID := c.Param("id")
 
-	// Verify the user owns the casting call
-	if !isCastingCallOwner(h.DB, userID.(uint), castingCallID) {
+	// Verify the user is on the casting call's team
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallID, CastingRoleViewer) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view applications for this casting call."})
 		return
 	}
 
-	var applications []models.Application
-	if err := h.DB.Preload("TalentProfile").Where("casting_call_id = ?", castingCallID).Find(&applications).Error; err != nil {
+	filter := applicationReviewFilter{
+		Status:    c.Query("status"),
+		Tag:       normalizeTag(c.Query("tag")),
+		AgencyID:  c.Query("agency_id"),
+		SortBy:    c.DefaultQuery("sort", "created_at"),
+		SortOrder: c.DefaultQuery("order", "desc"),
+	}
+	if minRating := c.Query("min_rating"); minRating != "" {
+		value, err := strconv.ParseFloat(minRating, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_rating must be a number."})
+			return
+		}
+		filter.MinRating = value
+	}
+
+	reviews, err := loadApplicationReviews(h.DB, castingCallID, userID.(uint), filter)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch applications."})
 		return
 	}
 
-	c.JSON(http.StatusOK, applications)
+	c.JSON(http.StatusOK, reviews)
 }
 
 func (h *BaseHandler) GetMyApplications(c *gin.Context) {
@@ -427,7 +707,7 @@ func (h *BaseHandler) GetMyApplications(c *gin.Context) {
 	}
 
 	var applications []models.Application
-	if err := h.DB.Preload("CastingCall").Where("talent_profile_id = ?", profileID).Find(&applications).Error; err != nil {
+	if err := h.DB.Preload("CastingCall").Preload("Agency").Where("talent_profile_id = ?", profileID).Find(&applications).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch your applications."})
 		return
 	}
@@ -456,28 +736,59 @@ func (h *BaseHandler) UpdateApplicationStatus(c *gin.Context) {
 		return
 	}
 
-	// Verify the current user owns the casting call associated with the application
+	// Verify the current user can review applications for the associated casting call
 	castingCallIDStr := strconv.FormatUint(uint64(application.CastingCallID), 10)
-	if !isCastingCallOwner(h.DB, userID.(uint), castingCallIDStr) {
+	if !hasCastingCallRole(h.DB, userID.(uint), castingCallIDStr, CastingRoleReviewer) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to update this application."})
 		return
 	}
 
 	// Update the status
-	if err := h.DB.Model(&application).Update("status", input.Status).Error; err != nil {
+	previousStatus := application.Status
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&application).Update("status", input.Status).Error; err != nil {
+			return err
+		}
+		return recordApplicationEvent(tx, application.ID, userID.(uint), nil, "status_changed", input.Status)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update application status."})
 		return
 	}
+	if input.Status != previousStatus {
+		application.Status = input.Status
+		h.notifyApplicationStatus(application)
+	}
 
 	c.JSON(http.StatusOK, application)
 }
 
+// notifyApplicationStatus tells the applicant, and the agent who applied for them if any, that
+// the application's status changed.
+func (h *BaseHandler) notifyApplicationStatus(application models.Application) {
+	var profile models.TalentProfile
+	var call models.CastingCall
+	if h.DB.First(&profile, application.TalentProfileID).Error != nil || h.DB.First(&call, application.CastingCallID).Error != nil {
+		return
+	}
+
+	userIDs := []uint{profile.UserID}
+	if application.SubmittedByUserID != 0 && application.SubmittedByUserID != profile.UserID {
+		userIDs = append(userIDs, application.SubmittedByUserID)
+	}
+	title := "Your application for " + call.ProjectTitle + " is now " + application.Status
+	link := fmt.Sprintf("/talent/applications/%d", application.ID)
+	if err := notifications.Send(h.DB, userIDs, notifications.TypeApplicationStatus, title, "", link); err != nil {
+		log.Printf("Failed to notify applicant of application %d status: %v", application.ID, err)
+	}
+}
+
 func (h *BaseHandler) GetApplicationByID(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	appID := c.Param("app_id")
 
 	var application models.Application
-	if err := h.DB.Preload("TalentProfile").Preload("CastingCall").First(&application, appID).Error; err != nil {
+	if err := h.DB.Preload("TalentProfile").Preload("CastingCall").Preload("Agency").First(&application, appID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found."})
 		return
 	}
@@ -485,11 +796,14 @@ func (h *BaseHandler) GetApplicationByID(c *gin.Context) {
 	// Check if user is the applicant
 	isApplicant := application.TalentProfile.UserID == userID.(uint)
 
-	// Check if user is the recruiter
+	// Check if user is on the casting call's team
 	castingCallIDStr := strconv.FormatUint(uint64(application.CastingCallID), 10)
-	isRecruiter := isCastingCallOwner(h.DB, userID.(uint), castingCallIDStr)
+	isRecruiter := hasCastingCallRole(h.DB, userID.(uint), castingCallIDStr, CastingRoleViewer)
+
+	// Check if user is an agent at the agency that submitted the application
+	isAgent := application.AgencyID != nil && isAgencyMember(h.DB, userID.(uint), *application.AgencyID)
 
-	if !isApplicant && !isRecruiter {
+	if !isApplicant && !isRecruiter && !isAgent {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this application."})
 		return
 	}
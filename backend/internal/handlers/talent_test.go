@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"siddu-verse-backend/internal/models"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB returns a database that builds statements without connecting and records
+// the SQL of every query it would have run.
+func dryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	assert.NoError(t, err)
+	return db, &queries
+}
+
+func TestSubmitApplicationToHiddenCastingCall(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, queries := dryRunDB(t)
+	h := &BaseHandler{DB: db}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	// Test case: The casting call is only looked up among calls that are still visible,
+	// so a call a moderator hid (or whose poster is banned) is never applied to
+	_, ok := h.submitApplication(c, models.TalentProfile{UserID: 1}, "7", "", 1, nil)
+	assert.False(t, ok)
+	assert.NotEqual(t, http.StatusCreated, w.Code)
+	var lookup string
+	for _, query := range *queries {
+		if strings.Contains(query, `FROM "casting_calls"`) {
+			lookup = query
+			break
+		}
+	}
+	assert.Contains(t, lookup, "casting_calls.hidden_at IS NULL")
+	assert.Contains(t, lookup, "casting_calls.posted_by_user_id NOT IN")
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"net/http"
+	"siddu-verse-backend/internal/blocks"
 	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,7 +12,8 @@ import (
 func (h *BaseHandler) GetUserByID(c *gin.Context) {
 	id := c.Param("id")
 	var user models.User
-	if result := h.DB.First(&user, id); result.Error != nil {
+	// Banned accounts no longer have a public profile, and blocked users can't see each other's
+	if result := h.DB.Where("status <> ?", moderation.AccountBanned).Scopes(blocks.Exclude(viewerID(c), "users.id")).First(&user, id); result.Error != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
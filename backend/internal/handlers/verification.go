@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/notifications"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// notifyVerificationDecision tells the talent what happened to their verification.
+func (h *BaseHandler) notifyVerificationDecision(profile models.TalentProfile, title, body string) {
+	link := "/talent/profiles/" + strconv.FormatUint(uint64(profile.ID), 10)
+	if err := notifications.Send(h.DB, []uint{profile.UserID}, notifications.TypeVerificationDecision, title, body, link); err != nil {
+		log.Printf("Failed to notify talent profile %d of verification decision: %v", profile.ID, err)
+	}
+}
+
+// --- Talent Verification Handlers ---
+
+type VerificationEvidenceInput struct {
+	Type  string `json:"type" binding:"required,oneof=union_membership link id_document"`
+	Value string `json:"value" binding:"required"`
+}
+
+type SubmitVerificationInput struct {
+	Note     string                      `json:"note"`
+	Evidence []VerificationEvidenceInput `json:"evidence" binding:"required,min=1,max=10,dive"`
+}
+
+func (h *BaseHandler) SubmitVerificationRequest(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to verify this profile"})
+		return
+	}
+
+	var input SubmitVerificationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.First(&profile, profileID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		return
+	}
+	if profile.IsVerified {
+		c.JSON(http.StatusConflict, gin.H{"error": "Profile is already verified"})
+		return
+	}
+
+	var pending int64
+	h.DB.Model(&models.VerificationRequest{}).Where("talent_profile_id = ? AND status = ?", profile.ID, "pending").Count(&pending)
+	if pending > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "A verification request is already pending review"})
+		return
+	}
+
+	request := models.VerificationRequest{
+		TalentProfileID: profile.ID,
+		Status:          "pending",
+		Note:            input.Note,
+	}
+	for _, evidence := range input.Evidence {
+		request.Evidence = append(request.Evidence, models.VerificationEvidence{Type: evidence.Type, Value: evidence.Value})
+	}
+
+	if err := h.DB.Create(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit verification request"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// GetVerificationRequests lists the profile's verification history, newest first.
+func (h *BaseHandler) GetVerificationRequests(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	if !isProfileOwner(h.DB, userID.(uint), profileID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this profile's verification"})
+		return
+	}
+
+	var requests []models.VerificationRequest
+	if err := h.DB.Preload("Evidence").Where("talent_profile_id = ?", profileID).Order("created_at desc").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch verification requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// --- Admin Verification Handlers ---
+
+// GetVerificationQueue lists verification requests for admins, oldest first so the queue is worked in order.
+func (h *BaseHandler) GetVerificationQueue(c *gin.Context) {
+	status := c.DefaultQuery("status", "pending")
+
+	var requests []models.VerificationRequest
+	if err := h.DB.Preload("TalentProfile").Preload("Evidence").Where("status = ?", status).Order("created_at asc").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch verification queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+type ReviewVerificationInput struct {
+	Reason string `json:"reason"`
+}
+
+func (h *BaseHandler) ApproveVerificationRequest(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	reviewerID := userID.(uint)
+
+	var request models.VerificationRequest
+	if err := h.DB.Preload("TalentProfile").First(&request, "id = ? AND status = ?", c.Param("request_id"), "pending").Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pending verification request not found"})
+		return
+	}
+
+	now := time.Now()
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&request).Updates(map[string]interface{}{
+			"status":              "approved",
+			"reviewed_by_user_id": reviewerID,
+			"reviewed_at":         now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.TalentProfile{}).Where("id = ?", request.TalentProfileID).
+			Updates(map[string]interface{}{"is_verified": true, "verified_at": now}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to approve verification request"})
+		return
+	}
+
+	h.notifyVerificationDecision(request.TalentProfile, "Your profile is verified", "Your profile now shows a verified badge.")
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *BaseHandler) RejectVerificationRequest(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input ReviewVerificationInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A reason is required to reject a verification request"})
+		return
+	}
+
+	var request models.VerificationRequest
+	if err := h.DB.Preload("TalentProfile").First(&request, "id = ? AND status = ?", c.Param("request_id"), "pending").Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pending verification request not found"})
+		return
+	}
+
+	if err := h.DB.Model(&request).Updates(map[string]interface{}{
+		"status":              "rejected",
+		"reviewed_by_user_id": userID.(uint),
+		"reviewed_at":         time.Now(),
+		"reason":              input.Reason,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reject verification request"})
+		return
+	}
+
+	h.notifyVerificationDecision(request.TalentProfile, "Your verification request was not approved", input.Reason)
+	c.JSON(http.StatusOK, request)
+}
+
+// RevokeVerification removes a profile's verified badge and records why on its approved request.
+func (h *BaseHandler) RevokeVerification(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var input ReviewVerificationInput
+	if err := c.ShouldBindJSON(&input); err != nil || input.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A reason is required to revoke verification"})
+		return
+	}
+
+	var profile models.TalentProfile
+	if err := h.DB.First(&profile, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		return
+	}
+	if !profile.IsVerified {
+		c.JSON(http.StatusConflict, gin.H{"error": "Profile is not verified"})
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&profile).Updates(map[string]interface{}{"is_verified": false, "verified_at": nil}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.VerificationRequest{}).
+			Where("talent_profile_id = ? AND status = ?", profile.ID, "approved").
+			Updates(map[string]interface{}{
+				"status":              "revoked",
+				"reviewed_by_user_id": userID.(uint),
+				"reviewed_at":         time.Now(),
+				"reason":              input.Reason,
+			}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke verification"})
+		return
+	}
+
+	h.notifyVerificationDecision(profile, "Your profile verification was revoked", input.Reason)
+	c.JSON(http.StatusOK, gin.H{"message": "Verification revoked successfully"})
+}
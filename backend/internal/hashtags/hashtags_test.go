@@ -0,0 +1,59 @@
+package hashtags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// Test case: Tags and mentions are found once each, in order, with tags lowercased
+	tags, mentions := Parse("Watching #Oppenheimer with @ravi and @Meera_K. #oppenheimer #IMAX!")
+	assert.Equal(t, []Tag{{Name: "oppenheimer", Display: "Oppenheimer"}, {Name: "imax", Display: "IMAX"}}, tags)
+	assert.Equal(t, []string{"ravi", "Meera_K"}, mentions)
+
+	// Test case: Emails, URLs fragments, words with # inside and number-only tags are ignored
+	tags, mentions = Parse("mail me@example.com, see site.com/#top, issue#12, ranked #1")
+	assert.Empty(t, tags)
+	assert.Empty(t, mentions)
+
+	// Test case: Non-Latin hashtags are kept
+	tags, _ = Parse("#ಕನ್ನಡ cinema")
+	assert.Len(t, tags, 1)
+}
+
+func TestKeyAndMatchTags(t *testing.T) {
+	assert.Equal(t, "thedarkknight", Key("The Dark Knight"))
+	assert.Equal(t, "spidermannowayhome", Key("Spider-Man: No Way Home"))
+
+	// Test case: Full names and abbreviations, in either order
+	tags := MatchTags("India", "Australia")
+	assert.Contains(t, tags, "indvaus")
+	assert.Contains(t, tags, "indiavsaustralia")
+	assert.Contains(t, tags, "ausvind")
+
+	// Test case: Short team codes are used as-is
+	assert.Contains(t, MatchTags("CSK", "MI"), "cskvmi")
+}
+
+func TestRank(t *testing.T) {
+	window := Windows["1h"]
+
+	// Test case: A tag rising from nothing beats a steadily busy one
+	ranked := Rank([]Activity{
+		{HashtagID: 1, Recent: 10, Baseline: 240}, // 10/hour as usual
+		{HashtagID: 2, Recent: 8, Baseline: 0},    // new and rising
+		{HashtagID: 3, Recent: 2, Baseline: 0},    // too few to trend
+	}, window, 10)
+	assert.Equal(t, 2, len(ranked))
+	assert.Equal(t, uint(2), ranked[0].HashtagID)
+	assert.Equal(t, 9.0, ranked[0].Velocity)
+	assert.Equal(t, uint(1), ranked[1].HashtagID)
+
+	// Test case: The limit is applied after ranking
+	assert.Len(t, Rank([]Activity{{HashtagID: 1, Recent: 5}, {HashtagID: 2, Recent: 6}}, window, 1), 1)
+
+	// Test case: Velocity compares with the rate the baseline predicts
+	assert.Equal(t, 1.0, Velocity(23, 23*24*6, Window{Recent: time.Hour, Baseline: 6 * 24 * time.Hour}))
+}
@@ -0,0 +1,89 @@
+package hashtags
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MaxTagLength bounds stored hashtags; longer tags are ignored rather than truncated.
+const MaxTagLength = 100
+
+var (
+	// A tag or mention starts at the beginning of the text or after a character that can't be
+	// part of a word, so "email@example.com" and "issue#12" aren't picked up.
+	hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/])#([\p{L}\p{N}_]+)`)
+	mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([A-Za-z0-9_][A-Za-z0-9_.]*)`)
+)
+
+// Tag is a hashtag as written and its normalized form.
+type Tag struct {
+	Name    string // Lowercased, used to group pulses
+	Display string // As first written, e.g. "Oppenheimer"
+}
+
+// Parse extracts the hashtags and @mentioned usernames from pulse content, each once and in
+// order of first appearance. Tags made only of digits, like "#1", are ignored.
+func Parse(content string) ([]Tag, []string) {
+	tags := []Tag{}
+	seenTags := map[string]bool{}
+	for _, match := range hashtagPattern.FindAllStringSubmatch(content, -1) {
+		display := match[1]
+		name := strings.ToLower(display)
+		if seenTags[name] || len(name) > MaxTagLength || !strings.ContainsFunc(name, unicode.IsLetter) {
+			continue
+		}
+		seenTags[name] = true
+		tags = append(tags, Tag{Name: name, Display: display})
+	}
+
+	mentions := []string{}
+	seenMentions := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		username := strings.TrimRight(match[1], ".")
+		if username == "" || seenMentions[strings.ToLower(username)] {
+			continue
+		}
+		seenMentions[strings.ToLower(username)] = true
+		mentions = append(mentions, username)
+	}
+	return tags, mentions
+}
+
+// Key reduces text to lowercase ASCII letters and digits so hashtags can be compared with
+// titles and team names, e.g. "The Dark Knight" and #TheDarkKnight both become "thedarkknight".
+func Key(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// MatchTags are the hashtags that refer to a match between two teams: full names or their
+// first three letters, joined by "v" or "vs", in either order. India vs Australia gives
+// "indiavaustralia", "indvaus", "ausvsind" and so on.
+func MatchTags(team1, team2 string) []string {
+	names := func(team string) []string {
+		key := Key(team)
+		if len(key) > 3 {
+			return []string{key, key[:3]}
+		}
+		return []string{key}
+	}
+
+	tags := []string{}
+	for _, pair := range [][2]string{{team1, team2}, {team2, team1}} {
+		for _, a := range names(pair[0]) {
+			for _, b := range names(pair[1]) {
+				if a == "" || b == "" {
+					continue
+				}
+				tags = append(tags, a+"v"+b, a+"vs"+b)
+			}
+		}
+	}
+	return tags
+}
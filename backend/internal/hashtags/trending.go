@@ -0,0 +1,70 @@
+package hashtags
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Window is a trending period: activity in the last Recent is compared with the Baseline
+// period before it.
+type Window struct {
+	Recent   time.Duration
+	Baseline time.Duration
+}
+
+// Windows are the trending periods clients can ask for.
+var Windows = map[string]Window{
+	"1h":  {Recent: time.Hour, Baseline: 24 * time.Hour},
+	"6h":  {Recent: 6 * time.Hour, Baseline: 3 * 24 * time.Hour},
+	"24h": {Recent: 24 * time.Hour, Baseline: 7 * 24 * time.Hour},
+}
+
+// MinRecentAuthors is how many different accounts must use a hashtag in the recent window
+// for it to trend, so one account posting a brand new tag a few times doesn't top the list.
+const MinRecentAuthors = 3
+
+// Velocity scores how fast a hashtag is rising: its rate in the recent window divided by the
+// rate its baseline would predict. Add-one smoothing keeps new tags from scoring infinitely.
+func Velocity(recent, baseline int64, window Window) float64 {
+	expected := float64(baseline) * window.Recent.Hours() / window.Baseline.Hours()
+	return math.Round((float64(recent)+1)/(expected+1)*100) / 100
+}
+
+// Activity is how many accounts used a hashtag in the recent and baseline periods.
+type Activity struct {
+	HashtagID uint
+	Recent    int64
+	Baseline  int64
+}
+
+// Ranked is a hashtag's trending score.
+type Ranked struct {
+	HashtagID uint
+	Recent    int64
+	Velocity  float64
+}
+
+// Rank orders hashtags by velocity, then recent volume, dropping those below MinRecentAuthors.
+func Rank(activity []Activity, window Window, limit int) []Ranked {
+	ranked := []Ranked{}
+	for _, a := range activity {
+		if a.Recent < MinRecentAuthors {
+			continue
+		}
+		ranked = append(ranked, Ranked{HashtagID: a.HashtagID, Recent: a.Recent, Velocity: Velocity(a.Recent, a.Baseline, window)})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Velocity != ranked[j].Velocity {
+			return ranked[i].Velocity > ranked[j].Velocity
+		}
+		if ranked[i].Recent != ranked[j].Recent {
+			return ranked[i].Recent > ranked[j].Recent
+		}
+		return ranked[i].HashtagID < ranked[j].HashtagID
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
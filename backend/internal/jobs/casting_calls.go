@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"log"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/notifications"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CloseExpiredCastingCalls closes every published casting call whose application deadline
+// has passed and lets its applicants know.
+func CloseExpiredCastingCalls(db *gorm.DB) error {
+	now := time.Now()
+
+	var calls []models.CastingCall
+	if err := db.Where("status = ? AND application_deadline IS NOT NULL AND application_deadline <= ?", models.CastingStatusPublished, now).Find(&calls).Error; err != nil {
+		return err
+	}
+
+	for _, call := range calls {
+		// Guard on status so a call reopened in the meantime is left alone
+		result := db.Model(&models.CastingCall{}).
+			Where("id = ? AND status = ?", call.ID, models.CastingStatusPublished).
+			Updates(map[string]interface{}{"status": models.CastingStatusClosed, "closed_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		if err := notifications.NotifyCastingCallApplicants(db, call.ID, notifications.TypeCastingClosed,
+			"Applications closed for "+call.ProjectTitle,
+			"The application deadline has passed and this casting call is no longer accepting applications."); err != nil {
+			log.Printf("jobs: failed to notify applicants of casting call %d: %v", call.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"siddu-verse-backend/internal/linkpreview"
+	"siddu-verse-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// linkPreviewBatchSize is how many queued links one run fetches.
+	linkPreviewBatchSize = 20
+	// linkPreviewLease is how long a claimed link is hidden from other runs while it is fetched.
+	linkPreviewLease = time.Minute
+)
+
+// FetchLinkPreviews fetches the OpenGraph and oEmbed metadata of queued external links in
+// pulses. Links are claimed with SKIP LOCKED so several API instances can run the job at
+// once. Failed fetches are retried a few times; links to blocked addresses are not.
+func FetchLinkPreviews(db *gorm.DB, f *linkpreview.Fetcher) error {
+	now := time.Now()
+
+	var due []models.LinkPreview
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", linkpreview.StatusPending, now).
+			Order("next_attempt_at").Limit(linkPreviewBatchSize).Find(&due).Error; err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		ids := make([]uint, 0, len(due))
+		for _, preview := range due {
+			ids = append(ids, preview.ID)
+		}
+		return tx.Model(&models.LinkPreview{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(linkPreviewLease),
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, preview := range due {
+		preview.Attempts++
+		card, fetchErr := f.Fetch(context.Background(), preview.URL)
+
+		fetchedAt := time.Now()
+		var updates map[string]interface{}
+		switch {
+		case fetchErr == nil:
+			updates = map[string]interface{}{
+				"status":      linkpreview.StatusReady,
+				"fetched_at":  fetchedAt,
+				"last_error":  "",
+				"title":       card.Title,
+				"description": card.Description,
+				"image_url":   card.ImageURL,
+				"site_name":   card.SiteName,
+				"embed_type":  card.EmbedType,
+				"embed_url":   card.EmbedURL,
+			}
+		case errors.Is(fetchErr, linkpreview.ErrBlockedAddress), errors.Is(fetchErr, linkpreview.ErrNoMetadata),
+			preview.Attempts >= linkpreview.MaxAttempts:
+			log.Printf("jobs: no preview for link %d: %v", preview.ID, fetchErr)
+			// Failed links are tried again once the failure is as old as a stale preview
+			updates = map[string]interface{}{
+				"status":     linkpreview.StatusFailed,
+				"fetched_at": fetchedAt,
+				"last_error": fetchErr.Error(),
+			}
+		default:
+			updates = map[string]interface{}{
+				"next_attempt_at": fetchedAt.Add(time.Duration(preview.Attempts) * time.Minute),
+				"last_error":      fetchErr.Error(),
+			}
+		}
+		if err := db.Model(&models.LinkPreview{}).Where("id = ?", preview.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"siddu-verse-backend/internal/mailer"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/notifications"
+	"siddu-verse-backend/internal/utils"
+	"siddu-verse-backend/internal/webpush"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// deliveryBatchSize is how many queued emails and pushes one run sends.
+	deliveryBatchSize = 100
+	// deliveryLease is how long a claimed delivery is hidden from other runs while it is sent.
+	deliveryLease = 5 * time.Minute
+)
+
+// errNoPushSubscriptions marks a push delivery for a user with no subscribed browsers.
+var errNoPushSubscriptions = errors.New("no push subscriptions")
+
+// DeliverNotifications sends queued email and web push notifications. Deliveries are claimed
+// with SKIP LOCKED so several API instances can run the job at once, and failed ones are
+// retried with backoff until they run out of attempts.
+func DeliverNotifications(db *gorm.DB, m mailer.Mailer, p webpush.Pusher) error {
+	now := time.Now()
+
+	var due []models.NotificationDelivery
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", notifications.DeliveryPending, now).
+			Order("next_attempt_at").Limit(deliveryBatchSize).Find(&due).Error; err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		ids := make([]uint, 0, len(due))
+		for _, delivery := range due {
+			ids = append(ids, delivery.ID)
+		}
+		return tx.Model(&models.NotificationDelivery{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(deliveryLease),
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range due {
+		delivery.Attempts++
+		var sendErr error
+		switch delivery.Channel {
+		case notifications.ChannelEmail:
+			sendErr = sendNotificationEmail(db, m, delivery)
+		case notifications.ChannelPush:
+			sendErr = sendNotificationPush(db, p, delivery)
+		default:
+			sendErr = fmt.Errorf("unknown channel %q", delivery.Channel)
+		}
+
+		updates := map[string]interface{}{}
+		switch {
+		case sendErr == nil:
+			updates["status"] = notifications.DeliverySent
+			updates["sent_at"] = time.Now()
+		case errors.Is(sendErr, errNoPushSubscriptions):
+			updates["status"] = notifications.DeliverySkipped
+		case delivery.Attempts >= notifications.MaxDeliveryAttempts:
+			log.Printf("jobs: giving up on %s notification %d: %v", delivery.Channel, delivery.ID, sendErr)
+			updates["status"] = notifications.DeliveryFailed
+			updates["last_error"] = sendErr.Error()
+		default:
+			updates["next_attempt_at"] = time.Now().Add(notifications.RetryDelay(delivery.Attempts))
+			updates["last_error"] = sendErr.Error()
+		}
+		if err := db.Model(&models.NotificationDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sendNotificationEmail(db *gorm.DB, m mailer.Mailer, delivery models.NotificationDelivery) error {
+	var user models.User
+	if err := db.First(&user, delivery.UserID).Error; err != nil {
+		return err
+	}
+
+	frontendURL := utils.GetFrontendURL()
+	body := delivery.Title
+	if delivery.Body != "" {
+		body += "\n\n" + delivery.Body
+	}
+	if delivery.Link != "" {
+		body += "\n\n" + frontendURL + delivery.Link
+	}
+	body += "\n\nChange which notifications you receive by email: " + frontendURL + "/notifications/settings"
+
+	return m.Send(mailer.Message{To: user.Email, Subject: delivery.Title, Body: body})
+}
+
+// pushPayload is the JSON the service worker receives.
+type pushPayload struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	Link  string `json:"link,omitempty"`
+}
+
+// sendNotificationPush pushes the notification to each of the user's browsers, forgetting
+// the ones whose subscription has lapsed. It succeeds if any browser accepted it.
+func sendNotificationPush(db *gorm.DB, p webpush.Pusher, delivery models.NotificationDelivery) error {
+	var subscriptions []models.PushSubscription
+	if err := db.Where("user_id = ?", delivery.UserID).Find(&subscriptions).Error; err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return errNoPushSubscriptions
+	}
+
+	payload, err := json.Marshal(pushPayload{Type: delivery.Type, Title: delivery.Title, Body: delivery.Body, Link: delivery.Link})
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	delivered, gone := 0, 0
+	for _, subscription := range subscriptions {
+		err := p.Push(context.Background(), webpush.Subscription{Endpoint: subscription.Endpoint, P256dh: subscription.P256dh, Auth: subscription.Auth}, payload)
+		switch {
+		case err == nil:
+			delivered++
+		case errors.Is(err, webpush.ErrGone):
+			gone++
+			if err := db.Unscoped().Delete(&subscription).Error; err != nil {
+				log.Printf("jobs: failed to remove lapsed push subscription %d: %v", subscription.ID, err)
+			}
+		default:
+			lastErr = err
+		}
+	}
+	if delivered > 0 {
+		return nil
+	}
+	if gone == len(subscriptions) {
+		return errNoPushSubscriptions
+	}
+	return lastErr
+}
+
+// matchStartWindow is how late a match start is still announced, so matches that started
+// while the server was down don't all notify at once when it comes back.
+const matchStartWindow = time.Hour
+
+// NotifyMatchStarts tells users when a cricket match they engaged with starts: those who
+// reacted to or commented on it, or posted a pulse linked to it.
+func NotifyMatchStarts(db *gorm.DB) error {
+	now := time.Now()
+
+	var matches []models.CricketMatch
+	if err := db.Where("start_notified_at IS NULL AND date <= ? AND date > ?", now, now.Add(-matchStartWindow)).Find(&matches).Error; err != nil {
+		return err
+	}
+
+	for _, match := range matches {
+		// Guard on start_notified_at so overlapping runs announce each match once
+		result := db.Model(&models.CricketMatch{}).Where("id = ? AND start_notified_at IS NULL", match.ID).Update("start_notified_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		var userIDs []uint
+		if err := db.Raw(`SELECT user_id FROM likes WHERE owner_type = 'cricket_matches' AND owner_id = ? AND deleted_at IS NULL
+			UNION SELECT user_id FROM comments WHERE owner_type = 'cricket_matches' AND owner_id = ? AND deleted_at IS NULL
+			UNION SELECT pulses.user_id FROM pulse_references JOIN pulses ON pulses.id = pulse_references.pulse_id
+				WHERE pulse_references.ref_type = 'cricket_matches' AND pulse_references.ref_id = ? AND pulses.deleted_at IS NULL`,
+			match.ID, match.ID, match.ID).Scan(&userIDs).Error; err != nil {
+			return err
+		}
+
+		if err := notifications.Send(db, userIDs, notifications.TypeMatchStarted,
+			match.Team1+" vs "+match.Team2+" has started", match.Venue,
+			fmt.Sprintf("/cricket/matches/%d", match.ID)); err != nil {
+			log.Printf("jobs: failed to notify users of match %d starting: %v", match.ID, err)
+		}
+	}
+
+	return nil
+}
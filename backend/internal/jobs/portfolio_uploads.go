@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/storage"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ExpirePortfolioUploads abandons resumable uploads that have sat idle past their expiry and
+// discards the parts they stored in s.
+func ExpirePortfolioUploads(db *gorm.DB, s storage.Storage) error {
+	var uploads []models.PortfolioUpload
+	if err := db.Where("status = ? AND expires_at <= ?", "uploading", time.Now()).Find(&uploads).Error; err != nil {
+		return err
+	}
+
+	for _, upload := range uploads {
+		// Guard on status so an upload completed in the meantime is left alone
+		result := db.Model(&models.PortfolioUpload{}).
+			Where("id = ? AND status = ?", upload.ID, "uploading").
+			Update("status", "expired")
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		if upload.StorageUploadID != "" {
+			if err := s.AbortMultipart(context.Background(), upload.StorageKey, upload.StorageUploadID); err != nil {
+				log.Printf("jobs: failed to discard the parts of upload %d: %v", upload.ID, err)
+			}
+		}
+		if err := db.Where("portfolio_upload_id = ?", upload.ID).Delete(&models.PortfolioUploadPart{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
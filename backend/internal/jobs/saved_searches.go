@@ -0,0 +1,176 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"siddu-verse-backend/internal/alerts"
+	"siddu-verse-backend/internal/blocks"
+	"siddu-verse-backend/internal/mailer"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
+	"siddu-verse-backend/internal/notifications"
+	"siddu-verse-backend/internal/skills"
+	"siddu-verse-backend/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// matchCheckpoint names the matcher's checkpoint: the start of its last successful run.
+	matchCheckpoint = "match-saved-searches"
+	// matchWindow is how far back the first run looks for newly published casting calls.
+	matchWindow = 24 * time.Hour
+	// matchOverlap re-checks calls published just before the checkpoint, in case their
+	// transaction committed after the last run read them. Matches are recorded once, so
+	// re-checking is harmless.
+	matchOverlap = time.Minute
+)
+
+// MatchSavedSearches checks the casting calls published since its last run against every
+// saved search and notifies users of new matches. Calls published before a search was saved,
+// and calls from users blocked either way with the search's owner, are ignored.
+func MatchSavedSearches(db *gorm.DB) error {
+	runStart := time.Now()
+	since := runStart.Add(-matchWindow)
+	var checkpoint models.JobCheckpoint
+	err := db.First(&checkpoint, "name = ?", matchCheckpoint).Error
+	switch {
+	case err == nil:
+		since = checkpoint.At.Add(-matchOverlap)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+
+	if err := matchSavedSearches(db, since); err != nil {
+		return err
+	}
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&models.JobCheckpoint{Name: matchCheckpoint, At: runStart}).Error
+}
+
+func matchSavedSearches(db *gorm.DB, since time.Time) error {
+	var calls []models.CastingCall
+	if err := db.Preload("Roles").Scopes(moderation.VisibleCastingCalls).
+		Where("status = ? AND published_at >= ?", models.CastingStatusPublished, since).
+		Find(&calls).Error; err != nil {
+		return err
+	}
+	if len(calls) == 0 {
+		return nil
+	}
+
+	var searches []models.SavedSearch
+	if err := db.Find(&searches).Error; err != nil {
+		return err
+	}
+	taxonomy, err := skills.LoadTaxonomy(db, alerts.SkillNames(searches, calls))
+	if err != nil {
+		return err
+	}
+
+	owners := make([]uint, 0, len(searches))
+	for _, search := range searches {
+		owners = append(owners, search.UserID)
+	}
+	posters := make([]uint, 0, len(calls))
+	for _, call := range calls {
+		posters = append(posters, call.PostedByUserID)
+	}
+	blocked, err := blocks.LoadPairs(db, owners, posters)
+	if err != nil {
+		return err
+	}
+
+	for _, search := range searches {
+		for _, call := range calls {
+			if call.PublishedAt.Before(search.CreatedAt) || call.PostedByUserID == search.UserID ||
+				blocked.Blocked(search.UserID, call.PostedByUserID) || !alerts.Matches(search, call, taxonomy) {
+				continue
+			}
+
+			result := db.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.SavedSearchMatch{SavedSearchID: search.ID, CastingCallID: call.ID})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			if err := notifications.Send(db, []uint{search.UserID}, notifications.TypeSavedSearchMatch,
+				"New casting call for \""+search.Name+"\"", call.ProjectTitle,
+				fmt.Sprintf("/talent/casting-calls/%d", call.ID)); err != nil {
+				log.Printf("jobs: failed to notify user %d of saved search match: %v", search.UserID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SendSavedSearchDigests emails each saved search that is due a daily or weekly digest the
+// matches it hasn't emailed yet.
+func SendSavedSearchDigests(db *gorm.DB, m mailer.Mailer) error {
+	now := time.Now()
+
+	var searches []models.SavedSearch
+	if err := db.Preload("User").Where("email_digest IN ?", []string{alerts.DigestDaily, alerts.DigestWeekly}).Find(&searches).Error; err != nil {
+		return err
+	}
+
+	for _, search := range searches {
+		if search.LastDigestAt != nil && now.Sub(*search.LastDigestAt) < alerts.DigestInterval(search.EmailDigest) {
+			continue
+		}
+
+		var matches []models.SavedSearchMatch
+		if err := db.Preload("CastingCall", openCastingCalls(search.UserID)).
+			Where("saved_search_id = ? AND emailed_at IS NULL", search.ID).Order("created_at").Find(&matches).Error; err != nil {
+			return err
+		}
+
+		// Matches whose call has since closed, been taken down or had its poster blocked are
+		// left out and stay unemailed, in case the call comes back
+		calls := make([]models.CastingCall, 0, len(matches))
+		ids := make([]uint, 0, len(matches))
+		for _, match := range matches {
+			if match.CastingCall.ID == 0 {
+				continue
+			}
+			calls = append(calls, match.CastingCall)
+			ids = append(ids, match.ID)
+		}
+
+		if len(calls) > 0 {
+			if err := m.Send(mailer.Message{
+				To:      search.User.Email,
+				Subject: fmt.Sprintf("New casting calls for \"%s\"", search.Name),
+				Body:    alerts.DigestBody(search, calls, utils.GetFrontendURL()),
+			}); err != nil {
+				// Leave the matches unemailed so the next run retries
+				log.Printf("jobs: failed to send digest for saved search %d: %v", search.ID, err)
+				continue
+			}
+			if err := db.Model(&models.SavedSearchMatch{}).Where("id IN ?", ids).Update("emailed_at", now).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := db.Model(&search).Update("last_digest_at", now).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openCastingCalls scopes casting calls to those still published and visible to the user.
+func openCastingCalls(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(moderation.VisibleCastingCalls, blocks.Exclude(userID, "casting_calls.posted_by_user_id")).
+			Where("casting_calls.status = ?", models.CastingStatusPublished)
+	}
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"siddu-verse-backend/internal/linkpreview"
+	"siddu-verse-backend/internal/mailer"
+	"siddu-verse-backend/internal/storage"
+	"siddu-verse-backend/internal/webpush"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Task is a unit of periodic background work.
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      func(db *gorm.DB) error
+}
+
+// DefaultTasks returns the background tasks the API server runs. Emails go through m, web
+// push notifications through p, and abandoned uploads are discarded from s.
+func DefaultTasks(m mailer.Mailer, p webpush.Pusher, s storage.Storage) []Task {
+	fetcher := linkpreview.NewFetcher()
+	return []Task{
+		{Name: "close-expired-casting-calls", Interval: time.Minute, Run: CloseExpiredCastingCalls},
+		{Name: "aggregate-view-stats", Interval: 5 * time.Minute, Run: AggregateViewStats},
+		{Name: "expire-portfolio-uploads", Interval: time.Hour, Run: func(db *gorm.DB) error {
+			return ExpirePortfolioUploads(db, s)
+		}},
+		{Name: "match-saved-searches", Interval: time.Minute, Run: MatchSavedSearches},
+		{Name: "send-saved-search-digests", Interval: time.Hour, Run: func(db *gorm.DB) error {
+			return SendSavedSearchDigests(db, m)
+		}},
+		{Name: "deliver-notifications", Interval: 10 * time.Second, Run: func(db *gorm.DB) error {
+			return DeliverNotifications(db, m, p)
+		}},
+		{Name: "notify-match-starts", Interval: time.Minute, Run: NotifyMatchStarts},
+		{Name: "fetch-link-previews", Interval: 10 * time.Second, Run: func(db *gorm.DB) error {
+			return FetchLinkPreviews(db, fetcher)
+		}},
+	}
+}
+
+// Start runs each task on its own ticker in the background until ctx is cancelled.
+func Start(ctx context.Context, db *gorm.DB, tasks ...Task) {
+	for _, task := range tasks {
+		go run(ctx, db, task)
+	}
+}
+
+func run(ctx context.Context, db *gorm.DB, task Task) {
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := task.Run(db); err != nil {
+			log.Printf("jobs: %s failed: %v", task.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"siddu-verse-backend/internal/safehttp"
+	"strings"
+	"time"
+)
+
+const (
+	// fetchTimeout bounds a whole fetch, including redirects and the oEmbed request.
+	fetchTimeout = 5 * time.Second
+	// maxBodyBytes is how much of a page is read looking for its metadata.
+	maxBodyBytes = 512 << 10
+	maxRedirects = 5
+
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+)
+
+var (
+	// ErrBlockedAddress is returned for links that resolve to a private, loopback or otherwise
+	// internal address, which the server must never be made to request.
+	ErrBlockedAddress = safehttp.ErrBlockedAddress
+	// ErrNoMetadata is returned for pages with nothing to show on a card.
+	ErrNoMetadata = errors.New("no preview metadata found")
+)
+
+// Preview is the card metadata found for a link.
+type Preview struct {
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+	EmbedType   string // oEmbed type: photo, video, rich or link
+	EmbedURL    string // Player URL for video and rich embeds from known providers
+}
+
+// Fetcher fetches OpenGraph and oEmbed metadata for links. It only connects to public
+// addresses, including when following redirects.
+type Fetcher struct {
+	Client *http.Client
+}
+
+// NewFetcher creates a fetcher with SSRF protection and timeouts.
+func NewFetcher() *Fetcher {
+	return newFetcher(safehttp.PublicIP)
+}
+
+func newFetcher(allowed func(net.IP) bool) *Fetcher {
+	return &Fetcher{Client: &http.Client{
+		Transport: safehttp.NewTransport(fetchTimeout, allowed),
+		Timeout:   fetchTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}}
+}
+
+// Fetch reads the link's page and returns its card metadata from OpenGraph and Twitter card
+// tags, the page title, and the oEmbed endpoint the page advertises, if any. Links to images
+// become photo cards.
+func (f *Fetcher) Fetch(ctx context.Context, link string) (Preview, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	body, contentType, finalURL, err := f.get(ctx, link, "text/html,application/xhtml+xml")
+	if err != nil {
+		return Preview{}, err
+	}
+	if strings.HasPrefix(contentType, "image/") {
+		return Preview{ImageURL: finalURL.String(), EmbedType: "photo"}, nil
+	}
+	if contentType != "text/html" && contentType != "application/xhtml+xml" {
+		return Preview{}, fmt.Errorf("%w: content type %q", ErrNoMetadata, contentType)
+	}
+
+	preview, oembedURL := parseHTML(body, finalURL)
+	if oembedURL != "" {
+		// The page's own metadata is enough for a card, so a failed oEmbed request is ignored
+		if embed, err := f.fetchOEmbed(ctx, oembedURL); err == nil {
+			preview = mergeOEmbed(preview, embed)
+		}
+	}
+	if preview.Title == "" && preview.Description == "" && preview.ImageURL == "" {
+		return Preview{}, ErrNoMetadata
+	}
+	if preview.SiteName == "" {
+		preview.SiteName = strings.TrimPrefix(finalURL.Hostname(), "www.")
+	}
+	return preview, nil
+}
+
+// get requests link and returns up to maxBodyBytes of the body, its media type and the URL it
+// was served from after redirects.
+func (f *Fetcher) get(ctx context.Context, link, accept string) ([]byte, string, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", "SidduVerseLinkPreview/1.0")
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, "", nil, ErrBlockedAddress
+		}
+		return nil, "", nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if strings.HasPrefix(contentType, "image/") {
+		return nil, contentType, resp.Request.URL, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return body, contentType, resp.Request.URL, nil
+}
+
+// oembed is the part of an oEmbed response used on cards.
+type oembed struct {
+	Type         string `json:"type"`
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	URL          string `json:"url"` // The image, for photo embeds
+	HTML         string `json:"html"`
+}
+
+func (f *Fetcher) fetchOEmbed(ctx context.Context, endpoint string) (oembed, error) {
+	var embed oembed
+	body, _, _, err := f.get(ctx, endpoint, "application/json")
+	if err != nil {
+		return embed, err
+	}
+	err = json.Unmarshal(body, &embed)
+	return embed, err
+}
+
+// mergeOEmbed fills what the page's tags left out from its oEmbed response, and adds the
+// embed's player. Only the iframe src of known providers is kept; other embed HTML is dropped
+// so a link can't put arbitrary markup on the site.
+func mergeOEmbed(preview Preview, embed oembed) Preview {
+	if preview.Title == "" {
+		preview.Title = truncate(embed.Title, maxTitleLength)
+	}
+	if preview.SiteName == "" {
+		preview.SiteName = embed.ProviderName
+	}
+	if preview.ImageURL == "" {
+		if embed.Type == "photo" && embed.URL != "" {
+			preview.ImageURL = resolve(nil, embed.URL)
+		} else {
+			preview.ImageURL = resolve(nil, embed.ThumbnailURL)
+		}
+	}
+	preview.EmbedType = embed.Type
+	if embed.Type == "video" || embed.Type == "rich" {
+		preview.EmbedURL = embedURL(embed.HTML)
+	}
+	return preview
+}
+
+func truncate(text string, max int) string {
+	text = strings.TrimSpace(text)
+	if runes := []rune(text); len(runes) > max {
+		return strings.TrimSpace(string(runes[:max-1])) + "…"
+	}
+	return text
+}
@@ -0,0 +1,126 @@
+package linkpreview
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	tagPattern    = regexp.MustCompile(`(?is)<(meta|link)\b([^>]*)>`)
+	iframePattern = regexp.MustCompile(`(?is)<iframe\b([^>]*)>`)
+	titlePattern  = regexp.MustCompile(`(?is)<title\b[^>]*>(.*?)</title>`)
+	attrPattern   = regexp.MustCompile(`(?s)([a-zA-Z_:.-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	spacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// parseHTML reads card metadata from a page's meta tags, preferring OpenGraph over Twitter
+// card tags over the plain title and description, and returns the oEmbed endpoint the page
+// advertises, if any. Relative URLs are resolved against base.
+func parseHTML(body []byte, base *url.URL) (Preview, string) {
+	page := string(body)
+	if end := strings.Index(strings.ToLower(page), "</head>"); end >= 0 {
+		page = page[:end]
+	}
+
+	meta := map[string]string{}
+	var oembedURL string
+	for _, tag := range tagPattern.FindAllStringSubmatch(page, -1) {
+		attrs := parseAttrs(tag[2])
+		if strings.EqualFold(tag[1], "link") {
+			if oembedURL == "" && strings.EqualFold(attrs["rel"], "alternate") && strings.EqualFold(attrs["type"], "application/json+oembed") {
+				oembedURL = resolve(base, attrs["href"])
+			}
+			continue
+		}
+		key := strings.ToLower(attrs["property"])
+		if key == "" {
+			key = strings.ToLower(attrs["name"])
+		}
+		if _, seen := meta[key]; key != "" && !seen {
+			meta[key] = attrs["content"]
+		}
+	}
+
+	var title string
+	if match := titlePattern.FindStringSubmatch(page); match != nil {
+		title = html.UnescapeString(match[1])
+	}
+
+	preview := Preview{
+		Title:       truncate(clean(first(meta["og:title"], meta["twitter:title"], title)), maxTitleLength),
+		Description: truncate(clean(first(meta["og:description"], meta["twitter:description"], meta["description"])), maxDescriptionLength),
+		ImageURL:    resolve(base, first(meta["og:image:secure_url"], meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"])),
+		SiteName:    clean(meta["og:site_name"]),
+	}
+	return preview, oembedURL
+}
+
+func parseAttrs(tag string) map[string]string {
+	attrs := map[string]string{}
+	for _, attr := range attrPattern.FindAllStringSubmatch(tag, -1) {
+		name := strings.ToLower(attr[1])
+		if _, seen := attrs[name]; !seen {
+			attrs[name] = html.UnescapeString(attr[2] + attr[3] + attr[4])
+		}
+	}
+	return attrs
+}
+
+func first(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// clean collapses the whitespace in text pulled from a page.
+func clean(text string) string {
+	return strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+}
+
+// resolve makes a link from a page absolute, keeping only http and https links.
+func resolve(base *url.URL, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	return u.String()
+}
+
+// embedHosts are the oEmbed providers whose players are shown on cards.
+var embedHosts = map[string]bool{
+	"www.youtube.com":          true,
+	"www.youtube-nocookie.com": true,
+	"player.vimeo.com":         true,
+	"open.spotify.com":         true,
+	"w.soundcloud.com":         true,
+	"www.dailymotion.com":      true,
+}
+
+// embedURL returns the src of the iframe in oEmbed HTML when it is an https player from one of
+// the embedHosts, or "" otherwise.
+func embedURL(embedHTML string) string {
+	iframe := iframePattern.FindStringSubmatch(embedHTML)
+	if iframe == nil {
+		return ""
+	}
+	u, err := url.Parse(strings.TrimSpace(parseAttrs(iframe[1])["src"]))
+	if err != nil || u.Scheme != "https" || u.User != nil || !embedHosts[strings.ToLower(u.Host)] {
+		return ""
+	}
+	return u.String()
+}
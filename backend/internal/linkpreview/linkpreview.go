@@ -0,0 +1,124 @@
+package linkpreview
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxLinks caps how many links in one pulse get preview cards.
+	MaxLinks = 4
+	// TTL is how long a fetched preview is used before it is fetched again.
+	TTL = 7 * 24 * time.Hour
+	// MaxAttempts is how many times a preview is fetched before it is marked failed.
+	MaxAttempts = 3
+)
+
+// Preview statuses.
+const (
+	StatusPending = "pending"
+	StatusReady   = "ready"
+	StatusFailed  = "failed"
+)
+
+// Native card types, named after the tables the cards come from.
+const (
+	TargetMovie         = "movies"
+	TargetTalentProfile = "talent_profiles"
+	TargetCastingCall   = "casting_calls"
+)
+
+var urlPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+`)
+
+// ExtractURLs finds the http and https links in text, normalized, in order and without
+// repeats, keeping at most MaxLinks.
+func ExtractURLs(text string) []string {
+	var found []string
+	seen := map[string]bool{}
+	for _, match := range urlPattern.FindAllString(text, -1) {
+		normalized, ok := Normalize(trimTrailing(match))
+		if !ok || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		found = append(found, normalized)
+		if len(found) == MaxLinks {
+			break
+		}
+	}
+	return found
+}
+
+// trimTrailing drops punctuation that ends the sentence around a link rather than the link,
+// keeping closing parentheses that belong to it, as in Wikipedia links.
+func trimTrailing(link string) string {
+	for link != "" {
+		last := link[len(link)-1]
+		switch {
+		case strings.IndexByte(".,;:!?'*", last) >= 0:
+			link = link[:len(link)-1]
+		case last == ')' && strings.Count(link, ")") > strings.Count(link, "("):
+			link = link[:len(link)-1]
+		default:
+			return link
+		}
+	}
+	return link
+}
+
+// Normalize lowercases a link's scheme and host and drops its fragment, so the same page is
+// cached once. Only http and https links with a host are accepted.
+func Normalize(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || u.User != nil {
+		return "", false
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String(), true
+}
+
+// Target is a movie, talent profile or casting call on the site itself.
+type Target struct {
+	Type string
+	ID   uint
+}
+
+// ParseInternal recognizes links to the site's own movie, talent profile and casting call
+// pages, served from frontendURL, so they can get native cards without a fetch.
+func ParseInternal(link, frontendURL string) (Target, bool) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return Target{}, false
+	}
+	frontend, err := url.Parse(frontendURL)
+	if err != nil || !strings.EqualFold(u.Host, frontend.Host) {
+		return Target{}, false
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var targetType, id string
+	switch {
+	case len(segments) == 2 && segments[0] == "movies":
+		targetType, id = TargetMovie, segments[1]
+	case len(segments) == 3 && segments[0] == "talent" && segments[1] == "profiles":
+		targetType, id = TargetTalentProfile, segments[2]
+	case len(segments) == 3 && segments[0] == "talent" && segments[1] == "casting-calls":
+		targetType, id = TargetCastingCall, segments[2]
+	default:
+		return Target{}, false
+	}
+	parsed, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || parsed == 0 {
+		return Target{}, false
+	}
+	return Target{Type: targetType, ID: uint(parsed)}, true
+}
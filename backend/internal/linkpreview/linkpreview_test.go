@@ -0,0 +1,153 @@
+package linkpreview
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractURLs(t *testing.T) {
+	// Test case: Trailing punctuation is left out, and the same page is only listed once
+	text := "Trailer: https://Example.com/watch?v=1. Also (see https://example.com/watch?v=1#t=30) and http://news.example.org/a,"
+	assert.Equal(t, []string{"https://example.com/watch?v=1", "http://news.example.org/a"}, ExtractURLs(text))
+
+	// Test case: Parentheses belonging to the link are kept
+	assert.Equal(t, []string{"https://en.wikipedia.org/wiki/RRR_(film)"}, ExtractURLs("https://en.wikipedia.org/wiki/RRR_(film)!"))
+
+	// Test case: Other schemes and bare domains aren't links
+	assert.Empty(t, ExtractURLs("ftp://example.com and example.com and javascript:alert(1)"))
+
+	// Test case: At most MaxLinks links
+	text = ""
+	for i := 0; i < MaxLinks+2; i++ {
+		text += fmt.Sprintf(" https://example.com/%d", i)
+	}
+	assert.Len(t, ExtractURLs(text), MaxLinks)
+}
+
+func TestParseInternal(t *testing.T) {
+	frontend := "https://siddu.example"
+
+	target, ok := ParseInternal("https://siddu.example/movies/42", frontend)
+	assert.True(t, ok)
+	assert.Equal(t, Target{Type: TargetMovie, ID: 42}, target)
+
+	target, ok = ParseInternal("https://SIDDU.example/talent/profiles/7/", frontend)
+	assert.True(t, ok)
+	assert.Equal(t, Target{Type: TargetTalentProfile, ID: 7}, target)
+
+	target, ok = ParseInternal("https://siddu.example/talent/casting-calls/9?ref=pulse", frontend)
+	assert.True(t, ok)
+	assert.Equal(t, Target{Type: TargetCastingCall, ID: 9}, target)
+
+	// Test case: Other hosts, other pages and bad IDs are external links
+	for _, link := range []string{
+		"https://other.example/movies/42",
+		"https://siddu.example/movies/42/reviews",
+		"https://siddu.example/movies/abc",
+		"https://siddu.example/pulses/3",
+	} {
+		_, ok = ParseInternal(link, frontend)
+		assert.False(t, ok, link)
+	}
+}
+
+func TestParseHTML(t *testing.T) {
+	base, _ := url.Parse("https://films.example/reviews/rrr")
+	page := `<html><head>
+		<title>RRR review &amp; more</title>
+		<meta property="og:title" content="RRR &#8211; Review">
+		<meta name="description" content="  A   roaring
+			epic. ">
+		<meta property='og:image' content='/images/rrr.jpg'>
+		<link rel="alternate" type="application/json+oembed" href="/oembed?url=rrr">
+	</head><body><meta property="og:title" content="Ignored"></body></html>`
+
+	preview, oembedURL := parseHTML([]byte(page), base)
+	assert.Equal(t, "RRR – Review", preview.Title)
+	assert.Equal(t, "A roaring epic.", preview.Description)
+	assert.Equal(t, "https://films.example/images/rrr.jpg", preview.ImageURL)
+	assert.Empty(t, preview.SiteName)
+	assert.Equal(t, "https://films.example/oembed?url=rrr", oembedURL)
+
+	// Test case: Falls back to the page title, and drops non-http images
+	preview, oembedURL = parseHTML([]byte(`<title>Plain page</title><meta name="twitter:image" content="javascript:alert(1)">`), base)
+	assert.Equal(t, "Plain page", preview.Title)
+	assert.Empty(t, preview.ImageURL)
+	assert.Empty(t, oembedURL)
+}
+
+func TestEmbedURL(t *testing.T) {
+	assert.Equal(t, "https://www.youtube.com/embed/abc?feature=oembed",
+		embedURL(`<iframe width="200" src="https://www.youtube.com/embed/abc?feature=oembed" allowfullscreen></iframe>`))
+
+	// Test case: Only https players from known providers, and nothing but the iframe's src
+	for _, embedHTML := range []string{
+		`<iframe src="https://vids.example/e/1"></iframe>`,
+		`<iframe src="http://www.youtube.com/embed/abc"></iframe>`,
+		`<iframe src="https://user@www.youtube.com/embed/abc"></iframe>`,
+		`<iframe src="javascript:alert(1)"></iframe>`,
+		`<script src="https://www.youtube.com/embed/abc"></script>`,
+	} {
+		assert.Empty(t, embedURL(embedHTML), embedHTML)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/video", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, `<head><title>Teaser</title><link rel="alternate" type="application/json+oembed" href="%s/oembed"></head>`, server.URL)
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"type":"video","provider_name":"Vids","thumbnail_url":"https://vids.example/t.jpg","html":"<iframe src=\"https://vids.example/e/1\"></iframe>"}`)
+	})
+	mux.HandleFunc("/poster.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/video", http.StatusFound)
+	})
+
+	// Test case: The test server is on loopback, which the real fetcher refuses to reach
+	_, err := NewFetcher().Fetch(context.Background(), server.URL+"/video")
+	assert.ErrorIs(t, err, ErrBlockedAddress)
+
+	fetcher := newFetcher(func(net.IP) bool { return true })
+
+	// Test case: Page metadata is combined with the oEmbed response, following redirects
+	preview, err := fetcher.Fetch(context.Background(), server.URL+"/redirect")
+	assert.NoError(t, err)
+	assert.Equal(t, "Teaser", preview.Title)
+	assert.Equal(t, "Vids", preview.SiteName)
+	assert.Equal(t, "https://vids.example/t.jpg", preview.ImageURL)
+	assert.Equal(t, "video", preview.EmbedType)
+	assert.Empty(t, preview.EmbedURL, "players from unknown providers are dropped")
+
+	// Test case: Without a site name or oEmbed provider, the host names the site
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<meta property="og:title" content="Box office">`)
+	})
+	preview, err = fetcher.Fetch(context.Background(), server.URL+"/article")
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", preview.SiteName)
+
+	// Test case: Images become photo cards
+	preview, err = fetcher.Fetch(context.Background(), server.URL+"/poster.png")
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/poster.png", preview.ImageURL)
+
+	// Test case: Missing pages fail
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing")
+	assert.Error(t, err)
+}
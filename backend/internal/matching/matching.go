@@ -0,0 +1,209 @@
+package matching
+
+import (
+	"math"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/skills"
+	"sort"
+	"strings"
+)
+
+// Criterion names reported in match explanations.
+const (
+	CriterionSkills     = "skills"
+	CriterionExperience = "experience"
+	CriterionPortfolio  = "portfolio"
+	CriterionLocation   = "location"
+)
+
+// weights controls how much each criterion contributes to the overall score.
+var weights = map[string]float64{
+	CriterionSkills:     0.40,
+	CriterionExperience: 0.25,
+	CriterionPortfolio:  0.15,
+	CriterionLocation:   0.20,
+}
+
+// CriterionScore explains how a single criterion contributed to a match.
+type CriterionScore struct {
+	Criterion string   `json:"criterion"`
+	Score     float64  `json:"score"`  // 0 to 1
+	Weight    float64  `json:"weight"` // share of the overall score, after skipping criteria the role doesn't specify
+	Matched   []string `json:"matched"`
+	Missing   []string `json:"missing"`
+}
+
+// Match is the result of scoring a talent profile against a casting call role.
+type Match struct {
+	TalentProfileID   uint             `json:"talentProfileId"`
+	CastingCallRoleID uint             `json:"castingCallRoleId"`
+	Score             float64          `json:"score"` // 0 to 100
+	Criteria          []CriterionScore `json:"criteria"`
+}
+
+// SplitList splits a comma-separated requirement field into normalized, non-empty values.
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = normalize(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SkillNames lists the skill names a taxonomy needs to resolve to score the profiles against
+// the roles.
+func SkillNames(profiles []models.TalentProfile, roles []models.CastingCallRole) []string {
+	var names []string
+	for _, profile := range profiles {
+		for _, skill := range profile.Skills {
+			names = append(names, skill.Name)
+		}
+	}
+	for _, role := range roles {
+		names = append(names, SplitList(role.RequiredSkills)...)
+	}
+	return names
+}
+
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// ScoreProfile scores a profile against a role. The profile's Skills, Experiences and
+// Portfolio must be loaded, and taxonomy should resolve both the profile's and the role's
+// skill names. Criteria the role doesn't specify are left out of the score.
+func ScoreProfile(profile models.TalentProfile, role models.CastingCallRole, taxonomy skills.Taxonomy) Match {
+	var criteria []CriterionScore
+
+	if required := SplitList(role.RequiredSkills); len(required) > 0 {
+		criteria = append(criteria, scoreSkills(profile.Skills, required, normalize(role.MinProficiency), taxonomy))
+	}
+	if keywords := SplitList(role.ExperienceKeywords); len(keywords) > 0 {
+		criteria = append(criteria, scoreExperience(profile.Experiences, keywords))
+	}
+	if mediaTypes := SplitList(role.PreferredMediaTypes); len(mediaTypes) > 0 {
+		criteria = append(criteria, scorePortfolio(profile.Portfolio, mediaTypes))
+	}
+	if location := normalize(role.Location); location != "" {
+		criteria = append(criteria, scoreLocation(normalize(profile.Location), location))
+	}
+
+	match := Match{TalentProfileID: profile.ID, CastingCallRoleID: role.ID, Criteria: criteria}
+	if len(criteria) == 0 {
+		return match
+	}
+
+	var totalWeight float64
+	for _, criterion := range criteria {
+		totalWeight += weights[criterion.Criterion]
+	}
+
+	var score float64
+	for i := range match.Criteria {
+		match.Criteria[i].Weight = round(weights[match.Criteria[i].Criterion] / totalWeight)
+		score += match.Criteria[i].Score * weights[match.Criteria[i].Criterion] / totalWeight
+	}
+	match.Score = round(score * 100)
+	return match
+}
+
+// scoreSkills gives full credit for a required skill at or above the minimum proficiency
+// and half credit for the skill at a lower level. Skills are compared by taxonomy skill, so
+// an alias matches its canonical name.
+func scoreSkills(profileSkills []models.Skill, required []string, minProficiency string, taxonomy skills.Taxonomy) CriterionScore {
+	levels := make(map[string]int, len(profileSkills))
+	for _, skill := range profileSkills {
+		levels[taxonomy.CanonicalKey(skill.Name)] = skills.ProficiencyRank(skill.Proficiency)
+	}
+
+	result := CriterionScore{Criterion: CriterionSkills, Matched: []string{}, Missing: []string{}}
+	var total float64
+	for _, name := range required {
+		level, ok := levels[taxonomy.CanonicalKey(name)]
+		switch {
+		case !ok:
+			result.Missing = append(result.Missing, name)
+		case level >= skills.ProficiencyRank(minProficiency):
+			result.Matched = append(result.Matched, name)
+			total += 1
+		default:
+			result.Matched = append(result.Matched, name+" (below "+minProficiency+")")
+			total += 0.5
+		}
+	}
+	result.Score = round(total / float64(len(required)))
+	return result
+}
+
+// scoreExperience checks each keyword against the profile's experience titles.
+func scoreExperience(experiences []models.Experience, keywords []string) CriterionScore {
+	result := CriterionScore{Criterion: CriterionExperience, Matched: []string{}, Missing: []string{}}
+	for _, keyword := range keywords {
+		found := false
+		for _, experience := range experiences {
+			if strings.Contains(normalize(experience.Title), keyword) {
+				found = true
+				break
+			}
+		}
+		if found {
+			result.Matched = append(result.Matched, keyword)
+		} else {
+			result.Missing = append(result.Missing, keyword)
+		}
+	}
+	result.Score = round(float64(len(result.Matched)) / float64(len(keywords)))
+	return result
+}
+
+// scorePortfolio checks the profile has portfolio items of each preferred media type.
+func scorePortfolio(items []models.PortfolioItem, mediaTypes []string) CriterionScore {
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[normalize(item.MediaType)] = true
+	}
+
+	result := CriterionScore{Criterion: CriterionPortfolio, Matched: []string{}, Missing: []string{}}
+	for _, mediaType := range mediaTypes {
+		if present[mediaType] {
+			result.Matched = append(result.Matched, mediaType)
+		} else {
+			result.Missing = append(result.Missing, mediaType)
+		}
+	}
+	result.Score = round(float64(len(result.Matched)) / float64(len(mediaTypes)))
+	return result
+}
+
+// scoreLocation matches when either location contains the other, so "Mumbai" matches
+// "Mumbai, India". Remote roles match everyone.
+func scoreLocation(profileLocation, roleLocation string) CriterionScore {
+	result := CriterionScore{Criterion: CriterionLocation, Matched: []string{}, Missing: []string{}}
+	if AnyLocation(roleLocation) ||
+		(profileLocation != "" && (strings.Contains(profileLocation, roleLocation) || strings.Contains(roleLocation, profileLocation))) {
+		result.Score = 1
+		result.Matched = append(result.Matched, roleLocation)
+	} else {
+		result.Missing = append(result.Missing, roleLocation)
+	}
+	return result
+}
+
+// AnyLocation reports whether a role location is met wherever the talent is.
+func AnyLocation(roleLocation string) bool {
+	roleLocation = normalize(roleLocation)
+	return roleLocation == "remote" || roleLocation == "any"
+}
+
+// SortByScore orders matches from best to worst.
+func SortByScore(matches []Match) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,73 @@
+package matching
+
+import (
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/skills"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"acting", "horse riding"}, SplitList(" Acting, ,Horse Riding "))
+	assert.Empty(t, SplitList(""))
+}
+
+func TestScoreProfile(t *testing.T) {
+	profile := models.TalentProfile{
+		Location: "Mumbai, India",
+		Skills: []models.Skill{
+			{Name: "Acting", Proficiency: "Expert"},
+			{Name: "Dancing", Proficiency: "Beginner"},
+		},
+		Experiences: []models.Experience{{Title: "Lead Actor"}},
+		Portfolio:   []models.PortfolioItem{{MediaType: "video"}},
+	}
+	role := models.CastingCallRole{
+		RequiredSkills:      "Acting, Dancing, Singing",
+		MinProficiency:      "Intermediate",
+		ExperienceKeywords:  "actor",
+		PreferredMediaTypes: "video, audio",
+		Location:            "Mumbai",
+	}
+
+	match := ScoreProfile(profile, role, nil)
+	assert.Len(t, match.Criteria, 4)
+
+	// Test case: Skills get full credit for acting, half for dancing, none for singing
+	skillScore := match.Criteria[0]
+	assert.Equal(t, CriterionSkills, skillScore.Criterion)
+	assert.Equal(t, 0.5, skillScore.Score)
+	assert.Equal(t, []string{"singing"}, skillScore.Missing)
+
+	assert.Equal(t, 1.0, match.Criteria[1].Score)
+	assert.Equal(t, 0.5, match.Criteria[2].Score)
+	assert.Equal(t, 1.0, match.Criteria[3].Score)
+
+	// 0.4*0.5 + 0.25*1 + 0.15*0.5 + 0.2*1
+	assert.Equal(t, 72.5, match.Score)
+
+	// Test case: A required skill matches the profile's skill under another of its names
+	taxonomy := skills.Taxonomy{"vo": "voice over", "voice over": "voice over"}
+	profile.Skills = append(profile.Skills, models.Skill{Name: "Voice Over", Proficiency: "Advanced"})
+	match = ScoreProfile(profile, models.CastingCallRole{RequiredSkills: "VO"}, taxonomy)
+	assert.Equal(t, 1.0, match.Criteria[0].Score)
+	assert.Equal(t, []string{"vo"}, match.Criteria[0].Matched)
+}
+
+func TestScoreProfileSkipsUnspecifiedCriteria(t *testing.T) {
+	profile := models.TalentProfile{Location: "Chennai"}
+	role := models.CastingCallRole{Location: "Remote"}
+
+	match := ScoreProfile(profile, role, nil)
+	assert.Len(t, match.Criteria, 1)
+	assert.Equal(t, 1.0, match.Criteria[0].Weight)
+	assert.Equal(t, 100.0, match.Score)
+}
+
+func TestAnyLocation(t *testing.T) {
+	assert.True(t, AnyLocation(" Remote "))
+	assert.True(t, AnyLocation("ANY"))
+	assert.False(t, AnyLocation("Hyderabad"))
+	assert.False(t, AnyLocation(""))
+}
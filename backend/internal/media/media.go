@@ -0,0 +1,284 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Media types a portfolio item can hold.
+const (
+	TypeImage = "image"
+	TypeVideo = "video"
+	TypeAudio = "audio"
+)
+
+// Limits bounds what can be uploaded for a media type.
+type Limits struct {
+	MaxBytes  int64
+	MaxWidth  int // Images only
+	MaxHeight int // Images only
+	MaxPixels int // Images only
+}
+
+// limits per media type. Image dimensions are checked before decoding so oversized images
+// are rejected without allocating their pixels; at MaxPixels a decoded image, plus the
+// upright copy made when its EXIF orientation is applied, takes about 200MB.
+var limits = map[string]Limits{
+	TypeImage: {MaxBytes: 15 << 20, MaxWidth: 6000, MaxHeight: 6000, MaxPixels: 24_000_000},
+	TypeVideo: {MaxBytes: 500 << 20},
+	TypeAudio: {MaxBytes: 50 << 20},
+}
+
+// allowedContentTypes are the sniffed content types accepted for each media type.
+var allowedContentTypes = map[string][]string{
+	TypeImage: {"image/jpeg", "image/png", "image/gif"},
+	TypeVideo: {"video/mp4", "video/webm", "video/avi"},
+	TypeAudio: {"audio/mpeg", "audio/wave", "audio/aiff", "application/ogg"},
+}
+
+// ThumbnailSizes are the longest-edge sizes, in pixels, generated for each image.
+var ThumbnailSizes = []struct {
+	Name string
+	Edge int
+}{
+	{"small", 160},
+	{"medium", 480},
+	{"large", 1200},
+}
+
+// UploadTempPath is where the chunks of a resumable upload are collected until it completes.
+func UploadTempPath(uploadID uint) string {
+	return filepath.Join(os.TempDir(), "siddu-uploads", strconv.FormatUint(uint64(uploadID), 10)+".part")
+}
+
+// Extension returns the file extension used when storing a file of the content type.
+func Extension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "video/mp4":
+		return ".mp4"
+	case "video/webm":
+		return ".webm"
+	case "video/avi":
+		return ".avi"
+	case "audio/mpeg":
+		return ".mp3"
+	case "audio/wave":
+		return ".wav"
+	case "audio/aiff":
+		return ".aiff"
+	case "application/ogg":
+		return ".ogg"
+	}
+	return ""
+}
+
+// ErrUnsupported is returned for files whose content doesn't match the declared media type.
+var ErrUnsupported = errors.New("file content does not match the media type")
+
+// LimitsFor returns the upload limits for the media type, and false if it isn't supported.
+func LimitsFor(mediaType string) (Limits, bool) {
+	l, ok := limits[mediaType]
+	return l, ok
+}
+
+// MaxUploadBytes is the largest file any media type allows, for capping request bodies before
+// the media type is known.
+func MaxUploadBytes() int64 {
+	var largest int64
+	for _, l := range limits {
+		largest = max(largest, l.MaxBytes)
+	}
+	return largest
+}
+
+// CheckSize rejects files larger than the media type allows.
+func CheckSize(mediaType string, size int64) error {
+	l, ok := LimitsFor(mediaType)
+	if !ok {
+		return fmt.Errorf("unsupported media type %q", mediaType)
+	}
+	if size <= 0 {
+		return errors.New("file is empty")
+	}
+	if size > l.MaxBytes {
+		return fmt.Errorf("file is %d bytes, the limit for %s is %d bytes", size, mediaType, l.MaxBytes)
+	}
+	return nil
+}
+
+// Sniff detects the content type from the start of the file and checks it is allowed for
+// the declared media type. The client-supplied Content-Type is never trusted.
+func Sniff(r io.ReadSeeker, mediaType string) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	for _, allowed := range allowedContentTypes[mediaType] {
+		if contentType == allowed {
+			return contentType, nil
+		}
+	}
+	return contentType, ErrUnsupported
+}
+
+// Thumbnail is a scaled-down copy of an image.
+type Thumbnail struct {
+	Name   string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// ProcessedImage is an uploaded image re-encoded without its metadata, plus its thumbnails.
+type ProcessedImage struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+	Thumbnails  []Thumbnail
+}
+
+// ProcessImage checks the image's dimensions, then decodes and re-encodes it. Re-encoding
+// drops EXIF and other metadata (GPS location, camera serials) from JPEG and PNG files, so a
+// JPEG's EXIF orientation is applied to its pixels first.
+// Animated GIFs are stored as-is since they carry no EXIF; their thumbnails use the first frame.
+func ProcessImage(data []byte, contentType string) (ProcessedImage, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("could not read image: %w", err)
+	}
+	l := limits[TypeImage]
+	if config.Width > l.MaxWidth || config.Height > l.MaxHeight {
+		return ProcessedImage{}, fmt.Errorf("image is %dx%d, the limit is %dx%d", config.Width, config.Height, l.MaxWidth, l.MaxHeight)
+	}
+	if config.Width*config.Height > l.MaxPixels {
+		return ProcessedImage{}, fmt.Errorf("image is %d pixels, the limit is %d", config.Width*config.Height, l.MaxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("could not decode image: %w", err)
+	}
+
+	if contentType == "image/jpeg" {
+		if orientation := jpegOrientation(data); orientation > 1 {
+			img = orient(toRGBA(img), orientation)
+		}
+	}
+
+	bounds := img.Bounds()
+	result := ProcessedImage{ContentType: contentType, Width: bounds.Dx(), Height: bounds.Dy(), Data: data}
+	if contentType != "image/gif" {
+		if result.Data, err = encode(img, contentType); err != nil {
+			return ProcessedImage{}, err
+		}
+	}
+
+	thumbnailType := ThumbnailContentType(contentType)
+	var pixels *image.RGBA
+	for _, size := range ThumbnailSizes {
+		width, height := fit(result.Width, result.Height, size.Edge)
+		if width >= result.Width && height >= result.Height {
+			continue
+		}
+		if pixels == nil {
+			pixels = toRGBA(img)
+		}
+		thumbnail, err := encode(Resize(pixels, width, height), thumbnailType)
+		if err != nil {
+			return ProcessedImage{}, err
+		}
+		result.Thumbnails = append(result.Thumbnails, Thumbnail{Name: size.Name, Width: width, Height: height, Data: thumbnail})
+	}
+	return result, nil
+}
+
+// ThumbnailContentType is the content type thumbnails of an image are encoded as. GIF
+// thumbnails are still images, so they are stored as PNG.
+func ThumbnailContentType(contentType string) string {
+	if contentType == "image/gif" {
+		return "image/png"
+	}
+	return contentType
+}
+
+func encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 88})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	default:
+		err = fmt.Errorf("cannot encode %s", contentType)
+	}
+	return buf.Bytes(), err
+}
+
+// fit scales width and height so the longest edge is at most edge, keeping the aspect ratio.
+func fit(width, height, edge int) (int, int) {
+	if width <= edge && height <= edge {
+		return width, height
+	}
+	if width >= height {
+		return edge, max(1, height*edge/width)
+	}
+	return max(1, width*edge/height), edge
+}
+
+// Resize scales img to width x height by averaging the source pixels each destination pixel
+// covers, which avoids the aliasing of nearest-neighbour scaling when shrinking. Images other
+// than *image.RGBA are converted first so the pixels can be summed straight from Pix.
+func Resize(img image.Image, width, height int) *image.RGBA {
+	src := toRGBA(img)
+	sb := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := y * sb.Dy() / height
+		y1 := max(y0+1, (y+1)*sb.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := x * sb.Dx() / width
+			x1 := max(x0+1, (x+1)*sb.Dx()/width)
+
+			var r, g, b, a uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[src.PixOffset(sb.Min.X+x0, sb.Min.Y+sy):src.PixOffset(sb.Min.X+x1, sb.Min.Y+sy)]
+				for i := 0; i < len(row); i += 4 {
+					r, g, b, a = r+uint64(row[i]), g+uint64(row[i+1]), b+uint64(row[i+2]), a+uint64(row[i+3])
+				}
+			}
+
+			n := uint64((y1 - y0) * (x1 - x0))
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,151 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPNG(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func TestCheckSize(t *testing.T) {
+	assert.NoError(t, CheckSize(TypeImage, 1024))
+	assert.Error(t, CheckSize(TypeImage, 0))
+	assert.Error(t, CheckSize(TypeImage, 16<<20))
+	assert.NoError(t, CheckSize(TypeVideo, 16<<20))
+	assert.Error(t, CheckSize("document", 1024))
+}
+
+func TestMaxUploadBytes(t *testing.T) {
+	// Test case: The cap fits the largest media type
+	assert.NoError(t, CheckSize(TypeVideo, MaxUploadBytes()))
+	assert.Error(t, CheckSize(TypeVideo, MaxUploadBytes()+1))
+}
+
+func TestSniff(t *testing.T) {
+	data := testPNG(4, 4)
+
+	contentType, err := Sniff(bytes.NewReader(data), TypeImage)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	// Test case: The declared media type must match the content
+	_, err = Sniff(bytes.NewReader(data), TypeVideo)
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	_, err = Sniff(strings.NewReader("<html><script>alert(1)</script></html>"), TypeImage)
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestProcessImage(t *testing.T) {
+	result, err := ProcessImage(testPNG(600, 300), "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, 600, result.Width)
+	assert.Equal(t, 300, result.Height)
+
+	// Test case: Thumbnails larger than the original are skipped
+	assert.Len(t, result.Thumbnails, 2)
+	assert.Equal(t, "small", result.Thumbnails[0].Name)
+	assert.Equal(t, 160, result.Thumbnails[0].Width)
+	assert.Equal(t, 80, result.Thumbnails[0].Height)
+
+	thumbnail, err := png.Decode(bytes.NewReader(result.Thumbnails[1].Data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Pt(480, 240), thumbnail.Bounds().Size())
+}
+
+func TestProcessImageStripsEXIF(t *testing.T) {
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 8)), nil)
+	original := buf.Bytes()
+
+	// Insert an APP1 EXIF segment straight after the SOI marker
+	exif := append([]byte{0xFF, 0xE1, 0x00, 0x10}, []byte("Exif\x00\x00GPS-DATA")...)
+	withEXIF := append(append(append([]byte{}, original[:2]...), exif...), original[2:]...)
+
+	result, err := ProcessImage(withEXIF, "image/jpeg")
+	assert.NoError(t, err)
+	assert.NotContains(t, string(result.Data), "GPS-DATA")
+}
+
+func TestProcessImageRejectsHugeDimensions(t *testing.T) {
+	_, err := ProcessImage(testPNG(6001, 1), "image/png")
+	assert.Error(t, err)
+
+	// Test case: Each edge is under the cap but the pixel count isn't
+	_, err = ProcessImage(testPNG(5000, 5000), "image/png")
+	assert.Error(t, err)
+}
+
+func TestProcessImageAppliesOrientation(t *testing.T) {
+	// A landscape photo with its left half red, taken with the camera turned a quarter
+	img := image.NewRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			if x < 8 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100})
+	original := buf.Bytes()
+
+	// APP1 EXIF segment with a big-endian IFD holding only Orientation = 6
+	exif := []byte{0xFF, 0xE1, 0x00, 0x22}
+	exif = append(exif, "Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08"...)
+	exif = append(exif, 0x00, 0x01, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x06, 0x00, 0x00)
+	exif = append(exif, 0x00, 0x00, 0x00, 0x00)
+	withEXIF := append(append(append([]byte{}, original[:2]...), exif...), original[2:]...)
+	assert.Equal(t, 6, jpegOrientation(withEXIF))
+
+	// Test case: The pixels are turned upright before EXIF is dropped
+	result, err := ProcessImage(withEXIF, "image/jpeg")
+	assert.NoError(t, err)
+	assert.Equal(t, 8, result.Width)
+	assert.Equal(t, 16, result.Height)
+
+	upright, err := jpeg.Decode(bytes.NewReader(result.Data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Pt(8, 16), upright.Bounds().Size())
+	r, _, b, _ := upright.At(4, 3).RGBA()
+	assert.Greater(t, r, b, "the left half is on top after turning clockwise")
+
+	// Test case: Files without EXIF are upright
+	assert.Equal(t, 1, jpegOrientation(original))
+}
+
+func TestResize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+		img.Set(x, 1, color.RGBA{R: 100, A: 255})
+	}
+
+	// Test case: Each destination pixel averages the block it covers
+	resized := Resize(img, 2, 1)
+	assert.Equal(t, color.RGBA{R: 150, A: 255}, resized.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{R: 150, A: 255}, resized.RGBAAt(1, 0))
+
+	// Test case: Sub-images are read from their own bounds
+	sub := img.SubImage(image.Rect(0, 1, 4, 2))
+	assert.Equal(t, color.RGBA{R: 100, A: 255}, Resize(sub, 1, 1).RGBAAt(0, 0))
+}
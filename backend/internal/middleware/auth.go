@@ -2,36 +2,104 @@ package middleware
 
 import (
 	"net/http"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/moderation"
 	"siddu-verse-backend/internal/utils"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-// AuthMiddleware creates a Gin middleware for JWT authentication.
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware creates a Gin middleware for JWT authentication. Suspended and banned
+// accounts are turned away; they can still reach the routes behind AccountMiddleware.
+func AuthMiddleware(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+		userID, ok := authenticate(c)
+		if !ok {
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+		var user models.User
+		if err := db.Select("id", "status", "suspended_until").First(&user, userID).Error; err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Account not found"})
 			return
 		}
-
-		tokenString := parts[1]
-		claims, err := utils.ValidateJWT(tokenString)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		switch moderation.AccountStatus(user, time.Now()) {
+		case moderation.AccountBanned:
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This account has been banned", "accountStatus": moderation.AccountBanned})
+			return
+		case moderation.AccountSuspended:
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":          "This account is suspended",
+				"accountStatus":  moderation.AccountSuspended,
+				"suspendedUntil": user.SuspendedUntil,
+			})
 			return
 		}
 
 		// Set user ID in the context for downstream handlers
-		c.Set("userID", claims.UserID)
+		c.Set("userID", userID)
+
+		c.Next()
+	}
+}
+
+// AccountMiddleware authenticates the request without checking the account's standing, for
+// the routes suspended and banned users still need, like appealing.
+func AccountMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := authenticate(c)
+		if !ok {
+			return
+		}
+
+		c.Set("userID", userID)
+
+		c.Next()
+	}
+}
+
+// authenticate validates the bearer token and returns the user it was issued to. It aborts
+// the request on failure.
+func authenticate(c *gin.Context) (uint, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+		return 0, false
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+		return 0, false
+	}
+
+	tokenString := parts[1]
+	claims, err := utils.ValidateJWT(tokenString)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		return 0, false
+	}
+	return claims.UserID, true
+}
+
+// OptionalAuthMiddleware sets the user ID in the context when a valid token is present,
+// but lets anonymous requests through. Used on public routes that personalise their response.
+// Tokens of banned or deleted accounts are ignored, so those requests are served as anonymous.
+func OptionalAuthMiddleware(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := utils.ValidateJWT(parts[1]); err == nil {
+				var user models.User
+				if err := db.Select("id", "status", "suspended_until").First(&user, claims.UserID).Error; err == nil &&
+					moderation.AccountStatus(user, time.Now()) != moderation.AccountBanned {
+					c.Set("userID", claims.UserID)
+				}
+			}
+		}
 
 		c.Next()
 	}
@@ -17,6 +17,10 @@ type User struct {
 	AvatarURL     string
 	Role          string `gorm:"default:'user'"` // e.g., user, creator, admin
 	TalentProfile TalentProfile `gorm:"foreignKey:UserID"`
+	FollowerCount  int64 `gorm:"default:0"` // Users following this account
+	FollowingCount int64 `gorm:"default:0"` // Accounts and talent profiles this user follows
+	Status         string `gorm:"index;default:'active'"` // active, suspended, banned
+	SuspendedUntil *time.Time
 }
 
 // --- Talent Hub Models ---
@@ -30,18 +34,80 @@ type TalentProfile struct {
 	Bio         string
 	AvatarURL   string
 	CoverImageURL string
+	Location    string // e.g., "Mumbai, India"
+	IsVerified  bool   `gorm:"index;default:false"`
+	VerifiedAt  *time.Time
+	HiddenAt    *time.Time `gorm:"index"` // Set when a moderator hides the profile
 	Skills      []Skill         `gorm:"foreignKey:TalentProfileID"`
 	Experiences []Experience    `gorm:"foreignKey:TalentProfileID"`
 	Portfolio   []PortfolioItem `gorm:"foreignKey:TalentProfileID"`
 	Applications []Application  `gorm:"foreignKey:TalentProfileID"`
+	FollowerCount int64 `gorm:"default:0"`
+}
+
+// VerificationRequest is a talent's request to have their profile verified, reviewed by an admin.
+type VerificationRequest struct {
+	gorm.Model
+	TalentProfileID  uint          `gorm:"index;not null"`
+	TalentProfile    TalentProfile `gorm:"foreignKey:TalentProfileID"`
+	Status           string        `gorm:"index;default:'pending'"` // pending, approved, rejected, revoked
+	Note             string        // Message from the talent to the reviewer
+	ReviewedByUserID *uint
+	ReviewedAt       *time.Time
+	Reason           string // Why the request was rejected or the verification revoked
+	Evidence         []VerificationEvidence `gorm:"foreignKey:VerificationRequestID"`
+}
+
+// VerificationEvidence is one piece of evidence attached to a verification request.
+type VerificationEvidence struct {
+	gorm.Model
+	VerificationRequestID uint   `gorm:"index;not null"`
+	Type                  string `gorm:"not null"` // union_membership, link, id_document
+	Value                 string `gorm:"not null"` // Membership number, URL, or uploaded document URL
 }
 
 // Skill represents a specific skill for a talent profile.
 type Skill struct {
 	gorm.Model
-	TalentProfileID uint
-	Name            string `gorm:"not null"`
-	Proficiency     string // e.g., "Expert", "Intermediate"
+	TalentProfileID  uint
+	Name             string `gorm:"not null"`
+	Proficiency      string // Beginner, Intermediate, Advanced, Expert
+	TaxonomySkillID  *uint  `gorm:"index"` // Set when the name matched the curated taxonomy
+	EndorsementCount int64  `gorm:"-"`     // Filled in when a profile is loaded for display
+}
+
+// SkillEndorsement is another user vouching for a skill on a talent profile.
+type SkillEndorsement struct {
+	gorm.Model
+	SkillID        uint `gorm:"uniqueIndex:idx_skill_endorser;not null"`
+	EndorserUserID uint `gorm:"uniqueIndex:idx_skill_endorser;not null"`
+	Endorser       User `gorm:"foreignKey:EndorserUserID"`
+	Comment        string
+}
+
+// SkillCategory groups taxonomy skills, e.g. "Performance", "Dance", "Technical".
+type SkillCategory struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex;not null"`
+	Description string
+	Skills      []TaxonomySkill `gorm:"foreignKey:CategoryID"`
+}
+
+// TaxonomySkill is a curated, canonical skill that free-text skill names are mapped onto.
+type TaxonomySkill struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex;not null"` // Canonical display name, e.g. "Acting"
+	CategoryID  *uint  `gorm:"index"`
+	Description string
+	Aliases     []SkillAlias `gorm:"foreignKey:TaxonomySkillID"`
+}
+
+// SkillAlias is another name that resolves to a taxonomy skill.
+type SkillAlias struct {
+	gorm.Model
+	TaxonomySkillID uint   `gorm:"index;not null"`
+	Name            string `gorm:"uniqueIndex;not null"` // Stored lower-cased, e.g. "actor"
+	Kind            string `gorm:"default:'alias'"`      // alias (alternative spelling, e.g. "hiphop" for Hip-Hop), synonym (e.g. "actor" for Acting)
 }
 
 // Experience represents a work experience entry.
@@ -63,21 +129,102 @@ type PortfolioItem struct {
 	Description     string
 	MediaURL        string `gorm:"not null"` // URL to video, image, etc.
 	MediaType       string // "video", "image", "audio"
+	Position        int    `gorm:"default:0"` // Display order on the profile, lowest first
+
+	// Set for media uploaded through the API rather than linked by URL
+	StorageKey  string
+	ContentType string // Sniffed from the file, e.g. "image/jpeg"
+	SizeBytes   int64
+	Width       int // Images only
+	Height      int // Images only
+	Thumbnails  []PortfolioThumbnail `gorm:"foreignKey:PortfolioItemID"`
+}
+
+// PortfolioThumbnail is a scaled-down copy of an uploaded portfolio image.
+type PortfolioThumbnail struct {
+	gorm.Model
+	PortfolioItemID uint   `gorm:"index;not null"`
+	Name            string `gorm:"not null"` // small, medium, large
+	Width           int
+	Height          int
+	URL             string `gorm:"not null"`
+	StorageKey      string `gorm:"not null"`
+}
+
+// PortfolioUpload is a resumable, chunked upload of a portfolio file. Each chunk is stored as
+// a part of a multipart upload in file storage until ReceivedSize reaches TotalSize, when the
+// parts are joined and the portfolio item is created.
+type PortfolioUpload struct {
+	gorm.Model
+	TalentProfileID uint   `gorm:"index;not null"`
+	UserID          uint   `gorm:"not null"`
+	MediaType       string `gorm:"not null"` // "video", "image", "audio"
+	FileName        string
+	Title           string `gorm:"not null"`
+	Description     string
+	TotalSize       int64     `gorm:"not null"`
+	ReceivedSize    int64     `gorm:"default:0"`
+	Status          string    `gorm:"index;default:'uploading'"` // uploading, completed, failed, cancelled, expired
+	ExpiresAt       time.Time `gorm:"index"`
+	PortfolioItemID *uint
+	Error           string // Why processing failed
+	ContentType     string // Sniffed from the first chunk
+	StorageKey      string `json:"-"` // Where the parts are joined
+	StorageUploadID string `json:"-"` // The storage backend's multipart upload ID
+}
+
+// PortfolioUploadPart is a chunk of a PortfolioUpload stored as one part of its multipart
+// upload, with the ETag needed to join it.
+type PortfolioUploadPart struct {
+	ID                uint   `gorm:"primarykey"`
+	PortfolioUploadID uint   `gorm:"uniqueIndex:idx_portfolio_upload_part;not null"`
+	Number            int    `gorm:"uniqueIndex:idx_portfolio_upload_part;not null"`
+	ETag              string `gorm:"not null"`
+	Size              int64  `gorm:"not null"`
+	CreatedAt         time.Time
+}
+
+// AvailabilityEntry is a block of time on a talent's calendar: time they've marked as
+// unavailable, a tentative hold placed by a recruiter, or a confirmed booking.
+type AvailabilityEntry struct {
+	gorm.Model
+	TalentProfileID uint       `gorm:"index;not null"`
+	Kind            string     `gorm:"index;not null"` // unavailable, hold, booking
+	StartsAt        time.Time  `gorm:"index;not null"`
+	EndsAt          time.Time  `gorm:"index;not null"`
+	Title           string     // e.g., "Family wedding", "Hold: Feature Film shoot"
+	Note            string
+	CastingCallID   *uint      `gorm:"index"` // Set for holds and bookings
+	CreatedByUserID uint       `gorm:"not null"`
+	ExpiresAt       *time.Time // Holds lapse automatically after this time
 }
 
 // CastingCall represents a job posting for talent.
 type CastingCall struct {
 	gorm.Model
-	PostedByUserID uint   `gorm:"not null"`
-	PostedByUser   User   `gorm:"foreignKey:PostedByUserID"`
-	ProjectTitle   string `gorm:"not null"`
-	ProjectType    string // e.g., "Feature Film", "Short Film", "Web Series"
-	Description    string
-	IsActive       bool   `gorm:"default:true"`
-	Roles          []CastingCallRole `gorm:"foreignKey:CastingCallID"`
-	Applications   []Application     `gorm:"foreignKey:CastingCallID"`
+	PostedByUserID      uint       `gorm:"not null"`
+	PostedByUser        User       `gorm:"foreignKey:PostedByUserID"`
+	ProjectTitle        string     `gorm:"not null"`
+	ProjectType         string     // e.g., "Feature Film", "Short Film", "Web Series"
+	Description         string
+	Status              string     `gorm:"index;default:'published'"` // draft, published, closed, archived
+	ApplicationDeadline *time.Time `gorm:"index"`
+	StartDate           *time.Time
+	PublishedAt         *time.Time
+	ClosedAt            *time.Time
+	HiddenAt            *time.Time `gorm:"index"` // Set when a moderator hides the call
+	Roles               []CastingCallRole `gorm:"foreignKey:CastingCallID"`
+	Applications        []Application     `gorm:"foreignKey:CastingCallID"`
 }
 
+// Casting call lifecycle states.
+const (
+	CastingStatusDraft     = "draft"
+	CastingStatusPublished = "published"
+	CastingStatusClosed    = "closed"
+	CastingStatusArchived  = "archived"
+)
+
 // CastingCallRole represents a specific role within a casting call.
 type CastingCallRole struct {
 	gorm.Model
@@ -85,50 +232,541 @@ type CastingCallRole struct {
 	RoleName      string `gorm:"not null"`
 	Description   string
 	Requirements  string // e.g., "Age: 25-35, Height: 6'0\""
+
+	// Structured requirements used for talent matching
+	RequiredSkills      string // comma-separated, e.g., "Acting, Horse Riding"
+	MinProficiency      string // e.g., "Intermediate"; applies to every required skill
+	ExperienceKeywords  string // comma-separated, matched against experience titles
+	PreferredMediaTypes string // comma-separated, e.g., "video, audio"
+	Location            string // e.g., "Hyderabad" or "Remote"
 }
 
 // Application represents a talent's application to a casting call.
 type Application struct {
 	gorm.Model
-	TalentProfileID uint `gorm:"not null"`
-	CastingCallID   uint `gorm:"not null"`
-	Status          string `gorm:"default:'pending'"` // pending, shortlisted, rejected, hired
-	CoverLetter     string
+	TalentProfileID   uint `gorm:"not null"`
+	TalentProfile     TalentProfile `gorm:"foreignKey:TalentProfileID"`
+	CastingCallID     uint `gorm:"not null"`
+	CastingCall       CastingCall `gorm:"foreignKey:CastingCallID"`
+	Status            string `gorm:"default:'pending'"` // pending, shortlisted, rejected, hired
+	CoverLetter       string
+	SubmittedByUserID uint    // The talent themself, or the agent who applied on their behalf
+	AgencyID          *uint   `gorm:"index"` // Set when an agent applied on the talent's behalf
+	Agency            *Agency `gorm:"foreignKey:AgencyID"`
+}
+
+// ApplicationEvent is an audit trail entry recording who did what to an application.
+type ApplicationEvent struct {
+	gorm.Model
+	ApplicationID uint   `gorm:"index;not null"`
+	ActorUserID   uint   `gorm:"not null"`
+	Actor         User   `gorm:"foreignKey:ActorUserID"`
+	AgencyID      *uint  // Set when the actor was acting for an agency
+	Action        string `gorm:"not null"` // submitted, status_changed
+	Detail        string // e.g., the new status
+}
+
+// CastingCallMember is a member of a casting call's production team.
+type CastingCallMember struct {
+	gorm.Model
+	CastingCallID   uint   `gorm:"uniqueIndex:idx_casting_call_member;not null"`
+	UserID          uint   `gorm:"uniqueIndex:idx_casting_call_member;not null"`
+	User            User   `gorm:"foreignKey:UserID"`
+	Role            string `gorm:"default:'viewer'"` // owner, editor, reviewer, viewer
+	InvitedByUserID uint
+}
+
+// CastingCallInvitation is a pending invitation to join a casting call's team, sent by username or email.
+type CastingCallInvitation struct {
+	gorm.Model
+	CastingCallID   uint        `gorm:"index;not null"`
+	CastingCall     CastingCall `gorm:"foreignKey:CastingCallID"`
+	InvitedUserID   *uint       `gorm:"index"` // set when the invitee already has an account
+	Email           string      `gorm:"index;not null"`
+	Role            string      `gorm:"not null"`
+	Token           string      `gorm:"uniqueIndex;not null" json:"-"` // Only ever sent in the invitation email
+	Status          string      `gorm:"default:'pending'"`             // pending, accepted, declined, revoked
+	InvitedByUserID uint        `gorm:"not null"`
+}
+
+// ApplicationNote is a private recruiter note on an application. Never shown to the applicant.
+type ApplicationNote struct {
+	gorm.Model
+	ApplicationID uint   `gorm:"index;not null"`
+	AuthorUserID  uint   `gorm:"not null"`
+	Author        User   `gorm:"foreignKey:AuthorUserID"`
+	Content       string `gorm:"not null"`
+}
+
+// ApplicationRating is a single recruiter's 1-5 rating of an application.
+type ApplicationRating struct {
+	gorm.Model
+	ApplicationID uint `gorm:"uniqueIndex:idx_application_rater;not null"`
+	RaterUserID   uint `gorm:"uniqueIndex:idx_application_rater;not null"`
+	Score         int  `gorm:"not null"`
+}
+
+// ApplicationTag is a free-form recruiter label on an application, e.g. "callback".
+type ApplicationTag struct {
+	gorm.Model
+	ApplicationID uint   `gorm:"uniqueIndex:idx_application_tag;not null"`
+	Name          string `gorm:"uniqueIndex:idx_application_tag;index;not null"`
+}
+
+// --- Agency Models ---
+
+// Agency is a talent agency whose agents manage applications for the talent they represent.
+type Agency struct {
+	gorm.Model
+	Name            string `gorm:"uniqueIndex;not null"`
+	Description     string
+	Website         string
+	Location        string
+	CreatedByUserID uint          `gorm:"not null"`
+	HiddenAt        *time.Time     `gorm:"index"` // Set when a moderator hides the agency
+	Members         []AgencyMember `gorm:"foreignKey:AgencyID"`
+}
+
+// AgencyMember is an agent working at an agency.
+type AgencyMember struct {
+	gorm.Model
+	AgencyID uint   `gorm:"uniqueIndex:idx_agency_member;not null"`
+	UserID   uint   `gorm:"uniqueIndex:idx_agency_member;index;not null"`
+	User     User   `gorm:"foreignKey:UserID"`
+	Role     string `gorm:"default:'agent'"` // admin, agent
+}
+
+// AgencyRepresentation puts a talent profile on an agency's roster. Agents request it and it
+// only takes effect once the talent consents.
+type AgencyRepresentation struct {
+	gorm.Model
+	AgencyID          uint          `gorm:"index;not null"`
+	Agency            Agency        `gorm:"foreignKey:AgencyID"`
+	TalentProfileID   uint          `gorm:"index;not null"`
+	TalentProfile     TalentProfile `gorm:"foreignKey:TalentProfileID"`
+	Status            string        `gorm:"index;default:'pending'"` // pending, active, declined, ended
+	RequestedByUserID uint          `gorm:"not null"`
+	ConsentedAt       *time.Time
+	EndedAt           *time.Time
+	EndedByUserID     *uint
 }
 
 // --- Social/Pulse Models ---
 
-// Pulse represents a social media post.
+// Pulse represents a social media post. Reposts share another pulse as-is and have no content
+// of their own; quotes share it with added content.
 type Pulse struct {
 	gorm.Model
-	UserID    uint   `gorm:"not null"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_pulse_repost,where:kind = 'repost' AND deleted_at IS NULL"`
 	User      User   `gorm:"foreignKey:UserID"`
 	Content   string `gorm:"not null"`
 	MediaURL  string
 	MediaType string // "image", "video"
+	Kind            string `gorm:"default:'post'"` // post, repost, quote
+	OriginalPulseID *uint  `gorm:"index;uniqueIndex:idx_pulse_repost,where:kind = 'repost' AND deleted_at IS NULL"`
+	OriginalPulse   *Pulse `gorm:"foreignKey:OriginalPulseID"`
+	// OriginalUnavailable is set on quotes whose original has been deleted
+	OriginalUnavailable bool `gorm:"-"`
+	RepostCount         int64 `gorm:"default:0"`
+	QuoteCount          int64 `gorm:"default:0"`
+	EditedAt            *time.Time
+	HiddenAt            *time.Time `gorm:"index"` // Set when a moderator hides the pulse
 	Likes     []Like `gorm:"polymorphic:Owner;"`
 	Comments  []Comment `gorm:"polymorphic:Owner;"`
+	Hashtags   []PulseHashtag   `gorm:"foreignKey:PulseID"`
+	Mentions   []PulseMention   `gorm:"foreignKey:PulseID"`
+	References []PulseReference `gorm:"foreignKey:PulseID"`
+	Poll       *Poll            `gorm:"foreignKey:PulseID"`
+	Links      []PulseLink      `gorm:"foreignKey:PulseID"`
+	Bookmarked bool             `gorm:"-"` // Set when the signed-in viewer has bookmarked the pulse
 }
 
-// Comment represents a comment on a polymorphic owner (Pulse, Movie, etc.).
+// Poll is a question asked in a pulse. Votes are tallied on the poll and its options as they
+// come in.
+type Poll struct {
+	gorm.Model
+	PulseID        uint      `gorm:"uniqueIndex;not null"`
+	ClosesAt       time.Time `gorm:"not null"`
+	MultipleChoice bool
+	HideResults    bool         // Withhold the tallies until the poll closes
+	VoterCount     int          `gorm:"default:0"`
+	Options        []PollOption `gorm:"foreignKey:PollID"`
+	// Set per viewer when the poll is shown
+	Closed        bool   `gorm:"-"`
+	ResultsHidden bool   `gorm:"-"`
+	MyVotes       []uint `gorm:"-"` // The options the viewer chose
+}
+
+// PollOption is one of a poll's answers, listed in Position order.
+type PollOption struct {
+	gorm.Model
+	PollID    uint    `gorm:"index;not null"`
+	Position  int     `gorm:"not null"`
+	Text      string  `gorm:"not null"`
+	VoteCount int     `gorm:"default:0"`
+	Percent   float64 `gorm:"-"` // Share of the poll's voters, set when the poll is shown
+}
+
+// PollVote is a user's choice of one option. A multiple-choice ballot is several votes.
+type PollVote struct {
+	ID        uint `gorm:"primarykey"`
+	PollID    uint `gorm:"not null;uniqueIndex:idx_poll_vote;index:idx_poll_voter"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_poll_vote;index:idx_poll_voter"`
+	OptionID  uint `gorm:"not null;uniqueIndex:idx_poll_vote"`
+	CreatedAt time.Time
+}
+
+// PulseEdit keeps a pulse's content as it was before an edit.
+type PulseEdit struct {
+	ID        uint `gorm:"primarykey"`
+	PulseID   uint `gorm:"not null;index"`
+	Content   string
+	MediaURL  string
+	MediaType string
+	CreatedAt time.Time // When the edit replaced this version
+}
+
+// Hashtag is a #tag used in pulses, stored lowercased without the #.
+type Hashtag struct {
+	gorm.Model
+	Name    string `gorm:"uniqueIndex;not null"`
+	Display string // As first written, e.g. "Oppenheimer"
+}
+
+// PulseHashtag records a hashtag used in a pulse. CreatedAt is when the pulse was posted, so
+// edits don't make old pulses count towards trending.
+type PulseHashtag struct {
+	ID        uint      `gorm:"primarykey"`
+	PulseID   uint      `gorm:"not null;uniqueIndex:idx_pulse_hashtag"`
+	HashtagID uint      `gorm:"not null;uniqueIndex:idx_pulse_hashtag;index:idx_hashtag_created,priority:1"`
+	Hashtag   Hashtag   `gorm:"foreignKey:HashtagID"`
+	CreatedAt time.Time `gorm:"index:idx_hashtag_created,priority:2"`
+}
+
+// PulseMention is a user @mentioned in a pulse.
+type PulseMention struct {
+	ID        uint `gorm:"primarykey"`
+	PulseID   uint `gorm:"not null;uniqueIndex:idx_pulse_mention"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_pulse_mention;index"`
+	User      User `gorm:"foreignKey:UserID"`
+	CreatedAt time.Time
+}
+
+// PulseReference links a pulse to a movie or cricket match one of its hashtags refers to.
+type PulseReference struct {
+	ID        uint   `gorm:"primarykey"`
+	PulseID   uint   `gorm:"not null;index"`
+	RefType   string `gorm:"not null;index:idx_pulse_ref"` // movies, cricket_matches
+	RefID     uint   `gorm:"not null;index:idx_pulse_ref"`
+	Hashtag   string // The tag that made the link, lowercased
+	Title     string // Movie title or "Team1 vs Team2", so clients can render the link
+	CreatedAt time.Time
+}
+
+// PulseLink is a link in a pulse's content, shown as a preview card. Links to the site's own
+// movies, talent profiles and casting calls get a native card filled in when the pulse is
+// saved; other links share a LinkPreview fetched in the background.
+type PulseLink struct {
+	ID            uint   `gorm:"primarykey"`
+	PulseID       uint   `gorm:"not null;index"`
+	Position      int    `gorm:"not null"`
+	URL           string `gorm:"not null"`
+	TargetType    string // movies, talent_profiles, casting_calls; empty for external links
+	TargetID      *uint
+	Title         string `gorm:"-"` // The native card, for internal links the viewer can see
+	Description   string `gorm:"-"`
+	ImageURL      string `gorm:"-"`
+	LinkPreviewID *uint        `gorm:"index"`
+	LinkPreview   *LinkPreview `gorm:"foreignKey:LinkPreviewID"`
+	CreatedAt     time.Time
+}
+
+// LinkPreview is the OpenGraph and oEmbed metadata of an external link, cached by URL for every
+// pulse that links to it and fetched again once it is stale.
+type LinkPreview struct {
+	gorm.Model
+	URL           string    `gorm:"uniqueIndex;not null"`
+	Status        string    `gorm:"not null;index:idx_link_preview_due"` // pending, ready, failed
+	NextAttemptAt time.Time `gorm:"index:idx_link_preview_due"`
+	Attempts      int
+	LastError     string
+	FetchedAt     *time.Time
+	Title         string
+	Description   string
+	ImageURL      string
+	SiteName      string
+	EmbedType     string // oEmbed type: photo, video, rich, link
+	EmbedURL      string // Player from an allow-listed oEmbed provider; clients load it in a sandboxed iframe
+}
+
+// Follow is one user following another user or a talent profile. Following a profile brings
+// its owner's pulses into the follower's feed, just like following the owner directly.
+type Follow struct {
+	gorm.Model
+	FollowerID   uint   `gorm:"not null;uniqueIndex:idx_follow_edge"`
+	Follower     User   `gorm:"foreignKey:FollowerID"`
+	FolloweeType string `gorm:"not null;uniqueIndex:idx_follow_edge;index:idx_follow_followee"` // users, talent_profiles
+	FolloweeID   uint   `gorm:"not null;uniqueIndex:idx_follow_edge;index:idx_follow_followee"`
+	AuthorUserID uint   `gorm:"index;not null"` // The user whose pulses this follow brings into the feed
+}
+
+// Block hides two users from each other. Unblocking deletes the row so the edge can be
+// recreated.
+type Block struct {
+	gorm.Model
+	BlockerID uint `gorm:"not null;uniqueIndex:idx_block_edge"`
+	BlockedID uint `gorm:"not null;uniqueIndex:idx_block_edge;index"`
+	Blocked   User `gorm:"foreignKey:BlockedID"`
+}
+
+// Mute keeps a user's pulses out of the muter's feeds. Unmuting deletes the row.
+type Mute struct {
+	gorm.Model
+	MuterID uint `gorm:"not null;uniqueIndex:idx_mute_edge"`
+	MutedID uint `gorm:"not null;uniqueIndex:idx_mute_edge"`
+	Muted   User `gorm:"foreignKey:MutedID"`
+}
+
+// Bookmark is a pulse a user saved privately for later, optionally in one of their folders.
+// Removing a bookmark deletes the row.
+type Bookmark struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;uniqueIndex:idx_bookmark"`
+	PulseID  uint   `gorm:"not null;uniqueIndex:idx_bookmark;index"`
+	FolderID *uint  `gorm:"index"` // Unfiled when nil
+	Pulse    *Pulse `gorm:"foreignKey:PulseID"`
+}
+
+// BookmarkFolder is a private collection a user files bookmarks in. Deleting a folder leaves
+// its bookmarks unfiled.
+type BookmarkFolder struct {
+	gorm.Model
+	UserID        uint   `gorm:"not null;uniqueIndex:idx_bookmark_folder_name"`
+	Name          string `gorm:"not null;uniqueIndex:idx_bookmark_folder_name"`
+	BookmarkCount int64  `gorm:"-"`
+}
+
+// FeedEntry is a pulse written into a follower's home feed when it was posted (fan-out on write).
+type FeedEntry struct {
+	ID           uint `gorm:"primarykey"`
+	UserID       uint `gorm:"not null;uniqueIndex:idx_feed_entry"` // Whose feed the entry is in
+	PulseID      uint `gorm:"not null;uniqueIndex:idx_feed_entry"`
+	AuthorUserID uint `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+// Comment represents a comment on a polymorphic owner (Pulse, Movie, etc.). Replies point at
+// their parent comment. Deleted comments that have replies are kept as placeholders so the
+// thread stays intact.
 type Comment struct {
 	gorm.Model
-	UserID    uint   `gorm:"not null"`
-	User      User   `gorm:"foreignKey:UserID"`
-	Content   string `gorm:"not null"`
-	OwnerID   uint   `gorm:"not null"`
-	OwnerType string `gorm:"not null"` // e.g., "pulses", "movies"
+	UserID          uint   `gorm:"not null"`
+	User            User   `gorm:"foreignKey:UserID"`
+	Content         string `gorm:"not null"`
+	OwnerID         uint   `gorm:"not null;index:idx_comment_owner"`
+	OwnerType       string `gorm:"not null;index:idx_comment_owner"` // e.g., "pulses", "movies"
+	ParentID        *uint  `gorm:"index"`
+	Depth           int    `gorm:"default:0"` // 0 for top-level comments
+	ReplyCount      int64  `gorm:"default:0"` // Replies that haven't been deleted
+	LikeCount       int64  `gorm:"default:0"`
+	EditedAt        *time.Time
+	DeletedByUserID *uint // The author, or the owner of what was commented on
+	HiddenAt        *time.Time `gorm:"index"` // Set when a moderator hides the comment
 }
 
-// Like represents a like on a polymorphic owner.
+// Like represents a reaction on a polymorphic owner. Each user has at most one reaction per
+// item; reacting again changes its Kind.
 type Like struct {
 	gorm.Model
-	UserID    uint `gorm:"not null"`
-	User      User `gorm:"foreignKey:UserID"`
-	OwnerID   uint `gorm:"not null"`
-	OwnerType string `gorm:"not null"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_like_user_owner,where:deleted_at IS NULL"`
+	User      User   `gorm:"foreignKey:UserID"`
+	OwnerID   uint   `gorm:"not null;uniqueIndex:idx_like_user_owner,where:deleted_at IS NULL;index:idx_like_owner"`
+	OwnerType string `gorm:"not null;uniqueIndex:idx_like_user_owner,where:deleted_at IS NULL;index:idx_like_owner"`
+	Kind      string `gorm:"not null;default:'like'"` // like, love, laugh, wow, sad, angry
+}
+
+
+// --- Messaging Models ---
+
+// Conversation is a private message thread, optionally about a specific application.
+type Conversation struct {
+	gorm.Model
+	ApplicationID *uint        `gorm:"index"`
+	Application   *Application `gorm:"foreignKey:ApplicationID"`
+	LastMessageAt *time.Time   `gorm:"index"`
+	Participants  []ConversationParticipant `gorm:"foreignKey:ConversationID"`
+}
+
+// ConversationParticipant links a user to a conversation and tracks what they have read.
+type ConversationParticipant struct {
+	gorm.Model
+	ConversationID    uint `gorm:"uniqueIndex:idx_conversation_participant;not null"`
+	UserID            uint `gorm:"uniqueIndex:idx_conversation_participant;index;not null"`
+	User              User `gorm:"foreignKey:UserID"`
+	LastReadMessageID uint // Read receipt: the newest message this participant has seen
+	LastReadAt        *time.Time
+}
+
+// Message is a single message in a conversation.
+type Message struct {
+	gorm.Model
+	ConversationID uint   `gorm:"index;not null"`
+	SenderUserID   uint   `gorm:"not null"`
+	Sender         User   `gorm:"foreignKey:SenderUserID"`
+	Content        string `gorm:"not null"`
+	Attachments    []MessageAttachment `gorm:"foreignKey:MessageID"`
+}
+
+// MessageAttachment is a file shared in a message, e.g. a script or audition sides.
+type MessageAttachment struct {
+	gorm.Model
+	MessageID uint   `gorm:"index;not null"`
+	URL       string `gorm:"not null"`
+	FileName  string
+	MediaType string // "image", "video", "audio", "document"
+}
+
+
+// --- Notification Models ---
+
+// Notification is an in-app notification for a user, e.g. a casting call they applied to was closed.
+type Notification struct {
+	gorm.Model
+	UserID uint   `gorm:"index;not null"`
+	Type   string `gorm:"not null"` // e.g., "casting_call_updated", "casting_call_closed"
+	Title  string `gorm:"not null"`
+	Body   string
+	Link   string // Frontend path the notification points to
+	ReadAt *time.Time
+}
+
+// NotificationPreference is where a user wants to receive one type of notification. Types
+// without a row use the type's default channels.
+type NotificationPreference struct {
+	gorm.Model
+	UserID uint   `gorm:"not null;uniqueIndex:idx_notification_preference"`
+	Type   string `gorm:"not null;uniqueIndex:idx_notification_preference"`
+	InApp  bool
+	Email  bool
+	Push   bool
+}
+
+// NotificationDelivery is a notification queued for email or web push. The delivery job sends
+// due rows and retries failures with backoff.
+type NotificationDelivery struct {
+	gorm.Model
+	UserID        uint      `gorm:"index;not null"`
+	User          User      `gorm:"foreignKey:UserID"`
+	Channel       string    `gorm:"not null"` // email, push
+	Type          string    `gorm:"not null"`
+	Title         string    `gorm:"not null"`
+	Body          string
+	Link          string
+	Status        string    `gorm:"not null;index:idx_notification_delivery_due"` // pending, sent, skipped, failed
+	NextAttemptAt time.Time `gorm:"index:idx_notification_delivery_due"`
+	Attempts      int
+	LastError     string
+	SentAt        *time.Time
+}
+
+// PushSubscription is a browser a user has allowed to receive web push notifications.
+type PushSubscription struct {
+	gorm.Model
+	UserID    uint   `gorm:"index;not null"`
+	Endpoint  string `gorm:"uniqueIndex;not null"` // The push service URL for the browser
+	P256dh    string `gorm:"not null"`             // The browser's public key
+	Auth      string `gorm:"not null"`             // The browser's authentication secret
+	UserAgent string
+}
+
+
+// --- Saved Search Models ---
+
+// SavedSearch is a user's saved casting call filter. New casting calls that match it raise
+// an in-app notification and, optionally, appear in an email digest.
+type SavedSearch struct {
+	gorm.Model
+	UserID           uint   `gorm:"index;not null"`
+	User             User   `gorm:"foreignKey:UserID"`
+	Name             string `gorm:"not null"`
+	ProjectType      string // e.g., "Feature Film"; exact match, case-insensitive
+	Keywords         string // comma-separated, matched against the title, description and roles
+	Location         string // matched against role locations
+	RequiredSkills   string // comma-separated; a role requiring any of them matches
+	EmailDigest      string `gorm:"default:'none'"` // none, daily, weekly
+	UnsubscribeToken string `gorm:"uniqueIndex;not null"`
+	LastDigestAt     *time.Time
 }
 
+// SavedSearchMatch records a casting call matching a saved search, so each match is only
+// notified and emailed once.
+type SavedSearchMatch struct {
+	gorm.Model
+	SavedSearchID uint        `gorm:"uniqueIndex:idx_saved_search_match;not null"`
+	CastingCallID uint        `gorm:"uniqueIndex:idx_saved_search_match;not null"`
+	CastingCall   CastingCall `gorm:"foreignKey:CastingCallID"`
+	EmailedAt     *time.Time  `gorm:"index"`
+}
+
+// JobCheckpoint records how far a background job has got, so each run only picks up what
+// changed since the last one.
+type JobCheckpoint struct {
+	Name      string    `gorm:"primarykey"`
+	At        time.Time `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+// --- Analytics Models ---
+
+// ViewEvent is one viewer looking at a talent profile or casting call on a given day. Repeat
+// views by the same viewer on the same day are not recorded again.
+type ViewEvent struct {
+	gorm.Model
+	SubjectType  string    `gorm:"uniqueIndex:idx_view_event;not null"` // talent_profile, casting_call
+	SubjectID    uint      `gorm:"uniqueIndex:idx_view_event;not null"`
+	ViewerKey    string    `gorm:"uniqueIndex:idx_view_event;not null"` // "user:<id>", or a hash of IP and user agent for anonymous viewers
+	Day          time.Time `gorm:"uniqueIndex:idx_view_event;type:date;index;not null"`
+	ViewerUserID *uint
+	ViewerKind   string // anonymous, member, recruiter
+	Referrer     string `gorm:"index"` // The search that led to the view, if any
+}
+
+// DailyViewStat is the per-day rollup of view events for one subject, kept up to date by a
+// background job so analytics reads don't scan raw events.
+type DailyViewStat struct {
+	gorm.Model
+	SubjectType    string    `gorm:"uniqueIndex:idx_daily_view_stat;not null"`
+	SubjectID      uint      `gorm:"uniqueIndex:idx_daily_view_stat;not null"`
+	Day            time.Time `gorm:"uniqueIndex:idx_daily_view_stat;type:date;not null"`
+	Views          int64
+	AnonymousViews int64
+	MemberViews    int64
+	RecruiterViews int64
+	SearchViews    int64
+	Applications   int64 // Casting calls only
+}
+
+// DailyReferrerStat is the per-day rollup of the views a search led to for one subject.
+type DailyReferrerStat struct {
+	gorm.Model
+	SubjectType string    `gorm:"uniqueIndex:idx_daily_referrer_stat;not null"`
+	SubjectID   uint      `gorm:"uniqueIndex:idx_daily_referrer_stat;not null"`
+	Day         time.Time `gorm:"uniqueIndex:idx_daily_referrer_stat;type:date;not null"`
+	Referrer    string    `gorm:"uniqueIndex:idx_daily_referrer_stat;not null"`
+	Views       int64
+}
+
+// SubjectViewer is the rollup of one viewer's views of a subject: the first and last day
+// they viewed it. Unique viewers over a range are counted from these rather than from the
+// view events.
+type SubjectViewer struct {
+	gorm.Model
+	SubjectType string    `gorm:"uniqueIndex:idx_subject_viewer;not null"`
+	SubjectID   uint      `gorm:"uniqueIndex:idx_subject_viewer;not null"`
+	ViewerKey   string    `gorm:"uniqueIndex:idx_subject_viewer;not null"`
+	FirstDay    time.Time `gorm:"type:date;not null"`
+	LastDay     time.Time `gorm:"type:date;not null"`
+}
 
 // --- Entertainment Models ---
 
@@ -146,6 +784,20 @@ type Movie struct {
 	Likes       []Like    `gorm:"polymorphic:Owner;"`
 }
 
+// Review is a user's review of a movie. Each user can review a movie once.
+type Review struct {
+	gorm.Model
+	MovieID  uint   `gorm:"not null;uniqueIndex:idx_review_movie_user,where:deleted_at IS NULL"`
+	Movie    Movie  `gorm:"foreignKey:MovieID"`
+	UserID   uint   `gorm:"not null;uniqueIndex:idx_review_movie_user,where:deleted_at IS NULL"`
+	User     User   `gorm:"foreignKey:UserID"`
+	Rating   int    `gorm:"not null"` // 1-10
+	Title    string
+	Content  string `gorm:"not null"`
+	Comments []Comment `gorm:"polymorphic:Owner;"`
+	Likes    []Like    `gorm:"polymorphic:Owner;"`
+}
+
 // Award represents an award ceremony and its details.
 type Award struct {
 	gorm.Model
@@ -157,10 +809,76 @@ type Award struct {
 // CricketMatch represents a cricket match.
 type CricketMatch struct {
 	gorm.Model
-	Team1  string
-	Team2  string
-	Date   time.Time
-	Venue  string
-	Status string // e.g., "Upcoming", "Live", "Completed"
-	Score  string
+	Team1           string
+	Team2           string
+	Date            time.Time
+	Venue           string
+	Status          string // e.g., "Upcoming", "Live", "Completed"
+	Score           string
+	StartNotifiedAt *time.Time // When users were told the match started
+}
+
+// --- Moderation Models ---
+
+// Report is a user's report of content or an account they think breaks the rules. Each user
+// can have one unresolved report per subject.
+type Report struct {
+	gorm.Model
+	ReporterID    uint       `gorm:"not null;uniqueIndex:idx_report_pending,where:resolved_at IS NULL AND deleted_at IS NULL"`
+	Reporter      User       `gorm:"foreignKey:ReporterID"`
+	SubjectType   string     `gorm:"not null;index:idx_report_subject;uniqueIndex:idx_report_pending,where:resolved_at IS NULL AND deleted_at IS NULL"` // pulses, comments, talent_profiles, casting_calls, users
+	SubjectID     uint       `gorm:"not null;index:idx_report_subject;uniqueIndex:idx_report_pending,where:resolved_at IS NULL AND deleted_at IS NULL"`
+	SubjectUserID uint       `gorm:"index"`                  // Who posted the content, or the reported account
+	Reason        string     `gorm:"not null"`               // Reason code, e.g. spam, harassment
+	Details       string
+	Status        string     `gorm:"index;default:'open'"`   // open, in_review, actioned, dismissed
+	AssignedToID  *uint                                       // Moderator reviewing it
+	ResolvedByID  *uint
+	ResolvedAt    *time.Time
+	ActionID      *uint                                       // The moderation action that resolved it
+}
+
+// ModerationAction is a moderator's decision. Together the actions make up the transparency
+// log, so decisions are never edited; undoing one records another and marks it reversed.
+type ModerationAction struct {
+	gorm.Model
+	ModeratorID  uint       `gorm:"not null"`
+	Moderator    User       `gorm:"foreignKey:ModeratorID"`
+	Action       string     `gorm:"not null;index"` // hide, restore, warn, suspend, ban, reinstate, dismiss, appeal_granted, appeal_denied
+	SubjectType  string     `gorm:"index:idx_moderation_subject"` // pulses, comments, talent_profiles, casting_calls, users
+	SubjectID    uint       `gorm:"index:idx_moderation_subject"`
+	TargetUserID uint       `gorm:"index"` // The user the decision affects
+	Reason       string     // Reason code
+	Note         string     // Explanation shown to the affected user
+	ExpiresAt    *time.Time // When a suspension ends
+	AppealID     *uint      // Set on appeal decisions
+	ReversedAt   *time.Time // Set when a later action or granted appeal undid it
+}
+
+// Appeal is a user asking for a moderation action against them to be reconsidered.
+type Appeal struct {
+	gorm.Model
+	ActionID   uint             `gorm:"not null;uniqueIndex"` // One appeal per action
+	Action     ModerationAction `gorm:"foreignKey:ActionID"`
+	UserID     uint             `gorm:"not null;index"`
+	User       User             `gorm:"foreignKey:UserID"`
+	Statement  string           `gorm:"not null"`
+	Status     string           `gorm:"index;default:'pending'"` // pending, granted, denied
+	ReviewerID *uint
+	Response   string // The moderator's reply
+	DecidedAt  *time.Time
+}
+
+// ScreeningResult records why automated screening held or rejected user content. Held content
+// is stored hidden until a moderator restores or removes it.
+type ScreeningResult struct {
+	gorm.Model
+	UserID       uint   `gorm:"not null;index"`
+	ContentType  string `gorm:"not null;index:idx_screening_content"` // pulses, comments, casting_calls, talent_profiles
+	ContentID    uint   `gorm:"index:idx_screening_content"`          // 0 for rejected content, which isn't stored
+	Verdict      string `gorm:"not null;index"`                       // hold, reject
+	Reasons      string // The rules tripped and what matched, one per line
+	Excerpt      string // The start of the text screened
+	ReviewedByID *uint
+	ReviewedAt   *time.Time
 }
@@ -0,0 +1,187 @@
+package moderation
+
+import (
+	"siddu-verse-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Subject types that can be reported. Content types are also the tables their rows live in.
+const (
+	SubjectPulse         = "pulses"
+	SubjectComment       = "comments"
+	SubjectTalentProfile = "talent_profiles"
+	SubjectCastingCall   = "casting_calls"
+	SubjectAgency        = "agencies"
+	SubjectUser          = "users"
+)
+
+// IsContent reports whether the subject type is content that can be hidden, as opposed to an
+// account.
+func IsContent(subjectType string) bool {
+	switch subjectType {
+	case SubjectPulse, SubjectComment, SubjectTalentProfile, SubjectCastingCall, SubjectAgency:
+		return true
+	}
+	return false
+}
+
+// Reasons are the reason codes reports and moderation actions are filed under, with the
+// description shown to users.
+var Reasons = map[string]string{
+	"spam":                  "Spam or scams",
+	"harassment":            "Harassment or bullying",
+	"hate_speech":           "Hate speech",
+	"violence":              "Violence or threats",
+	"sexual_content":        "Sexual content",
+	"impersonation":         "Impersonation",
+	"misinformation":        "False information",
+	"intellectual_property": "Copyright or trademark infringement",
+	"other":                 "Something else",
+}
+
+// IsReason reports whether reason is a supported reason code.
+func IsReason(reason string) bool {
+	_, ok := Reasons[reason]
+	return ok
+}
+
+// Report statuses.
+const (
+	ReportOpen      = "open"
+	ReportInReview  = "in_review"
+	ReportActioned  = "actioned"
+	ReportDismissed = "dismissed"
+)
+
+// CanTransition reports whether a report can move from one status to another. Moderators
+// claim open reports for review and can hand them back; actioned and dismissed are final.
+func CanTransition(from, to string) bool {
+	switch from {
+	case ReportOpen:
+		return to == ReportInReview || to == ReportActioned || to == ReportDismissed
+	case ReportInReview:
+		return to == ReportOpen || to == ReportActioned || to == ReportDismissed
+	}
+	return false
+}
+
+// Moderation actions. Hide, warn, suspend and ban are enforcement actions the affected user
+// can appeal; the others record decisions.
+const (
+	ActionHide          = "hide"
+	ActionRestore       = "restore"
+	ActionWarn          = "warn"
+	ActionSuspend       = "suspend"
+	ActionBan           = "ban"
+	ActionReinstate     = "reinstate"
+	ActionDismiss       = "dismiss"
+	ActionAppealGranted = "appeal_granted"
+	ActionAppealDenied  = "appeal_denied"
+)
+
+// Appealable reports whether the action can be appealed by the user it was taken against.
+func Appealable(action string) bool {
+	switch action {
+	case ActionHide, ActionWarn, ActionSuspend, ActionBan:
+		return true
+	}
+	return false
+}
+
+// ReportOutcome is the status an action leaves the subject's pending reports in. Actions that
+// undo earlier ones leave them as they are.
+func ReportOutcome(action string) (string, bool) {
+	switch action {
+	case ActionHide, ActionWarn, ActionSuspend, ActionBan:
+		return ReportActioned, true
+	case ActionDismiss:
+		return ReportDismissed, true
+	}
+	return "", false
+}
+
+const (
+	// MaxSuspension is the longest a single suspension can run; longer needs a ban.
+	MaxSuspension = 365 * 24 * time.Hour
+	// AppealWindow is how long after an action the user can appeal it.
+	AppealWindow = 30 * 24 * time.Hour
+)
+
+// Account statuses.
+const (
+	AccountActive    = "active"
+	AccountSuspended = "suspended"
+	AccountBanned    = "banned"
+)
+
+// AccountStatus is the user's standing at now. Suspensions lapse on their own once they run
+// out, without the stored status being updated.
+func AccountStatus(user models.User, now time.Time) string {
+	switch user.Status {
+	case AccountBanned:
+		return AccountBanned
+	case AccountSuspended:
+		if user.SuspendedUntil == nil || user.SuspendedUntil.After(now) {
+			return AccountSuspended
+		}
+	}
+	return AccountActive
+}
+
+// Standing works out a user's account status from the suspensions and bans taken against
+// them, skipping those that have been reversed. It is used when one of them is reversed, so
+// the others stay in force.
+func Standing(actions []models.ModerationAction, now time.Time) (string, *time.Time) {
+	status := AccountActive
+	var until *time.Time
+	for _, action := range actions {
+		if action.ReversedAt != nil {
+			continue
+		}
+		switch action.Action {
+		case ActionBan:
+			return AccountBanned, nil
+		case ActionSuspend:
+			if action.ExpiresAt != nil && action.ExpiresAt.After(now) && (until == nil || action.ExpiresAt.After(*until)) {
+				status, until = AccountSuspended, action.ExpiresAt
+			}
+		}
+	}
+	return status, until
+}
+
+// BannedUsers selects the IDs of banned accounts, for excluding their content by author.
+func BannedUsers(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.User{}).Select("id").Where("status = ?", AccountBanned)
+}
+
+// NotBanned scopes a listing to rows whose author, in authorColumn of table, isn't banned.
+func NotBanned(table, authorColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+"."+authorColumn+" NOT IN (?)", BannedUsers(db))
+	}
+}
+
+// Visible scopes a listing to content that hasn't been hidden by a moderator and whose author
+// isn't banned.
+func Visible(table, authorColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return NotBanned(table, authorColumn)(db.Where(table + ".hidden_at IS NULL"))
+	}
+}
+
+// Visibility scopes for each kind of content that can be hidden.
+var (
+	VisiblePulses         = Visible("pulses", "user_id")
+	VisibleComments       = Visible("comments", "user_id")
+	VisibleTalentProfiles = Visible("talent_profiles", "user_id")
+	VisibleCastingCalls   = Visible("casting_calls", "posted_by_user_id")
+	VisibleAgencies       = Visible("agencies", "created_by_user_id")
+)
+
+// HiddenPulses selects the IDs of pulses a moderator has hidden.
+func HiddenPulses(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.Pulse{}).Select("id").Where("hidden_at IS NOT NULL")
+}
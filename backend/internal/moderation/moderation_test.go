@@ -0,0 +1,88 @@
+package moderation
+
+import (
+	"siddu-verse-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	// Test case: Open reports can be claimed, actioned or dismissed
+	assert.True(t, CanTransition(ReportOpen, ReportInReview))
+	assert.True(t, CanTransition(ReportOpen, ReportDismissed))
+
+	// Test case: Reports in review can be handed back
+	assert.True(t, CanTransition(ReportInReview, ReportOpen))
+	assert.True(t, CanTransition(ReportInReview, ReportActioned))
+
+	// Test case: Resolved reports are final
+	assert.False(t, CanTransition(ReportActioned, ReportOpen))
+	assert.False(t, CanTransition(ReportDismissed, ReportInReview))
+	assert.False(t, CanTransition(ReportOpen, "closed"))
+}
+
+func TestAccountStatus(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	// Test case: Bans don't lapse
+	assert.Equal(t, AccountBanned, AccountStatus(models.User{Status: AccountBanned}, now))
+
+	// Test case: Suspensions last until they run out
+	assert.Equal(t, AccountSuspended, AccountStatus(models.User{Status: AccountSuspended, SuspendedUntil: &later}, now))
+	assert.Equal(t, AccountActive, AccountStatus(models.User{Status: AccountSuspended, SuspendedUntil: &earlier}, now))
+
+	// Test case: Accounts created before statuses existed are active
+	assert.Equal(t, AccountActive, AccountStatus(models.User{}, now))
+}
+
+func TestStanding(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	week := now.AddDate(0, 0, 7)
+	month := now.AddDate(0, 1, 0)
+	past := now.AddDate(0, 0, -1)
+
+	// Test case: The longest suspension still running applies
+	status, until := Standing([]models.ModerationAction{
+		{Action: ActionSuspend, ExpiresAt: &week},
+		{Action: ActionSuspend, ExpiresAt: &month},
+		{Action: ActionWarn},
+	}, now)
+	assert.Equal(t, AccountSuspended, status)
+	assert.Equal(t, &month, until)
+
+	// Test case: Reversed and lapsed actions are ignored
+	status, until = Standing([]models.ModerationAction{
+		{Action: ActionBan, ReversedAt: &past},
+		{Action: ActionSuspend, ExpiresAt: &past},
+	}, now)
+	assert.Equal(t, AccountActive, status)
+	assert.Nil(t, until)
+
+	// Test case: A ban in force outranks suspensions
+	status, until = Standing([]models.ModerationAction{
+		{Action: ActionSuspend, ExpiresAt: &month},
+		{Action: ActionBan},
+	}, now)
+	assert.Equal(t, AccountBanned, status)
+	assert.Nil(t, until)
+}
+
+func TestReportOutcome(t *testing.T) {
+	// Test case: Enforcement actions resolve reports as actioned, dismissals as dismissed
+	status, ok := ReportOutcome(ActionSuspend)
+	assert.True(t, ok)
+	assert.Equal(t, ReportActioned, status)
+	status, ok = ReportOutcome(ActionDismiss)
+	assert.True(t, ok)
+	assert.Equal(t, ReportDismissed, status)
+
+	// Test case: Reversals leave reports alone
+	_, ok = ReportOutcome(ActionRestore)
+	assert.False(t, ok)
+	assert.False(t, Appealable(ActionRestore))
+	assert.True(t, Appealable(ActionBan))
+}
@@ -0,0 +1,93 @@
+package notifications
+
+import (
+	"siddu-verse-backend/internal/models"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Send records the notification for each of the given users on the channels they chose for its
+// type: in their in-app inbox, and queued for email and web push delivery by the delivery job.
+func Send(db *gorm.DB, userIDs []uint, notificationType, title, body, link string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	channels, err := ChannelsFor(db, userIDs, notificationType)
+	if err != nil {
+		return err
+	}
+
+	var inbox []models.Notification
+	var deliveries []models.NotificationDelivery
+	now := time.Now()
+	for _, userID := range userIDs {
+		userChannels := channels[userID]
+		if userChannels.InApp {
+			inbox = append(inbox, models.Notification{
+				UserID: userID,
+				Type:   notificationType,
+				Title:  title,
+				Body:   body,
+				Link:   link,
+			})
+		}
+		for _, channel := range userChannels.queued() {
+			deliveries = append(deliveries, models.NotificationDelivery{
+				UserID:        userID,
+				Channel:       channel,
+				Type:          notificationType,
+				Title:         title,
+				Body:          body,
+				Link:          link,
+				Status:        DeliveryPending,
+				NextAttemptAt: now,
+			})
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if len(inbox) > 0 {
+			if err := tx.Create(&inbox).Error; err != nil {
+				return err
+			}
+		}
+		if len(deliveries) > 0 {
+			return tx.Create(&deliveries).Error
+		}
+		return nil
+	})
+}
+
+// NotifyCastingCallApplicants sends a notification to every user who applied to the casting call.
+func NotifyCastingCallApplicants(db *gorm.DB, castingCallID uint, notificationType, title, body string) error {
+	var userIDs []uint
+	err := db.Model(&models.Application{}).
+		Joins("JOIN talent_profiles ON talent_profiles.id = applications.talent_profile_id").
+		Where("applications.casting_call_id = ?", castingCallID).
+		Distinct().
+		Pluck("talent_profiles.user_id", &userIDs).Error
+	if err != nil {
+		return err
+	}
+
+	link := "/talent/casting-calls/" + strconv.FormatUint(uint64(castingCallID), 10)
+	return Send(db, userIDs, notificationType, title, body, link)
+}
+
+// NotifyCastingCallTeam sends a notification to the user who posted the casting call and every
+// member of its team.
+func NotifyCastingCallTeam(db *gorm.DB, castingCallID uint, notificationType, title, body, link string) error {
+	var call models.CastingCall
+	if err := db.First(&call, castingCallID).Error; err != nil {
+		return err
+	}
+	var memberIDs []uint
+	if err := db.Model(&models.CastingCallMember{}).Where("casting_call_id = ? AND user_id <> ?", castingCallID, call.PostedByUserID).
+		Distinct().Pluck("user_id", &memberIDs).Error; err != nil {
+		return err
+	}
+	return Send(db, append([]uint{call.PostedByUserID}, memberIDs...), notificationType, title, body, link)
+}
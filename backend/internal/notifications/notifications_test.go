@@ -0,0 +1,38 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaults(t *testing.T) {
+	// Test case: Configurable types use their catalog defaults
+	assert.Equal(t, Channels{InApp: true, Email: true, Push: true}, Defaults(TypeApplicationStatus))
+	assert.Equal(t, Channels{InApp: true}, Defaults(TypeLike))
+
+	// Test case: Feature notifications are configurable rather than inbox-only
+	assert.Equal(t, Channels{InApp: true, Email: true, Push: true}, Defaults(TypeAvailabilityHold))
+	assert.Equal(t, Channels{InApp: true, Email: true}, Defaults(TypeRepresentationRequested))
+
+	// Test case: Other types only go to the inbox
+	assert.Equal(t, Channels{InApp: true}, Defaults("moderation_ban"))
+}
+
+func TestQueuedChannels(t *testing.T) {
+	// Test case: Only email and push go through the delivery queue
+	assert.Equal(t, []string{ChannelEmail, ChannelPush}, Channels{InApp: true, Email: true, Push: true}.queued())
+	assert.Equal(t, []string{ChannelPush}, Channels{Push: true}.queued())
+	assert.Empty(t, Channels{InApp: true}.queued())
+}
+
+func TestRetryDelay(t *testing.T) {
+	// Test case: Doubles from one minute
+	assert.Equal(t, time.Minute, RetryDelay(1))
+	assert.Equal(t, 2*time.Minute, RetryDelay(2))
+	assert.Equal(t, 16*time.Minute, RetryDelay(5))
+
+	// Test case: Capped at six hours
+	assert.Equal(t, 6*time.Hour, RetryDelay(20))
+}
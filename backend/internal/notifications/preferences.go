@@ -0,0 +1,147 @@
+package notifications
+
+import (
+	"siddu-verse-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Delivery channels.
+const (
+	ChannelInApp = "in_app"
+	ChannelEmail = "email"
+	ChannelPush  = "push"
+)
+
+// Channels are where a user receives a type of notification.
+type Channels struct {
+	InApp bool `json:"inApp"`
+	Email bool `json:"email"`
+	Push  bool `json:"push"`
+}
+
+// queued lists the channels delivered through the delivery queue rather than the inbox.
+func (c Channels) queued() []string {
+	var channels []string
+	if c.Email {
+		channels = append(channels, ChannelEmail)
+	}
+	if c.Push {
+		channels = append(channels, ChannelPush)
+	}
+	return channels
+}
+
+// Notification types users can set preferences for.
+const (
+	TypeLike                    = "like"           // Likes and other reactions to the user's content
+	TypeComment                 = "comment"        // Comments on the user's content
+	TypeReply                   = "comment_reply"  // Replies to the user's comments
+	TypeFollow                  = "new_follower"   // Follows of the user or their talent profile
+	TypeMention                 = "mention"        // Mentions in pulses
+	TypeRepost                  = "pulse_reposted" // Reposts of the user's pulses
+	TypeQuote                   = "pulse_quoted"   // Quotes of the user's pulses
+	TypeApplicationStatus       = "application_status"
+	TypeNewApplication          = "new_application" // Applications to casting calls the user recruits for
+	TypeCastingUpdated          = "casting_call_updated"
+	TypeCastingClosed           = "casting_call_closed"
+	TypeCastingReopened         = "casting_call_reopened"
+	TypeCastingExtended         = "casting_call_extended" // Application deadline pushed back
+	TypeSavedSearchMatch        = "saved_search_match"
+	TypeSkillEndorsed           = "skill_endorsed"
+	TypeAvailabilityHold        = "availability_hold"    // Holds placed on the talent's calendar
+	TypeAvailabilityBooking     = "availability_booking" // Holds the recruiter placed being confirmed
+	TypeVerificationDecision    = "verification_decision"
+	TypeRepresentationRequested = "representation_requested"
+	TypeRepresentationAccepted  = "representation_accepted"
+	TypeRepresentationEnded     = "representation_ended"
+	TypeAgencyMemberAdded       = "agency_member_added"
+	TypeAgencyApplied           = "agency_applied" // The talent's agency applying to a casting call for them
+	TypeMatchStarted            = "match_started"  // Cricket matches the user engaged with going live
+)
+
+// TypeInfo describes a notification type and the channels it goes to until the user changes them.
+type TypeInfo struct {
+	Description string
+	Default     Channels
+}
+
+// Types are the notification types users can configure. Other types, such as moderation
+// decisions, always go to the in-app inbox only.
+var Types = map[string]TypeInfo{
+	TypeLike:                    {"Someone likes or reacts to your content", Channels{InApp: true}},
+	TypeComment:                 {"Someone comments on your content", Channels{InApp: true, Push: true}},
+	TypeReply:                   {"Someone replies to your comment", Channels{InApp: true, Push: true}},
+	TypeFollow:                  {"Someone follows you or your talent profile", Channels{InApp: true}},
+	TypeMention:                 {"Someone mentions you in a pulse", Channels{InApp: true, Push: true}},
+	TypeRepost:                  {"Someone reposts your pulse", Channels{InApp: true}},
+	TypeQuote:                   {"Someone quotes your pulse", Channels{InApp: true}},
+	TypeApplicationStatus:       {"The status of your casting call application changes", Channels{InApp: true, Email: true, Push: true}},
+	TypeNewApplication:          {"Someone applies to a casting call you recruit for", Channels{InApp: true, Email: true}},
+	TypeCastingUpdated:          {"A casting call you applied to changes", Channels{InApp: true, Email: true}},
+	TypeCastingClosed:           {"A casting call you applied to closes", Channels{InApp: true}},
+	TypeCastingReopened:         {"A casting call you applied to reopens", Channels{InApp: true, Email: true}},
+	TypeCastingExtended:         {"A casting call you applied to extends its deadline", Channels{InApp: true}},
+	TypeSavedSearchMatch:        {"A new casting call matches one of your saved searches", Channels{InApp: true}},
+	TypeSkillEndorsed:           {"Someone endorses one of your skills", Channels{InApp: true}},
+	TypeAvailabilityHold:        {"A recruiter places a hold on your calendar", Channels{InApp: true, Email: true, Push: true}},
+	TypeAvailabilityBooking:     {"A talent confirms a hold you placed", Channels{InApp: true, Email: true, Push: true}},
+	TypeVerificationDecision:    {"Your profile verification is decided or revoked", Channels{InApp: true, Email: true}},
+	TypeRepresentationRequested: {"An agency asks to represent you", Channels{InApp: true, Email: true}},
+	TypeRepresentationAccepted:  {"A talent accepts your agency's representation request", Channels{InApp: true, Email: true}},
+	TypeRepresentationEnded:     {"An agency stops representing you", Channels{InApp: true, Email: true}},
+	TypeAgencyMemberAdded:       {"You are added to an agency", Channels{InApp: true}},
+	TypeAgencyApplied:           {"Your agency applies to a casting call for you", Channels{InApp: true, Push: true}},
+	TypeMatchStarted:            {"A cricket match you follow the conversation on starts", Channels{InApp: true, Push: true}},
+}
+
+// Defaults returns the channels a type goes to for users who haven't set a preference.
+func Defaults(notificationType string) Channels {
+	if info, ok := Types[notificationType]; ok {
+		return info.Default
+	}
+	return Channels{InApp: true}
+}
+
+// ChannelsFor returns the channels each user receives the notification type on, from their
+// preferences or the type's defaults.
+func ChannelsFor(db *gorm.DB, userIDs []uint, notificationType string) (map[uint]Channels, error) {
+	channels := make(map[uint]Channels, len(userIDs))
+	for _, userID := range userIDs {
+		channels[userID] = Defaults(notificationType)
+	}
+	if _, ok := Types[notificationType]; !ok {
+		return channels, nil
+	}
+
+	var preferences []models.NotificationPreference
+	if err := db.Where("user_id IN ? AND type = ?", userIDs, notificationType).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+	for _, preference := range preferences {
+		channels[preference.UserID] = Channels{InApp: preference.InApp, Email: preference.Email, Push: preference.Push}
+	}
+	return channels, nil
+}
+
+// Delivery statuses.
+const (
+	DeliveryPending = "pending"
+	DeliverySent    = "sent"
+	DeliverySkipped = "skipped" // Nowhere to deliver it, e.g. push with no subscribed browsers
+	DeliveryFailed  = "failed"  // Gave up after MaxDeliveryAttempts
+)
+
+// MaxDeliveryAttempts is how many times an email or push is tried before it is marked failed.
+const MaxDeliveryAttempts = 6
+
+// RetryDelay is how long to wait before retrying a delivery that has failed attempts times:
+// one minute, doubling each time, up to six hours.
+func RetryDelay(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempts && delay < 6*time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, 6*time.Hour)
+}
@@ -0,0 +1,107 @@
+package polls
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Limits on new polls.
+const (
+	MinOptions      = 2
+	MaxOptions      = 6
+	MaxOptionLength = 80
+	MinDuration     = 5 * time.Minute
+	MaxDuration     = 7 * 24 * time.Hour
+)
+
+var (
+	ErrOptionCount     = fmt.Errorf("a poll needs %d to %d options", MinOptions, MaxOptions)
+	ErrEmptyOption     = errors.New("poll options cannot be empty")
+	ErrOptionTooLong   = fmt.Errorf("poll options can be at most %d characters", MaxOptionLength)
+	ErrDuplicateOption = errors.New("poll options must be different from each other")
+	ErrClosingTime     = errors.New("a poll must close between 5 minutes and 7 days from now")
+
+	ErrNoChoice      = errors.New("choose at least one option")
+	ErrSingleChoice  = errors.New("this poll only allows one choice")
+	ErrUnknownOption = errors.New("that option is not part of this poll")
+)
+
+// Validate checks a new poll's options and closing time, and returns the options trimmed.
+func Validate(options []string, closesAt, now time.Time) ([]string, error) {
+	if len(options) < MinOptions || len(options) > MaxOptions {
+		return nil, ErrOptionCount
+	}
+	trimmed := make([]string, 0, len(options))
+	seen := map[string]bool{}
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return nil, ErrEmptyOption
+		}
+		if len([]rune(option)) > MaxOptionLength {
+			return nil, ErrOptionTooLong
+		}
+		key := strings.ToLower(option)
+		if seen[key] {
+			return nil, ErrDuplicateOption
+		}
+		seen[key] = true
+		trimmed = append(trimmed, option)
+	}
+
+	if duration := closesAt.Sub(now); duration < MinDuration || duration > MaxDuration {
+		return nil, ErrClosingTime
+	}
+	return trimmed, nil
+}
+
+// CheckBallot checks the options a voter chose against the poll's options, and returns them
+// without repeats. Single-choice polls take exactly one option.
+func CheckBallot(chosen, options []uint, multipleChoice bool) ([]uint, error) {
+	valid := map[uint]bool{}
+	for _, id := range options {
+		valid[id] = true
+	}
+
+	ballot := make([]uint, 0, len(chosen))
+	seen := map[uint]bool{}
+	for _, id := range chosen {
+		if !valid[id] {
+			return nil, ErrUnknownOption
+		}
+		if !seen[id] {
+			seen[id] = true
+			ballot = append(ballot, id)
+		}
+	}
+	if len(ballot) == 0 {
+		return nil, ErrNoChoice
+	}
+	if len(ballot) > 1 && !multipleChoice {
+		return nil, ErrSingleChoice
+	}
+	return ballot, nil
+}
+
+// IsClosed reports whether voting has ended.
+func IsClosed(closesAt, now time.Time) bool {
+	return !now.Before(closesAt)
+}
+
+// ResultsHidden reports whether the tallies are withheld: only for polls that hide their
+// results, and only until they close.
+func ResultsHidden(hideResults bool, closesAt, now time.Time) bool {
+	return hideResults && !IsClosed(closesAt, now)
+}
+
+// Percent is the share of voters who chose an option, to one decimal place. In
+// multiple-choice polls the shares can add up to more than 100.
+func Percent(votes, voters int) float64 {
+	if voters == 0 {
+		return 0
+	}
+	return math.Round(float64(votes)*1000/float64(voters)) / 10
+}
@@ -0,0 +1,89 @@
+package polls
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.Add(24 * time.Hour)
+
+	// Test case: Options are trimmed
+	options, err := Validate([]string{" India ", "Australia"}, tomorrow, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"India", "Australia"}, options)
+
+	// Test case: Between two and six options
+	_, err = Validate([]string{"India"}, tomorrow, now)
+	assert.ErrorIs(t, err, ErrOptionCount)
+	_, err = Validate([]string{"a", "b", "c", "d", "e", "f", "g"}, tomorrow, now)
+	assert.ErrorIs(t, err, ErrOptionCount)
+
+	// Test case: Options must be non-empty, short and distinct ignoring case
+	_, err = Validate([]string{"India", "  "}, tomorrow, now)
+	assert.ErrorIs(t, err, ErrEmptyOption)
+	_, err = Validate([]string{"India", strings.Repeat("x", MaxOptionLength+1)}, tomorrow, now)
+	assert.ErrorIs(t, err, ErrOptionTooLong)
+	_, err = Validate([]string{"India", "india"}, tomorrow, now)
+	assert.ErrorIs(t, err, ErrDuplicateOption)
+
+	// Test case: Closes between five minutes and seven days out
+	_, err = Validate([]string{"India", "Australia"}, now.Add(time.Minute), now)
+	assert.ErrorIs(t, err, ErrClosingTime)
+	_, err = Validate([]string{"India", "Australia"}, now.Add(8*24*time.Hour), now)
+	assert.ErrorIs(t, err, ErrClosingTime)
+	_, err = Validate([]string{"India", "Australia"}, now.Add(-time.Hour), now)
+	assert.ErrorIs(t, err, ErrClosingTime)
+}
+
+func TestCheckBallot(t *testing.T) {
+	options := []uint{10, 11, 12}
+
+	// Test case: Single choice takes exactly one option
+	ballot, err := CheckBallot([]uint{11}, options, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{11}, ballot)
+	_, err = CheckBallot([]uint{10, 11}, options, false)
+	assert.ErrorIs(t, err, ErrSingleChoice)
+
+	// Test case: Multiple choice drops repeats
+	ballot, err = CheckBallot([]uint{10, 12, 10}, options, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{10, 12}, ballot)
+
+	// Test case: Choosing the same option twice is still one choice
+	ballot, err = CheckBallot([]uint{11, 11}, options, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{11}, ballot)
+
+	// Test case: Options must belong to the poll, and at least one is needed
+	_, err = CheckBallot([]uint{99}, options, true)
+	assert.ErrorIs(t, err, ErrUnknownOption)
+	_, err = CheckBallot(nil, options, true)
+	assert.ErrorIs(t, err, ErrNoChoice)
+}
+
+func TestResultsHidden(t *testing.T) {
+	closesAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Test case: Hidden results are withheld until the poll closes
+	assert.True(t, ResultsHidden(true, closesAt, closesAt.Add(-time.Second)))
+	assert.False(t, ResultsHidden(true, closesAt, closesAt))
+	assert.True(t, IsClosed(closesAt, closesAt))
+
+	// Test case: Other polls always show their results
+	assert.False(t, ResultsHidden(false, closesAt, closesAt.Add(-time.Hour)))
+}
+
+func TestPercent(t *testing.T) {
+	assert.Equal(t, 33.3, Percent(1, 3))
+	assert.Equal(t, 66.7, Percent(2, 3))
+	assert.Equal(t, 100.0, Percent(4, 4))
+
+	// Test case: No voters yet
+	assert.Equal(t, 0.0, Percent(0, 0))
+}
@@ -0,0 +1,89 @@
+package realtime
+
+import "sync"
+
+// Event is pushed to a connected user, e.g. a new message in one of their conversations.
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// Hub fans events out to the connections each user currently has open, and to connections
+// watching a topic such as a poll's results. It is in-process, so with several API instances
+// a connection only receives events published on the one it is connected to.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan Event]struct{}
+	topics      map[string]map[chan Event]struct{}
+}
+
+// NewHub creates an empty hub.
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[uint]map[chan Event]struct{}),
+		topics:      make(map[string]map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a connection for the user. Call the returned function when the connection closes.
+func (h *Hub) Subscribe(userID uint) (<-chan Event, func()) {
+	return subscribe(h, h.subscribers, userID)
+}
+
+// SubscribeTopic registers a connection watching the topic. Call the returned function when
+// the connection closes.
+func (h *Hub) SubscribeTopic(topic string) (<-chan Event, func()) {
+	return subscribe(h, h.topics, topic)
+}
+
+func subscribe[K comparable](h *Hub, subscribers map[K]map[chan Event]struct{}, key K) (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+
+	h.mu.Lock()
+	if subscribers[key] == nil {
+		subscribers[key] = make(map[chan Event]struct{})
+	}
+	subscribers[key][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := subscribers[key][ch]; ok {
+			delete(subscribers[key], ch)
+			if len(subscribers[key]) == 0 {
+				delete(subscribers, key)
+			}
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// Publish sends the event to every open connection of each user. Slow connections whose
+// buffer is full miss the event rather than blocking the publisher.
+func (h *Hub) Publish(event Event, userIDs ...uint) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, userID := range userIDs {
+		send(h.subscribers[userID], event)
+	}
+}
+
+// PublishTopic sends the event to every connection watching the topic.
+func (h *Hub) PublishTopic(event Event, topic string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	send(h.topics[topic], event)
+}
+
+func send(connections map[chan Event]struct{}, event Event) {
+	for ch := range connections {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+	events, unsubscribe := hub.Subscribe(1)
+	other, unsubscribeOther := hub.Subscribe(2)
+	defer unsubscribeOther()
+
+	hub.Publish(Event{Type: "message.created", Data: "hello"}, 1)
+
+	event := <-events
+	assert.Equal(t, "message.created", event.Type)
+	assert.Len(t, other, 0)
+
+	// Test case: Channel is closed after unsubscribing
+	unsubscribe()
+	_, open := <-events
+	assert.False(t, open)
+
+	// Publishing to a user with no connections is a no-op
+	hub.Publish(Event{Type: "message.created"}, 1)
+}
+
+func TestHubPublishTopic(t *testing.T) {
+	hub := NewHub()
+	watching, unsubscribe := hub.SubscribeTopic("polls:1")
+	user, unsubscribeUser := hub.Subscribe(1)
+	defer unsubscribeUser()
+
+	hub.PublishTopic(Event{Type: "poll.updated", Data: 3}, "polls:1")
+
+	event := <-watching
+	assert.Equal(t, "poll.updated", event.Type)
+	assert.Len(t, user, 0)
+
+	// Test case: Other topics don't receive the event
+	hub.PublishTopic(Event{Type: "poll.updated"}, "polls:2")
+	assert.Len(t, watching, 0)
+
+	// Test case: Channel is closed after unsubscribing
+	unsubscribe()
+	_, open := <-watching
+	assert.False(t, open)
+}
@@ -0,0 +1,207 @@
+package resume
+
+import (
+	"fmt"
+	"siddu-verse-backend/internal/models"
+	"siddu-verse-backend/internal/skills"
+	"strings"
+	"time"
+)
+
+// JSONResume is the subset of the jsonresume.org schema that maps onto a talent profile.
+type JSONResume struct {
+	Basics struct {
+		Name    string `json:"name"`
+		Label   string `json:"label"`
+		Summary string `json:"summary"`
+	} `json:"basics"`
+	Work   []JSONResumeWork  `json:"work"`
+	Skills []JSONResumeSkill `json:"skills"`
+}
+
+// JSONResumeWork is an entry in the "work" section.
+type JSONResumeWork struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position"`
+	StartDate  string   `json:"startDate"`
+	EndDate    string   `json:"endDate"`
+	Summary    string   `json:"summary"`
+	Highlights []string `json:"highlights"`
+}
+
+// JSONResumeSkill is an entry in the "skills" section.
+type JSONResumeSkill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level"`
+	Keywords []string `json:"keywords"`
+}
+
+// SkillChange describes what importing would do to one skill.
+type SkillChange struct {
+	Skill           models.Skill `json:"skill"`
+	FromProficiency string       `json:"fromProficiency,omitempty"`
+}
+
+// ExperienceChange describes what importing would do to one experience entry.
+type ExperienceChange struct {
+	Experience models.Experience  `json:"experience"`
+	Previous   *models.Experience `json:"previous,omitempty"`
+}
+
+// ImportPlan is the diff between a profile and a JSON Resume. It is returned as-is for a
+// dry run and applied otherwise.
+type ImportPlan struct {
+	SkillsAdded          []SkillChange      `json:"skillsAdded"`
+	SkillsUpdated        []SkillChange      `json:"skillsUpdated"`
+	SkillsUnchanged      []string           `json:"skillsUnchanged"`
+	ExperiencesAdded     []ExperienceChange `json:"experiencesAdded"`
+	ExperiencesUpdated   []ExperienceChange `json:"experiencesUpdated"`
+	ExperiencesUnchanged []string           `json:"experiencesUnchanged"`
+	Warnings             []string           `json:"warnings"`
+}
+
+// parseResumeDate accepts the ISO 8601 forms the schema allows: YYYY-MM-DD, YYYY-MM and YYYY.
+func parseResumeDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", value)
+}
+
+func experienceKey(title, company string) string {
+	return strings.ToLower(strings.TrimSpace(title)) + "|" + strings.ToLower(strings.TrimSpace(company))
+}
+
+// workDescription joins a work entry's summary and highlights into one description.
+func workDescription(work JSONResumeWork) string {
+	parts := []string{}
+	if summary := strings.TrimSpace(work.Summary); summary != "" {
+		parts = append(parts, summary)
+	}
+	for _, highlight := range work.Highlights {
+		if highlight = strings.TrimSpace(highlight); highlight != "" {
+			parts = append(parts, "- "+highlight)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// UnknownLevels lists the skill levels in the resume that aren't a recognised proficiency.
+// Skills without a level are fine; they import without one.
+func (r JSONResume) UnknownLevels() []string {
+	var unknown []string
+	for _, imported := range r.Skills {
+		if strings.TrimSpace(imported.Level) == "" {
+			continue
+		}
+		if _, ok := skills.NormalizeProficiency(imported.Level); !ok {
+			unknown = append(unknown, imported.Level)
+		}
+	}
+	return unknown
+}
+
+// PlanImport works out how the resume merges into the profile. Skills match by name and
+// experience entries by title and company; the profile's Skills and Experiences must be loaded.
+// Levels should be checked with UnknownLevels first.
+func PlanImport(profile models.TalentProfile, r JSONResume) ImportPlan {
+	plan := ImportPlan{
+		SkillsAdded:          []SkillChange{},
+		SkillsUpdated:        []SkillChange{},
+		SkillsUnchanged:      []string{},
+		ExperiencesAdded:     []ExperienceChange{},
+		ExperiencesUpdated:   []ExperienceChange{},
+		ExperiencesUnchanged: []string{},
+		Warnings:             []string{},
+	}
+
+	existingSkills := make(map[string]models.Skill, len(profile.Skills))
+	for _, skill := range profile.Skills {
+		existingSkills[strings.ToLower(strings.TrimSpace(skill.Name))] = skill
+	}
+	seenSkills := map[string]bool{}
+	for _, imported := range r.Skills {
+		name := strings.TrimSpace(imported.Name)
+		key := strings.ToLower(name)
+		if name == "" || seenSkills[key] {
+			continue
+		}
+		seenSkills[key] = true
+
+		proficiency, _ := skills.NormalizeProficiency(imported.Level)
+		existing, ok := existingSkills[key]
+		switch {
+		case !ok:
+			plan.SkillsAdded = append(plan.SkillsAdded, SkillChange{
+				Skill: models.Skill{TalentProfileID: profile.ID, Name: name, Proficiency: proficiency},
+			})
+		case proficiency != "" && proficiency != existing.Proficiency:
+			updated := existing
+			updated.Proficiency = proficiency
+			plan.SkillsUpdated = append(plan.SkillsUpdated, SkillChange{Skill: updated, FromProficiency: existing.Proficiency})
+		default:
+			plan.SkillsUnchanged = append(plan.SkillsUnchanged, existing.Name)
+		}
+	}
+
+	experiences := make(map[string]models.Experience, len(profile.Experiences))
+	for _, experience := range profile.Experiences {
+		experiences[experienceKey(experience.Title, experience.CompanyName)] = experience
+	}
+	for i, work := range r.Work {
+		if strings.TrimSpace(work.Position) == "" {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("work[%d]: skipped, position is required", i))
+			continue
+		}
+
+		startDate, err := parseResumeDate(work.StartDate)
+		if err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("work[%d]: skipped, %v", i, err))
+			continue
+		}
+		var endDate *time.Time
+		if work.EndDate != "" {
+			parsed, err := parseResumeDate(work.EndDate)
+			if err != nil {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("work[%d]: end date ignored, %v", i, err))
+			} else {
+				endDate = &parsed
+			}
+		}
+
+		incoming := models.Experience{
+			TalentProfileID: profile.ID,
+			Title:           strings.TrimSpace(work.Position),
+			CompanyName:     strings.TrimSpace(work.Name),
+			StartDate:       startDate,
+			EndDate:         endDate,
+			Description:     workDescription(work),
+		}
+
+		existing, ok := experiences[experienceKey(incoming.Title, incoming.CompanyName)]
+		if !ok {
+			plan.ExperiencesAdded = append(plan.ExperiencesAdded, ExperienceChange{Experience: incoming})
+			continue
+		}
+
+		sameEnd := (existing.EndDate == nil && endDate == nil) ||
+			(existing.EndDate != nil && endDate != nil && existing.EndDate.Equal(*endDate))
+		if existing.StartDate.Equal(startDate) && sameEnd && existing.Description == incoming.Description {
+			plan.ExperiencesUnchanged = append(plan.ExperiencesUnchanged, existing.Title)
+			continue
+		}
+
+		previous := existing
+		updated := existing
+		updated.StartDate = incoming.StartDate
+		updated.EndDate = incoming.EndDate
+		if incoming.Description != "" {
+			updated.Description = incoming.Description
+		}
+		plan.ExperiencesUpdated = append(plan.ExperiencesUpdated, ExperienceChange{Experience: updated, Previous: &previous})
+	}
+
+	return plan
+}
@@ -0,0 +1,299 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"siddu-verse-backend/internal/models"
+	"sort"
+	"strings"
+)
+
+// A4 in points, and the margin around the text.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	pageMargin = 56.0
+)
+
+// Fonts used by RenderPDF. Both are standard Type 1 fonts every PDF reader has, so nothing
+// is embedded.
+const (
+	fontRegular = "F1" // Helvetica
+	fontBold    = "F2" // Helvetica-Bold
+)
+
+// helveticaWidths and helveticaBoldWidths are the advance widths, in thousandths of the font
+// size, of the printable ASCII characters from the fonts' AFM metrics. Other characters are
+// measured as averageWidth.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+const averageWidth = 556
+
+// winAnsi maps the characters outside Latin-1 that WinAnsiEncoding has onto its bytes.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// encodeText converts text to WinAnsiEncoding, replacing characters the fonts can't show.
+func encodeText(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch b, ok := winAnsi[r]; {
+		case ok:
+			encoded = append(encoded, b)
+		case r == '\t':
+			encoded = append(encoded, ' ')
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+// textWidth is the width in points of WinAnsi-encoded text set in the font at size.
+func textWidth(text []byte, font string, size float64) float64 {
+	widths := &helveticaWidths
+	if font == fontBold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, b := range text {
+		if b >= 0x20 && b < 0x7F {
+			total += widths[b-0x20]
+		} else {
+			total += averageWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfLayout lays text out top to bottom over as many pages as it needs.
+type pdfLayout struct {
+	pages []*bytes.Buffer
+	y     float64 // Baseline of the last line on the current page
+}
+
+func (l *pdfLayout) newPage() {
+	l.pages = append(l.pages, &bytes.Buffer{})
+	l.y = pageHeight - pageMargin
+}
+
+// space moves down by points, starting a new page when that leaves no room for a line.
+func (l *pdfLayout) space(points float64) {
+	l.y -= points
+	if l.y < pageMargin {
+		l.newPage()
+	}
+}
+
+// text writes a paragraph wrapped to the page width, indented by indent points. Gray text
+// is used for secondary details such as dates.
+func (l *pdfLayout) text(text, font string, size, indent float64, gray bool) {
+	maxWidth := pageWidth - 2*pageMargin - indent
+	for _, line := range wrap(encodeText(text), font, size, maxWidth) {
+		l.space(size * 1.35)
+		page := l.pages[len(l.pages)-1]
+		if gray {
+			page.WriteString("0.4 g\n")
+		}
+		fmt.Fprintf(page, "BT /%s %.1f Tf %.2f %.2f Td (", font, size, pageMargin+indent, l.y)
+		page.Write(escapeText(line))
+		page.WriteString(") Tj ET\n")
+		if gray {
+			page.WriteString("0 g\n")
+		}
+	}
+}
+
+// heading starts a section with its title over a rule.
+func (l *pdfLayout) heading(title string) {
+	l.space(14)
+	l.text(strings.ToUpper(title), fontBold, 11, 0, false)
+	l.space(5)
+	fmt.Fprintf(l.pages[len(l.pages)-1], "0.6 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n",
+		pageMargin, l.y+3, pageWidth-pageMargin, l.y+3)
+}
+
+// wrap breaks text into lines no wider than maxWidth, splitting words only when a single
+// word is wider than a line.
+func wrap(text []byte, font string, size, maxWidth float64) [][]byte {
+	var lines [][]byte
+	var line []byte
+	for _, word := range bytes.Fields(text) {
+		candidate := word
+		if len(line) > 0 {
+			candidate = append(append(append([]byte{}, line...), ' '), word...)
+		}
+		if textWidth(candidate, font, size) <= maxWidth {
+			line = candidate
+			continue
+		}
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+		for textWidth(word, font, size) > maxWidth && len(word) > 1 {
+			cut := len(word) - 1
+			for cut > 1 && textWidth(word[:cut], font, size) > maxWidth {
+				cut--
+			}
+			lines = append(lines, word[:cut])
+			word = word[cut:]
+		}
+		line = word
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// escapeText escapes the characters that end or break a PDF string literal.
+func escapeText(text []byte) []byte {
+	var escaped []byte
+	for _, b := range text {
+		if b == '(' || b == ')' || b == '\\' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, b)
+	}
+	return escaped
+}
+
+// RenderPDF writes the profile as a PDF résumé with the same sections as the HTML
+// templates, in a single plain layout. The profile's Skills, Experiences and Portfolio must
+// be loaded.
+func RenderPDF(w io.Writer, profile models.TalentProfile) error {
+	experiences := append([]models.Experience(nil), profile.Experiences...)
+	sort.SliceStable(experiences, func(i, j int) bool {
+		return experiences[i].StartDate.After(experiences[j].StartDate)
+	})
+
+	l := &pdfLayout{}
+	l.newPage()
+	l.text(profile.FullName, fontBold, 22, 0, false)
+	if profile.Headline != "" {
+		l.text(profile.Headline, fontRegular, 12, 0, false)
+	}
+	if profile.Location != "" {
+		l.text(profile.Location, fontRegular, 10, 0, true)
+	}
+
+	if profile.Bio != "" {
+		l.heading("Profile")
+		for _, paragraph := range strings.Split(profile.Bio, "\n") {
+			l.text(paragraph, fontRegular, 10, 0, false)
+		}
+	}
+
+	if len(experiences) > 0 {
+		l.heading("Experience")
+		for i, experience := range experiences {
+			if i > 0 {
+				l.space(6)
+			}
+			title := experience.Title
+			if experience.CompanyName != "" {
+				title += " · " + experience.CompanyName
+			}
+			l.text(title, fontBold, 10, 0, false)
+			end := "Present"
+			if experience.EndDate != nil {
+				end = experience.EndDate.Format("Jan 2006")
+			}
+			l.text(experience.StartDate.Format("Jan 2006")+" – "+end, fontRegular, 9, 0, true)
+			for _, line := range strings.Split(experience.Description, "\n") {
+				if strings.TrimSpace(line) != "" {
+					l.text(line, fontRegular, 10, 0, false)
+				}
+			}
+		}
+	}
+
+	if len(profile.Skills) > 0 {
+		l.heading("Skills")
+		for _, skill := range profile.Skills {
+			entry := "• " + skill.Name
+			if skill.Proficiency != "" {
+				entry += " (" + skill.Proficiency + ")"
+			}
+			l.text(entry, fontRegular, 10, 0, false)
+		}
+	}
+
+	if len(profile.Portfolio) > 0 {
+		l.heading("Portfolio")
+		for _, item := range profile.Portfolio {
+			entry := "• " + item.Title
+			if item.MediaType != "" {
+				entry += " (" + item.MediaType + ")"
+			}
+			if item.Description != "" {
+				entry += " – " + item.Description
+			}
+			l.text(entry, fontRegular, 10, 0, false)
+			if item.MediaURL != "" {
+				l.text(item.MediaURL, fontRegular, 8, 10, true)
+			}
+		}
+	}
+
+	return writePDF(w, l.pages, profile.FullName+" – Résumé")
+}
+
+// writePDF assembles the pages' content streams into a PDF file.
+func writePDF(w io.Writer, pages []*bytes.Buffer, title string) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; each page then takes a page object and a content stream
+	const firstPage = 6
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Title (" + string(escapeText(encodeText(title))) + ") /Producer (Siddu Verse) >>")
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
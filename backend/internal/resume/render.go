@@ -0,0 +1,60 @@
+package resume
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"siddu-verse-backend/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+// DefaultTemplate is used when no template is requested.
+const DefaultTemplate = "classic"
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("Jan 2006") },
+	"endDate": func(t *time.Time) string {
+		if t == nil {
+			return "Present"
+		}
+		return t.Format("Jan 2006")
+	},
+	"lines": func(s string) []string { return strings.Split(s, "\n") },
+}).ParseFS(templateFiles, "templates/*.html"))
+
+// Templates returns the names of the available résumé templates. Files starting with an
+// underscore hold shared partials and aren't templates of their own.
+func Templates() []string {
+	var names []string
+	for _, t := range templates.Templates() {
+		name := strings.TrimSuffix(t.Name(), ".html")
+		if name != t.Name() && !strings.HasPrefix(name, "_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasTemplate reports whether a résumé template with the given name exists.
+func HasTemplate(name string) bool {
+	return !strings.HasPrefix(name, "_") && templates.Lookup(name+".html") != nil
+}
+
+// Render writes the profile as a printable HTML résumé. The profile's Skills, Experiences
+// and Portfolio must be loaded.
+func Render(w io.Writer, templateName string, profile models.TalentProfile) error {
+	// Most recent experience first
+	experiences := append([]models.Experience(nil), profile.Experiences...)
+	sort.SliceStable(experiences, func(i, j int) bool {
+		return experiences[i].StartDate.After(experiences[j].StartDate)
+	})
+	profile.Experiences = experiences
+
+	return templates.ExecuteTemplate(w, templateName+".html", profile)
+}
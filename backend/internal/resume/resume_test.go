@@ -0,0 +1,116 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"siddu-verse-backend/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates(t *testing.T) {
+	assert.Equal(t, []string{"classic", "minimal", "modern"}, Templates())
+	assert.True(t, HasTemplate(DefaultTemplate))
+	assert.False(t, HasTemplate("_sections"))
+}
+
+func TestRender(t *testing.T) {
+	profile := models.TalentProfile{
+		FullName:    "Priya <Sharma>",
+		Headline:    "Actor | Dancer",
+		Skills:      []models.Skill{{Name: "Kathak", Proficiency: "Expert"}},
+		Experiences: []models.Experience{{Title: "Lead", CompanyName: "Red Chillies", StartDate: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, name := range Templates() {
+		var out bytes.Buffer
+		assert.NoError(t, Render(&out, name, profile))
+		assert.Contains(t, out.String(), "Priya &lt;Sharma&gt;")
+		assert.Contains(t, out.String(), "May 2021")
+		assert.Contains(t, out.String(), "Present")
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	profile := models.TalentProfile{
+		Skills: []models.Skill{
+			{Name: "Acting", Proficiency: "Intermediate"},
+			{Name: "Singing", Proficiency: "Beginner"},
+		},
+		Experiences: []models.Experience{
+			{Title: "Lead Actor", CompanyName: "Yash Raj Films", StartDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	var r JSONResume
+	r.Skills = []JSONResumeSkill{
+		{Name: "acting", Level: "Master"},
+		{Name: "Singing", Level: "beginner"},
+		{Name: "Horse Riding", Level: "Advanced"},
+	}
+	r.Work = []JSONResumeWork{
+		{Name: "Yash Raj Films", Position: "Lead Actor", StartDate: "2020-01", EndDate: "2021"},
+		{Name: "Netflix", Position: "Supporting Role", StartDate: "2022-06-15", Highlights: []string{"Season 2"}},
+		{Name: "Nowhere", StartDate: "2019"},
+	}
+
+	plan := PlanImport(profile, r)
+
+	// Test case: Skills merge by name, case-insensitively
+	assert.Len(t, plan.SkillsAdded, 1)
+	assert.Equal(t, "Horse Riding", plan.SkillsAdded[0].Skill.Name)
+	assert.Len(t, plan.SkillsUpdated, 1)
+	assert.Equal(t, "Expert", plan.SkillsUpdated[0].Skill.Proficiency)
+	assert.Equal(t, "Intermediate", plan.SkillsUpdated[0].FromProficiency)
+	assert.Equal(t, []string{"Singing"}, plan.SkillsUnchanged)
+
+	// Test case: Experience merges by title and company
+	assert.Len(t, plan.ExperiencesUpdated, 1)
+	assert.Equal(t, 2021, plan.ExperiencesUpdated[0].Experience.EndDate.Year())
+	assert.Len(t, plan.ExperiencesAdded, 1)
+	assert.Equal(t, "- Season 2", plan.ExperiencesAdded[0].Experience.Description)
+	assert.Len(t, plan.Warnings, 1)
+}
+
+func TestUnknownLevels(t *testing.T) {
+	var r JSONResume
+	r.Skills = []JSONResumeSkill{
+		{Name: "Acting", Level: "Master"},
+		{Name: "Singing"},
+		{Name: "Juggling", Level: "Guru"},
+	}
+
+	// Test case: Only levels that aren't a proficiency are reported; missing levels are fine
+	assert.Equal(t, []string{"Guru"}, r.UnknownLevels())
+}
+
+func TestRenderPDF(t *testing.T) {
+	profile := models.TalentProfile{
+		FullName:    "Priya (Sharma)",
+		Bio:         strings.Repeat("Trained in Kathak and Bharatanatyam. ", 400),
+		Skills:      []models.Skill{{Name: "Kathak", Proficiency: "Expert"}},
+		Experiences: []models.Experience{{Title: "Lead", CompanyName: "Red Chillies", StartDate: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, RenderPDF(&out, profile))
+	pdf := out.String()
+
+	// Test case: The document is a PDF with the name escaped in a string literal
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.Contains(t, pdf, `(Priya \(Sharma\)) Tj`)
+	assert.Contains(t, pdf, "(May 2021 \x96 Present) Tj")
+
+	// Test case: A long bio flows onto more pages
+	assert.NotContains(t, pdf, "/Count 1 ")
+
+	// Test case: startxref points at the cross-reference table
+	var xref int
+	_, err := fmt.Sscanf(pdf[strings.LastIndex(pdf, "startxref\n"):], "startxref\n%d", &xref)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pdf[xref:], "xref\n"))
+}
@@ -0,0 +1,22 @@
+package safehttp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicIP(t *testing.T) {
+	for _, ip := range []string{"93.184.216.34", "2606:4700::1111"} {
+		assert.True(t, PublicIP(net.ParseIP(ip)), ip)
+	}
+
+	// Test case: Internal ranges, including cloud metadata and IPv4-mapped IPv6, are blocked
+	for _, ip := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1",
+		"0.0.0.0", "255.255.255.255", "224.0.0.1", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1", "64:ff9b::a00:1",
+	} {
+		assert.False(t, PublicIP(net.ParseIP(ip)), ip)
+	}
+}
@@ -0,0 +1,42 @@
+package screening
+
+import (
+	"context"
+	"siddu-verse-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DBHistory reads users' earlier pulses, comments and casting calls from the database,
+// including deleted ones so spam can't be hidden by deleting it after posting.
+type DBHistory struct {
+	DB *gorm.DB
+}
+
+func (h DBHistory) AccountCreatedAt(ctx context.Context, userID uint) (time.Time, error) {
+	var user models.User
+	if err := h.DB.WithContext(ctx).Select("id", "created_at").First(&user, userID).Error; err != nil {
+		return time.Time{}, err
+	}
+	return user.CreatedAt, nil
+}
+
+func (h DBHistory) RecentPosts(ctx context.Context, userID uint, since time.Time) ([]Post, error) {
+	var posts []Post
+	for _, source := range []struct{ table, textColumn, authorColumn string }{
+		{"pulses", "content", "user_id"},
+		{"comments", "content", "user_id"},
+		{"casting_calls", "description", "posted_by_user_id"},
+	} {
+		var found []Post
+		if err := h.DB.WithContext(ctx).Table(source.table).
+			Select("? AS content_type, id AS content_id, "+source.textColumn+" AS text, created_at", source.table).
+			Where(source.authorColumn+" = ? AND created_at >= ?", userID, since).
+			Scan(&found).Error; err != nil {
+			return nil, err
+		}
+		posts = append(posts, found...)
+	}
+	return posts, nil
+}
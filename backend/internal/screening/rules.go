@@ -0,0 +1,217 @@
+package screening
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rules configure the built-in screener. They are read from a rules file with one rule per
+// line, each starting with the verdict to give content that trips it:
+//
+//	# Blank lines and lines starting with # are ignored
+//	reject word <word or phrase>        matched on whole words, ignoring case
+//	hold words <file>                   a word list, one word or phrase per line
+//	reject pattern <regexp>             matched against the text, ignoring case
+//	reject domain <domain>              links and media hosted on it or its subdomains
+//	hold links <count> <ratio>          more than count links, or links over ratio of the words
+//	hold repeats <count> <window>       the same text posted count times within window
+//	hold new-account <age> <count> <window>
+//	                                    accounts younger than age posting more than count
+//	                                    times within window
+//
+// Word list paths are relative to the rules file. Durations use Go's format, e.g. 24h.
+type Rules struct {
+	Words      []WordRule
+	Patterns   []PatternRule
+	Domains    []DomainRule
+	Links      *LinkRule
+	Repeats    *RepeatRule
+	NewAccount *NewAccountRule
+}
+
+type WordRule struct {
+	Verdict string
+	Phrase  []string // The phrase's words, lowercased
+}
+
+type PatternRule struct {
+	Verdict string
+	Pattern *regexp.Regexp
+}
+
+type DomainRule struct {
+	Verdict string
+	Domain  string
+}
+
+type LinkRule struct {
+	Verdict  string
+	MaxLinks int
+	MaxRatio float64 // Links per word of the other text, once there is more than one link
+}
+
+type RepeatRule struct {
+	Verdict string
+	Count   int
+	Window  time.Duration
+}
+
+type NewAccountRule struct {
+	Verdict string
+	Age     time.Duration
+	Count   int
+	Window  time.Duration
+}
+
+// DefaultRules are the spam heuristics used when no rules file is configured. They hold
+// content for review rather than rejecting it, and have no word lists.
+func DefaultRules() Rules {
+	return Rules{
+		Links:      &LinkRule{Verdict: Hold, MaxLinks: 5, MaxRatio: 0.3},
+		Repeats:    &RepeatRule{Verdict: Hold, Count: 3, Window: 24 * time.Hour},
+		NewAccount: &NewAccountRule{Verdict: Hold, Age: 24 * time.Hour, Count: 10, Window: time.Hour},
+	}
+}
+
+// LoadRules reads the rules file at path.
+func LoadRules(path string) (Rules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Rules{}, err
+	}
+	defer f.Close()
+	return ParseRules(f, filepath.Dir(path))
+}
+
+// ParseRules reads rules in the rules file format. Word lists are looked up relative to dir.
+func ParseRules(r io.Reader, dir string) (Rules, error) {
+	var rules Rules
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := rules.parseLine(line, dir); err != nil {
+			return Rules{}, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+	return rules, scanner.Err()
+}
+
+func (rules *Rules) parseLine(line, dir string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return fmt.Errorf("expected <verdict> <rule> <arguments>, got %q", line)
+	}
+	verdict, kind, args := fields[0], fields[1], fields[2:]
+	if verdict != Hold && verdict != Reject {
+		return fmt.Errorf("verdict must be hold or reject, got %q", verdict)
+	}
+
+	switch kind {
+	case "word":
+		phrase := tokenize(strings.Join(args, " "))
+		if len(phrase) == 0 {
+			return fmt.Errorf("word has no letters or digits")
+		}
+		rules.Words = append(rules.Words, WordRule{Verdict: verdict, Phrase: phrase})
+	case "words":
+		path := strings.Join(args, " ")
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		words, err := loadWordList(path)
+		if err != nil {
+			return err
+		}
+		for _, phrase := range words {
+			rules.Words = append(rules.Words, WordRule{Verdict: verdict, Phrase: phrase})
+		}
+	case "pattern":
+		// The pattern is the rest of the line, spaces included
+		source := strings.TrimSpace(line[strings.Index(line, "pattern")+len("pattern"):])
+		pattern, err := regexp.Compile("(?i)" + source)
+		if err != nil {
+			return err
+		}
+		rules.Patterns = append(rules.Patterns, PatternRule{Verdict: verdict, Pattern: pattern})
+	case "domain":
+		rules.Domains = append(rules.Domains, DomainRule{Verdict: verdict, Domain: strings.ToLower(strings.TrimPrefix(args[0], "."))})
+	case "links":
+		if len(args) != 2 {
+			return fmt.Errorf("links takes <count> <ratio>")
+		}
+		count, err := strconv.Atoi(args[0])
+		if err != nil {
+			return err
+		}
+		ratio, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return err
+		}
+		rules.Links = &LinkRule{Verdict: verdict, MaxLinks: count, MaxRatio: ratio}
+	case "repeats":
+		if len(args) != 2 {
+			return fmt.Errorf("repeats takes <count> <window>")
+		}
+		count, err := strconv.Atoi(args[0])
+		if err != nil {
+			return err
+		}
+		window, err := time.ParseDuration(args[1])
+		if err != nil {
+			return err
+		}
+		rules.Repeats = &RepeatRule{Verdict: verdict, Count: count, Window: window}
+	case "new-account":
+		if len(args) != 3 {
+			return fmt.Errorf("new-account takes <age> <count> <window>")
+		}
+		age, err := time.ParseDuration(args[0])
+		if err != nil {
+			return err
+		}
+		count, err := strconv.Atoi(args[1])
+		if err != nil {
+			return err
+		}
+		window, err := time.ParseDuration(args[2])
+		if err != nil {
+			return err
+		}
+		rules.NewAccount = &NewAccountRule{Verdict: verdict, Age: age, Count: count, Window: window}
+	default:
+		return fmt.Errorf("unknown rule %q", kind)
+	}
+	return nil
+}
+
+// loadWordList reads a word list file: one word or phrase per line, with # comments.
+func loadWordList(path string) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var phrases [][]string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if phrase := tokenize(line); len(phrase) > 0 {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases, scanner.Err()
+}
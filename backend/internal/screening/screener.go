@@ -0,0 +1,171 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Post is something a user submitted earlier, for the spam heuristics.
+type Post struct {
+	ContentType string
+	ContentID   uint
+	Text        string
+	CreatedAt   time.Time
+}
+
+// History looks up what users have submitted before.
+type History interface {
+	// AccountCreatedAt is when the user signed up.
+	AccountCreatedAt(ctx context.Context, userID uint) (time.Time, error)
+	// RecentPosts returns the text the user has submitted since the given time.
+	RecentPosts(ctx context.Context, userID uint, since time.Time) ([]Post, error)
+}
+
+// RuleScreener is the built-in ContentScreener. It matches word lists, patterns and blocked
+// domains, and flags spam by link density, repeated text and how fast new accounts post.
+type RuleScreener struct {
+	Rules   Rules
+	History History          // Optional; the repeat and new-account rules need it
+	Now     func() time.Time // Defaults to time.Now
+}
+
+func (s *RuleScreener) Screen(ctx context.Context, submission Submission) (Result, error) {
+	result := Result{Verdict: Allow}
+	rules := s.Rules
+
+	tokens := tokenize(submission.Text)
+	for _, rule := range rules.Words {
+		if containsPhrase(tokens, rule.Phrase) {
+			result.add(rule.Verdict, "word", strings.Join(rule.Phrase, " "))
+		}
+	}
+	for _, rule := range rules.Patterns {
+		if rule.Pattern.MatchString(submission.Text) {
+			result.add(rule.Verdict, "pattern", rule.Pattern.String())
+		}
+	}
+
+	links := linkPattern.FindAllString(submission.Text, -1)
+	linkHosts := append(hosts(links), hosts(submission.MediaURLs)...)
+	for _, rule := range rules.Domains {
+		for _, host := range linkHosts {
+			if host == rule.Domain || strings.HasSuffix(host, "."+rule.Domain) {
+				result.add(rule.Verdict, "domain", host)
+				break
+			}
+		}
+	}
+	if rule := rules.Links; rule != nil && len(links) > 0 {
+		// The ratio is links per word of the text around them, and sharing a single link
+		// with little or no comment is normal, so it only applies to several links
+		words := len(strings.Fields(linkPattern.ReplaceAllString(submission.Text, " ")))
+		if len(links) > rule.MaxLinks || (len(links) > 1 && float64(len(links)) > rule.MaxRatio*float64(words)) {
+			result.add(rule.Verdict, "links", fmt.Sprintf("%d links in %d words", len(links), words))
+		}
+	}
+
+	if s.History == nil || (rules.Repeats == nil && rules.NewAccount == nil) {
+		return result, nil
+	}
+	now := time.Now()
+	if s.Now != nil {
+		now = s.Now()
+	}
+	var window time.Duration
+	if rules.Repeats != nil {
+		window = rules.Repeats.Window
+	}
+	if rules.NewAccount != nil && rules.NewAccount.Window > window {
+		window = rules.NewAccount.Window
+	}
+	history, err := s.History.RecentPosts(ctx, submission.UserID, now.Add(-window))
+	if err != nil {
+		return result, err
+	}
+	// An edit replaces the stored post, so it isn't counted against itself
+	posts := history[:0]
+	for _, post := range history {
+		if submission.ContentID == 0 || post.ContentType != submission.ContentType || post.ContentID != submission.ContentID {
+			posts = append(posts, post)
+		}
+	}
+
+	if rule := rules.Repeats; rule != nil && len(tokens) > 0 {
+		text := strings.Join(tokens, " ")
+		repeats := 1
+		for _, post := range posts {
+			if !post.CreatedAt.Before(now.Add(-rule.Window)) && strings.Join(tokenize(post.Text), " ") == text {
+				repeats++
+			}
+		}
+		if repeats >= rule.Count {
+			result.add(rule.Verdict, "repeats", fmt.Sprintf("posted %d times within %s", repeats, rule.Window))
+		}
+	}
+
+	if rule := rules.NewAccount; rule != nil {
+		createdAt, err := s.History.AccountCreatedAt(ctx, submission.UserID)
+		if err != nil {
+			return result, err
+		}
+		if now.Sub(createdAt) < rule.Age {
+			recent := 1
+			for _, post := range posts {
+				if !post.CreatedAt.Before(now.Add(-rule.Window)) {
+					recent++
+				}
+			}
+			if recent > rule.Count {
+				result.add(rule.Verdict, "new_account_velocity", fmt.Sprintf("%d posts within %s from an account %s old", recent, rule.Window, now.Sub(createdAt).Round(time.Minute)))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+|\bwww\.[^\s<>"]+`)
+
+// hosts returns the lowercased host of each URL that has one.
+func hosts(urls []string) []string {
+	var found []string
+	for _, raw := range urls {
+		if !strings.Contains(raw, "://") {
+			raw = "http://" + raw
+		}
+		if u, err := url.Parse(raw); err == nil && u.Hostname() != "" {
+			found = append(found, strings.ToLower(u.Hostname()))
+		}
+	}
+	return found
+}
+
+// tokenize splits text into lowercased words of letters and digits, so word rules match
+// however the words are punctuated.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsPhrase reports whether the phrase's words appear consecutively in tokens.
+func containsPhrase(tokens, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, word := range phrase {
+			if tokens[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package screening
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// Verdicts, from least to most severe.
+const (
+	Allow  = "allow"
+	Hold   = "hold"   // Store the content hidden until a moderator reviews it
+	Reject = "reject" // Refuse to store the content
+)
+
+var severity = map[string]int{Allow: 0, Hold: 1, Reject: 2}
+
+// ContentScreener checks user content before it is stored. Handlers depend on this interface
+// so the built-in rules can be swapped for an external moderation service.
+type ContentScreener interface {
+	Screen(ctx context.Context, submission Submission) (Result, error)
+}
+
+// Submission is user content about to be stored.
+type Submission struct {
+	UserID      uint
+	ContentType string // pulses, comments, casting_calls, talent_profiles
+	ContentID   uint   // For edits, the stored content being replaced; 0 for new content
+	Text        string
+	MediaURLs   []string
+}
+
+// Reason is a rule the content tripped.
+type Reason struct {
+	Rule   string `json:"rule"`   // e.g. word, links, repeats
+	Detail string `json:"detail"` // What matched; kept for moderators, not shown to the author
+}
+
+// Result is the screener's verdict on a submission and why.
+type Result struct {
+	Verdict string
+	Reasons []Reason
+}
+
+// add records a tripped rule, raising the verdict to the rule's if it is more severe.
+func (r *Result) add(verdict, rule, detail string) {
+	if severity[verdict] > severity[r.Verdict] {
+		r.Verdict = verdict
+	}
+	r.Reasons = append(r.Reasons, Reason{Rule: rule, Detail: detail})
+}
+
+// Rules lists the distinct rules tripped, which is what the author is told.
+func (r Result) Rules() []string {
+	seen := map[string]bool{}
+	rules := []string{}
+	for _, reason := range r.Reasons {
+		if !seen[reason.Rule] {
+			seen[reason.Rule] = true
+			rules = append(rules, reason.Rule)
+		}
+	}
+	return rules
+}
+
+// NewFromEnv returns the built-in screener using the rules file at SCREENING_RULES, or the
+// default rules when it isn't set. A rules file that can't be loaded stops the server rather
+// than letting content through unscreened.
+func NewFromEnv(db *gorm.DB) ContentScreener {
+	rules := DefaultRules()
+	if path := os.Getenv("SCREENING_RULES"); path != "" {
+		loaded, err := LoadRules(path)
+		if err != nil {
+			log.Fatalf("Could not load screening rules from %s: %v", path, err)
+		}
+		rules = loaded
+	}
+	return &RuleScreener{Rules: rules, History: DBHistory{DB: db}}
+}
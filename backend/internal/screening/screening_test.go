@@ -0,0 +1,145 @@
+package screening
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHistory struct {
+	createdAt time.Time
+	posts     []Post
+}
+
+func (h fakeHistory) AccountCreatedAt(ctx context.Context, userID uint) (time.Time, error) {
+	return h.createdAt, nil
+}
+
+func (h fakeHistory) RecentPosts(ctx context.Context, userID uint, since time.Time) ([]Post, error) {
+	var posts []Post
+	for _, post := range h.posts {
+		if !post.CreatedAt.Before(since) {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+func TestParseRules(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "slurs.txt"), []byte("# one per line\nbad word\nworse\n"), 0o644))
+
+	// Test case: Every rule kind parses, with word lists relative to the rules file
+	rules, err := ParseRules(strings.NewReader(`
+# Word lists
+reject words slurs.txt
+hold word Crypto Giveaway
+reject pattern free\s+money
+reject domain .spam.example
+hold links 3 0.25
+hold repeats 3 24h
+hold new-account 72h 5 1h
+`), dir)
+	assert.NoError(t, err)
+	assert.Len(t, rules.Words, 3)
+	assert.Equal(t, []string{"crypto", "giveaway"}, rules.Words[2].Phrase)
+	assert.Equal(t, Reject, rules.Words[0].Verdict)
+	assert.True(t, rules.Patterns[0].Pattern.MatchString("FREE   money"))
+	assert.Equal(t, "spam.example", rules.Domains[0].Domain)
+	assert.Equal(t, &LinkRule{Verdict: Hold, MaxLinks: 3, MaxRatio: 0.25}, rules.Links)
+	assert.Equal(t, 24*time.Hour, rules.Repeats.Window)
+	assert.Equal(t, &NewAccountRule{Verdict: Hold, Age: 72 * time.Hour, Count: 5, Window: time.Hour}, rules.NewAccount)
+
+	// Test case: Errors name the offending line
+	_, err = ParseRules(strings.NewReader("hold word ok\nallow word fine\n"), dir)
+	assert.ErrorContains(t, err, "line 2")
+	_, err = ParseRules(strings.NewReader("hold links many 0.5"), dir)
+	assert.Error(t, err)
+	_, err = ParseRules(strings.NewReader("hold sparkles 3"), dir)
+	assert.ErrorContains(t, err, "unknown rule")
+}
+
+func TestScreenContent(t *testing.T) {
+	ctx := context.Background()
+	rules, err := ParseRules(strings.NewReader(`
+reject word free money
+hold word giveaway
+reject domain spam.example
+hold links 2 0.3
+`), "")
+	assert.NoError(t, err)
+	screener := &RuleScreener{Rules: rules}
+
+	// Test case: Clean text is allowed
+	result, err := screener.Screen(ctx, Submission{Text: "Loved the premiere last night!"})
+	assert.NoError(t, err)
+	assert.Equal(t, Allow, result.Verdict)
+	assert.Empty(t, result.Reasons)
+
+	// Test case: Words match whole words regardless of case and punctuation
+	result, _ = screener.Screen(ctx, Submission{Text: "GIVEAWAY!!! Free... money"})
+	assert.Equal(t, Reject, result.Verdict)
+	assert.Equal(t, []string{"word"}, result.Rules())
+	result, _ = screener.Screen(ctx, Submission{Text: "giveaways are fun"})
+	assert.Equal(t, Allow, result.Verdict)
+
+	// Test case: Blocked domains apply to subdomains and media
+	result, _ = screener.Screen(ctx, Submission{Text: "look", MediaURLs: []string{"https://cdn.spam.example/a.jpg"}})
+	assert.Equal(t, Reject, result.Verdict)
+	assert.Equal(t, "domain", result.Reasons[0].Rule)
+
+	// Test case: Mostly links is held
+	result, _ = screener.Screen(ctx, Submission{Text: "see https://a.example www.b.example"})
+	assert.Equal(t, Hold, result.Verdict)
+	assert.Equal(t, []string{"links"}, result.Rules())
+
+	// Test case: Sharing one link, alone or with a short comment, is allowed by default
+	defaults := &RuleScreener{Rules: DefaultRules()}
+	for _, text := range []string{"https://trailers.example/teaser", "Watch https://trailers.example/teaser", "The new trailer is out: www.trailers.example/teaser"} {
+		result, err = defaults.Screen(ctx, Submission{Text: text})
+		assert.NoError(t, err)
+		assert.Equal(t, Allow, result.Verdict, text)
+	}
+}
+
+func TestScreenHistory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rules := DefaultRules()
+
+	// Test case: The same text a third time in a day is held, however it's punctuated
+	history := fakeHistory{createdAt: now.AddDate(-1, 0, 0), posts: []Post{
+		{Text: "Follow me for updates!", CreatedAt: now.Add(-2 * time.Hour)},
+		{Text: "follow me for UPDATES", CreatedAt: now.Add(-time.Hour)},
+		{Text: "Follow me for updates", CreatedAt: now.Add(-48 * time.Hour)},
+	}}
+	screener := &RuleScreener{Rules: rules, History: history, Now: func() time.Time { return now }}
+	result, err := screener.Screen(ctx, Submission{UserID: 1, Text: "Follow me for updates."})
+	assert.NoError(t, err)
+	assert.Equal(t, Hold, result.Verdict)
+	assert.Equal(t, []string{"repeats"}, result.Rules())
+
+	// Test case: Editing one of the repeats doesn't count the post being edited
+	history.posts[1].ContentType, history.posts[1].ContentID = "pulses", 7
+	result, _ = screener.Screen(ctx, Submission{UserID: 1, ContentType: "pulses", ContentID: 7, Text: "Follow me for updates."})
+	assert.Equal(t, Allow, result.Verdict)
+
+	// Test case: New accounts posting quickly are held, established ones aren't
+	var posts []Post
+	for i := 0; i < 10; i++ {
+		posts = append(posts, Post{Text: "post", CreatedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	screener.History = fakeHistory{createdAt: now.Add(-2 * time.Hour), posts: posts}
+	result, _ = screener.Screen(ctx, Submission{UserID: 1, Text: "another one"})
+	assert.Equal(t, Hold, result.Verdict)
+	assert.Equal(t, []string{"new_account_velocity"}, result.Rules())
+
+	screener.History = fakeHistory{createdAt: now.AddDate(0, -1, 0), posts: posts}
+	result, _ = screener.Screen(ctx, Submission{UserID: 1, Text: "another one"})
+	assert.Equal(t, Allow, result.Verdict)
+}
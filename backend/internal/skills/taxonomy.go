@@ -0,0 +1,191 @@
+package skills
+
+import (
+	"errors"
+	"siddu-verse-backend/internal/models"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Proficiencies are the levels a skill can be held at, lowest first.
+var Proficiencies = []string{"Beginner", "Intermediate", "Advanced", "Expert"}
+
+// proficiencyAliases maps common free-text levels onto Proficiencies.
+var proficiencyAliases = map[string]string{
+	"beginner":     "Beginner",
+	"novice":       "Beginner",
+	"basic":        "Beginner",
+	"intermediate": "Intermediate",
+	"competent":    "Intermediate",
+	"advanced":     "Advanced",
+	"proficient":   "Advanced",
+	"expert":       "Expert",
+	"master":       "Expert",
+	"professional": "Expert",
+}
+
+// NormalizeProficiency maps a free-text level onto one of Proficiencies. It reports false
+// for values it doesn't recognise, which are returned trimmed but otherwise unchanged.
+func NormalizeProficiency(level string) (string, bool) {
+	level = strings.TrimSpace(level)
+	if canonical, ok := proficiencyAliases[strings.ToLower(level)]; ok {
+		return canonical, true
+	}
+	return level, false
+}
+
+// ProficiencyRank orders proficiencies from 1 (Beginner) to 4 (Expert), and 0 for anything else.
+func ProficiencyRank(level string) int {
+	canonical, ok := NormalizeProficiency(level)
+	if !ok {
+		return 0
+	}
+	for i, proficiency := range Proficiencies {
+		if proficiency == canonical {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Key is the form skill names and aliases are compared in: lower-case with single spaces.
+func Key(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Resolve finds the taxonomy skill a free-text name refers to, by canonical name or alias.
+// It returns nil when the name isn't in the taxonomy.
+func Resolve(db *gorm.DB, name string) (*models.TaxonomySkill, error) {
+	key := Key(name)
+	if key == "" {
+		return nil, nil
+	}
+
+	var skill models.TaxonomySkill
+	err := db.Where("LOWER(name) = ?", key).
+		Or("id IN (?)", db.Model(&models.SkillAlias{}).Select("taxonomy_skill_id").Where("name = ?", key)).
+		First(&skill).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
+// Taxonomy maps skill keys to the key of the taxonomy skill they resolve to, so "VO" and
+// "Voice Over" compare equal. Load one with LoadTaxonomy; a nil Taxonomy only applies Key.
+type Taxonomy map[string]string
+
+// CanonicalKey is the Key of the taxonomy skill the name resolves to, or of the name itself
+// when it isn't in the taxonomy.
+func (t Taxonomy) CanonicalKey(name string) string {
+	key := Key(name)
+	if canonical, ok := t[key]; ok {
+		return canonical
+	}
+	return key
+}
+
+// LoadTaxonomy resolves each of the names the way Resolve does, in two queries.
+func LoadTaxonomy(db *gorm.DB, names []string) (Taxonomy, error) {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, name := range names {
+		if key := Key(name); key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	taxonomy := Taxonomy{}
+	if len(keys) == 0 {
+		return taxonomy, nil
+	}
+
+	var aliases []struct {
+		Name      string
+		Canonical string
+	}
+	if err := db.Model(&models.SkillAlias{}).Select("skill_aliases.name, taxonomy_skills.name AS canonical").
+		Joins("JOIN taxonomy_skills ON taxonomy_skills.id = skill_aliases.taxonomy_skill_id AND taxonomy_skills.deleted_at IS NULL").
+		Where("skill_aliases.name IN ?", keys).Scan(&aliases).Error; err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		taxonomy[alias.Name] = Key(alias.Canonical)
+	}
+
+	// A canonical name wins over an alias spelled the same way
+	var canonical []models.TaxonomySkill
+	if err := db.Select("name").Where("LOWER(name) IN ?", keys).Find(&canonical).Error; err != nil {
+		return nil, err
+	}
+	for _, skill := range canonical {
+		taxonomy[Key(skill.Name)] = Key(skill.Name)
+	}
+	return taxonomy, nil
+}
+
+// Spellings returns the keys of the names plus every name and alias of the taxonomy skills
+// they resolve to in taxonomy, for finding free text in the database that means the same
+// skills.
+func Spellings(db *gorm.DB, taxonomy Taxonomy, names []string) ([]string, error) {
+	seen := map[string]bool{}
+	spellings := []string{}
+	add := func(key string) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			spellings = append(spellings, key)
+		}
+	}
+	canonical := []string{}
+	for _, name := range names {
+		add(Key(name))
+		if key := taxonomy.CanonicalKey(name); key != "" {
+			add(key)
+			canonical = append(canonical, key)
+		}
+	}
+	if len(canonical) == 0 {
+		return spellings, nil
+	}
+
+	var aliases []string
+	if err := db.Model(&models.SkillAlias{}).
+		Joins("JOIN taxonomy_skills ON taxonomy_skills.id = skill_aliases.taxonomy_skill_id AND taxonomy_skills.deleted_at IS NULL").
+		Where("LOWER(taxonomy_skills.name) IN ?", canonical).
+		Pluck("skill_aliases.name", &aliases).Error; err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		add(Key(alias))
+	}
+	return spellings, nil
+}
+
+// DuplicateSkills picks, for each profile holding the same skill more than once, the entry to
+// keep (highest proficiency, then oldest) and returns the rest for removal.
+func DuplicateSkills(entries []models.Skill) (keep map[uint]models.Skill, remove []models.Skill) {
+	sorted := make([]models.Skill, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := ProficiencyRank(sorted[i].Proficiency), ProficiencyRank(sorted[j].Proficiency)
+		if ri != rj {
+			return ri > rj
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	keep = make(map[uint]models.Skill)
+	for _, entry := range sorted {
+		if _, ok := keep[entry.TalentProfileID]; ok {
+			remove = append(remove, entry)
+			continue
+		}
+		keep[entry.TalentProfileID] = entry
+	}
+	return keep, remove
+}
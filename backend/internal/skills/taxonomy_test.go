@@ -0,0 +1,58 @@
+package skills
+
+import (
+	"siddu-verse-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeProficiency(t *testing.T) {
+	level, ok := NormalizeProficiency(" master ")
+	assert.True(t, ok)
+	assert.Equal(t, "Expert", level)
+
+	// Test case: Unknown levels are reported and passed through trimmed
+	level, ok = NormalizeProficiency(" Legendary ")
+	assert.False(t, ok)
+	assert.Equal(t, "Legendary", level)
+}
+
+func TestProficiencyRank(t *testing.T) {
+	assert.Equal(t, 1, ProficiencyRank("novice"))
+	assert.Equal(t, 4, ProficiencyRank("Expert"))
+	assert.Equal(t, 0, ProficiencyRank(""))
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "stage combat", Key("  Stage   Combat "))
+}
+
+func TestCanonicalKey(t *testing.T) {
+	taxonomy := Taxonomy{"vo": "voice over", "voice over": "voice over"}
+
+	// Test case: Aliases and the canonical name share a key
+	assert.Equal(t, "voice over", taxonomy.CanonicalKey(" VO "))
+	assert.Equal(t, "voice over", taxonomy.CanonicalKey("Voice  Over"))
+
+	// Test case: Names outside the taxonomy keep their own key
+	assert.Equal(t, "puppetry", taxonomy.CanonicalKey("Puppetry"))
+	assert.Equal(t, "puppetry", Taxonomy(nil).CanonicalKey("Puppetry"))
+}
+
+func TestDuplicateSkills(t *testing.T) {
+	entries := []models.Skill{
+		{TalentProfileID: 1, Proficiency: "Beginner"},
+		{TalentProfileID: 1, Proficiency: "Advanced"},
+		{TalentProfileID: 2, Proficiency: "Expert"},
+	}
+	entries[0].ID, entries[1].ID, entries[2].ID = 10, 11, 12
+
+	keep, remove := DuplicateSkills(entries)
+
+	// Test case: The higher proficiency entry is kept
+	assert.Equal(t, uint(11), keep[1].ID)
+	assert.Equal(t, uint(12), keep[2].ID)
+	assert.Len(t, remove, 1)
+	assert.Equal(t, uint(10), remove[0].ID)
+}
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LocalStorage keeps files on the local filesystem. The router serves Dir at URLPath.
+// Multipart uploads collect their parts beside Dir, in Dir + ".parts", until completed.
+type LocalStorage struct {
+	Dir     string
+	URLPath string
+}
+
+// path resolves key inside Dir, refusing keys that would escape it.
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(clean)), nil
+}
+
+// partsDir is where the parts of a multipart upload are kept, refusing IDs that weren't
+// made by CreateMultipart.
+func (s *LocalStorage) partsDir(uploadID string) (string, error) {
+	if _, err := hex.DecodeString(uploadID); err != nil || uploadID == "" {
+		return "", fmt.Errorf("invalid upload ID %q", uploadID)
+	}
+	return filepath.Join(filepath.Clean(s.Dir)+".parts", uploadID), nil
+}
+
+// Put writes the file under Dir.
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.CopyN(file, r, size); err != nil {
+		file.Close()
+		os.Remove(target)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(s.URLPath, "/") + "/" + strings.TrimPrefix(key, "/"), nil
+}
+
+// Delete removes the file from Dir.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Get opens the file in Dir.
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(target)
+}
+
+// CreateMultipart makes a directory for the upload's parts.
+func (s *LocalStorage) CreateMultipart(ctx context.Context, key, contentType string) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	uploadID := hex.EncodeToString(id)
+	dir, _ := s.partsDir(uploadID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+// PutPart writes the part to its own file. The part number doubles as its ETag.
+func (s *LocalStorage) PutPart(ctx context.Context, key, uploadID string, number int, r io.Reader, size int64) (string, error) {
+	dir, err := s.partsDir(uploadID)
+	if err != nil {
+		return "", err
+	}
+	etag := strconv.Itoa(number)
+	file, err := os.Create(filepath.Join(dir, etag))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.CopyN(file, r, size); err != nil {
+		file.Close()
+		return "", err
+	}
+	return etag, file.Close()
+}
+
+// CompleteMultipart concatenates the parts into the file under Dir.
+func (s *LocalStorage) CompleteMultipart(ctx context.Context, key, uploadID string, parts []Part) (string, error) {
+	dir, err := s.partsDir(uploadID)
+	if err != nil {
+		return "", err
+	}
+	target, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	for _, part := range parts {
+		if err := appendPart(file, filepath.Join(dir, strconv.Itoa(part.Number))); err != nil {
+			file.Close()
+			os.Remove(target)
+			return "", err
+		}
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	os.RemoveAll(dir)
+	return strings.TrimSuffix(s.URLPath, "/") + "/" + strings.TrimPrefix(key, "/"), nil
+}
+
+func appendPart(w io.Writer, name string) error {
+	part, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+	_, err = io.Copy(w, part)
+	return err
+}
+
+// AbortMultipart removes the upload's parts.
+func (s *LocalStorage) AbortMultipart(ctx context.Context, key, uploadID string) error {
+	dir, err := s.partsDir(uploadID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,294 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Storage keeps files in an S3-compatible bucket (AWS S3, MinIO, Cloudflare R2, ...),
+// addressed path-style and signed with AWS Signature Version 4.
+type S3Storage struct {
+	Endpoint        string // e.g., "https://s3.us-east-1.amazonaws.com" or "http://localhost:9000"
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PublicURL       string       // Base URL files are served from, e.g. a CDN; defaults to the object URL
+	Client          *http.Client // Defaults to defaultClient
+}
+
+// defaultClient bounds every request so a stalled bucket can't hold a handler or job
+// forever. The overall timeout leaves room to stream a 500MB video over a slow link.
+var defaultClient = &http.Client{
+	Timeout: 10 * time.Minute,
+	Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: time.Minute,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConnsPerHost:   16,
+	},
+}
+
+func (s *S3Storage) endpoint() string {
+	if s.Endpoint != "" {
+		return strings.TrimSuffix(s.Endpoint, "/")
+	}
+	return "https://s3." + s.Region + ".amazonaws.com"
+}
+
+func (s *S3Storage) client() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return defaultClient
+}
+
+// objectURL is the path-style URL of the object stored under key.
+func (s *S3Storage) objectURL(key string) string {
+	return s.endpoint() + "/" + s.Bucket + "/" + uriEncodePath(strings.TrimPrefix(key, "/"))
+}
+
+// Put uploads the file with a PUT Object request.
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), io.LimitReader(r, size))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	if err := s.do(req, http.StatusOK); err != nil {
+		return "", err
+	}
+	return s.fileURL(key), nil
+}
+
+// fileURL is the URL the file stored under key is served from.
+func (s *S3Storage) fileURL(key string) string {
+	if s.PublicURL != "" {
+		return strings.TrimSuffix(s.PublicURL, "/") + "/" + strings.TrimPrefix(key, "/")
+	}
+	return s.objectURL(key)
+}
+
+// Delete removes the object with a DELETE Object request.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+// Get downloads the object with a GET Object request.
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.send(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateMultipart starts the upload with a CreateMultipartUpload request.
+func (s *S3Storage) CreateMultipart(ctx context.Context, key, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.objectURL(key)+"?uploads=", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := s.doXML(req, &result); err != nil {
+		return "", err
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("s3: no upload ID for %s", key)
+	}
+	return result.UploadID, nil
+}
+
+// PutPart uploads the part with an UploadPart request.
+func (s *S3Storage) PutPart(ctx context.Context, key, uploadID string, number int, r io.Reader, size int64) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key)+"?"+query.Encode(), io.LimitReader(r, size))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+
+	resp, err := s.send(req, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// CompleteMultipart joins the parts with a CompleteMultipartUpload request.
+func (s *S3Storage) CompleteMultipart(ctx context.Context, key, uploadID string, parts []Part) (string, error) {
+	type completedPart struct {
+		PartNumber int
+		ETag       string
+	}
+	body := struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{}
+	for _, part := range parts {
+		body.Parts = append(body.Parts, completedPart{PartNumber: part.Number, ETag: part.ETag})
+	}
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{"uploadId": {uploadID}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.objectURL(key)+"?"+query.Encode(), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+
+	var result struct {
+		XMLName xml.Name
+		Code    string
+		Message string
+	}
+	if err := s.doXML(req, &result); err != nil {
+		return "", err
+	}
+	// S3 can report a failed completion in the body of a 200 response
+	if result.XMLName.Local == "Error" {
+		return "", fmt.Errorf("s3: completing %s failed: %s: %s", key, result.Code, result.Message)
+	}
+	return s.fileURL(key), nil
+}
+
+// AbortMultipart discards the upload with an AbortMultipartUpload request.
+func (s *S3Storage) AbortMultipart(ctx context.Context, key, uploadID string) error {
+	query := url.Values{"uploadId": {uploadID}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key)+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *S3Storage) do(req *http.Request, okStatuses ...int) error {
+	resp, err := s.send(req, okStatuses...)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// doXML sends the request and decodes the XML body of its 200 response into v.
+func (s *S3Storage) doXML(req *http.Request, v interface{}) error {
+	resp, err := s.send(req, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("s3: %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return nil
+}
+
+// send signs and sends the request, returning the response when its status is one of
+// okStatuses. The caller closes the body.
+func (s *S3Storage) send(req *http.Request, okStatuses ...int) (*http.Response, error) {
+	s.sign(req, time.Now())
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range okStatuses {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("s3: %s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, body)
+}
+
+// sign adds Signature Version 4 headers. The payload is sent unsigned so large files can be
+// streamed instead of hashed up front.
+func (s *S3Storage) sign(req *http.Request, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	const payloadHash = "UNSIGNED-PAYLOAD"
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncodePath(req.URL.Path),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+	signature := hex.EncodeToString(hmacSHA256(signingKey(s.SecretAccessKey, date, s.Region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func signingKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// uriEncodePath percent-encodes everything except unreserved characters and slashes, as
+// SigV4 requires for object paths.
+func uriEncodePath(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
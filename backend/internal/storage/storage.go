@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+)
+
+// Storage stores uploaded files. Handlers depend on this interface so the backend can be
+// the local filesystem in development and an S3-compatible bucket in production.
+type Storage interface {
+	// Put stores size bytes read from r under key and returns the URL the file is served from.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	// Delete removes the file stored under key. Deleting a missing file is not an error.
+	Delete(ctx context.Context, key string) error
+	// Get opens the file stored under key. The caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// CreateMultipart starts a multipart upload of the file to be stored under key and
+	// returns its ID. Parts are kept by the backend, not the instance, so any instance can
+	// receive the next one.
+	CreateMultipart(ctx context.Context, key, contentType string) (string, error)
+	// PutPart stores size bytes read from r as part number (counting from 1) of the upload
+	// and returns the ETag needed to complete it. Every part but the last must be at least
+	// MinPartSize bytes.
+	PutPart(ctx context.Context, key, uploadID string, number int, r io.Reader, size int64) (string, error)
+	// CompleteMultipart joins the parts, in order, into the file and returns its URL.
+	CompleteMultipart(ctx context.Context, key, uploadID string, parts []Part) (string, error)
+	// AbortMultipart discards the upload and the parts stored so far. Aborting an upload
+	// that no longer exists is not an error.
+	AbortMultipart(ctx context.Context, key, uploadID string) error
+}
+
+// MinPartSize is the smallest part S3 accepts in a multipart upload, except for the last.
+const MinPartSize = 5 << 20
+
+// Part is a stored part of a multipart upload.
+type Part struct {
+	Number int
+	ETag   string
+}
+
+// NewFromEnv returns an S3Storage when STORAGE_DRIVER is "s3", otherwise a LocalStorage
+// writing to UPLOAD_DIR (default "uploads").
+func NewFromEnv() Storage {
+	if os.Getenv("STORAGE_DRIVER") == "s3" {
+		return &S3Storage{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          getEnv("S3_REGION", "us-east-1"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+		}
+	}
+
+	dir := getEnv("UPLOAD_DIR", "uploads")
+	log.Printf("STORAGE_DRIVER not set to s3, storing uploads in %s", dir)
+	return &LocalStorage{Dir: dir, URLPath: "/uploads"}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s := &LocalStorage{Dir: dir, URLPath: "/uploads"}
+
+	url, err := s.Put(context.Background(), "portfolio/1/photo.jpg", strings.NewReader("jpeg"), 4, "image/jpeg")
+	assert.NoError(t, err)
+	assert.Equal(t, "/uploads/portfolio/1/photo.jpg", url)
+
+	data, err := os.ReadFile(filepath.Join(dir, "portfolio", "1", "photo.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+
+	assert.NoError(t, s.Delete(context.Background(), "portfolio/1/photo.jpg"))
+	// Test case: Deleting a missing file is not an error
+	assert.NoError(t, s.Delete(context.Background(), "portfolio/1/photo.jpg"))
+
+	// Test case: Keys cannot escape the upload directory
+	_, err = s.Put(context.Background(), "../secrets", strings.NewReader("x"), 1, "text/plain")
+	assert.Error(t, err)
+}
+
+func TestLocalStorageMultipart(t *testing.T) {
+	dir := t.TempDir()
+	s := &LocalStorage{Dir: filepath.Join(dir, "uploads"), URLPath: "/uploads"}
+	ctx := context.Background()
+
+	uploadID, err := s.CreateMultipart(ctx, "portfolio/1/reel.mp4", "video/mp4")
+	assert.NoError(t, err)
+	first, err := s.PutPart(ctx, "portfolio/1/reel.mp4", uploadID, 1, strings.NewReader("hello "), 6)
+	assert.NoError(t, err)
+	second, err := s.PutPart(ctx, "portfolio/1/reel.mp4", uploadID, 2, strings.NewReader("world"), 5)
+	assert.NoError(t, err)
+
+	url, err := s.CompleteMultipart(ctx, "portfolio/1/reel.mp4", uploadID, []Part{{1, first}, {2, second}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/uploads/portfolio/1/reel.mp4", url)
+
+	file, err := s.Get(ctx, "portfolio/1/reel.mp4")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "hello world", string(data))
+
+	// Test case: Parts are kept outside the served directory and removed once joined
+	_, err = os.Stat(filepath.Join(dir, "uploads.parts", uploadID))
+	assert.True(t, os.IsNotExist(err))
+
+	// Test case: Upload IDs can't point outside the parts directory
+	_, err = s.PutPart(ctx, "portfolio/1/reel.mp4", "../../etc", 1, strings.NewReader("x"), 1)
+	assert.Error(t, err)
+
+	uploadID, err = s.CreateMultipart(ctx, "portfolio/1/other.mp4", "video/mp4")
+	assert.NoError(t, err)
+	assert.NoError(t, s.AbortMultipart(ctx, "portfolio/1/other.mp4", uploadID))
+	_, err = os.Stat(filepath.Join(dir, "uploads.parts", uploadID))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestS3Multipart(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.Method == http.MethodPost && r.URL.Query().Has("uploads"):
+			io.WriteString(w, `<InitiateMultipartUploadResult><UploadId>abc.123</UploadId></InitiateMultipartUploadResult>`)
+		case r.Method == http.MethodPut:
+			w.Header().Set("ETag", `"etag-`+r.URL.Query().Get("partNumber")+`"`)
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "<PartNumber>1</PartNumber><ETag>&#34;etag-1&#34;</ETag>") {
+				io.WriteString(w, `<CompleteMultipartUploadResult><Key>reel.mp4</Key></CompleteMultipartUploadResult>`)
+			} else {
+				// S3 reports some failures in the body of a 200 response
+				io.WriteString(w, `<Error><Code>InvalidPart</Code><Message>bad part</Message></Error>`)
+			}
+		}
+	}))
+	defer server.Close()
+
+	s := &S3Storage{Endpoint: server.URL, Region: "us-east-1", Bucket: "media", AccessKeyID: "AKID", SecretAccessKey: "secret"}
+	ctx := context.Background()
+
+	uploadID, err := s.CreateMultipart(ctx, "reel.mp4", "video/mp4")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc.123", uploadID)
+
+	etag, err := s.PutPart(ctx, "reel.mp4", uploadID, 1, strings.NewReader("data"), 4)
+	assert.NoError(t, err)
+	assert.Equal(t, `"etag-1"`, etag)
+	assert.Equal(t, "PUT /media/reel.mp4?partNumber=1&uploadId=abc.123", requests[1])
+
+	url, err := s.CompleteMultipart(ctx, "reel.mp4", uploadID, []Part{{1, etag}})
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/media/reel.mp4", url)
+
+	_, err = s.CompleteMultipart(ctx, "reel.mp4", uploadID, []Part{{1, "wrong"}})
+	assert.ErrorContains(t, err, "InvalidPart")
+}
+
+func TestSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
+
+func TestS3Sign(t *testing.T) {
+	s := &S3Storage{Endpoint: "http://localhost:9000", Region: "us-east-1", Bucket: "media", AccessKeyID: "AKID", SecretAccessKey: "secret"}
+	req, _ := http.NewRequest(http.MethodPut, s.objectURL("portfolio/1/my photo.jpg"), nil)
+	s.sign(req, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	assert.Equal(t, "/media/portfolio/1/my%20photo.jpg", req.URL.EscapedPath())
+	assert.Equal(t, "20260102T030405Z", req.Header.Get("x-amz-date"))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKID/20260102/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+)
+
+// GenerateToken returns a random hex-encoded token of n bytes, for invitation and unsubscribe links.
+func GenerateToken(n int) (string, error) {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// GetFrontendURL retrieves the base URL of the frontend used to build links in emails.
+func GetFrontendURL() string {
+	url := os.Getenv("FRONTEND_URL")
+	if url == "" {
+		return "http://localhost:3000"
+	}
+	return url
+}
@@ -0,0 +1,116 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// recordSize is the aes128gcm record size. Each message is sent as a single record, and push
+// services accept at most 4096 bytes of body.
+const recordSize = 4096
+
+// headerSize is the aes128gcm header: salt, record size, key ID length and the server's key.
+const headerSize = 16 + 4 + 1 + 65
+
+// MaxPayload is the longest payload that fits in one push message, leaving room for the
+// header, the padding delimiter and the authentication tag.
+const MaxPayload = recordSize - headerSize - 1 - 16
+
+var ErrPayloadTooLarge = errors.New("push payload is too large")
+
+// Encrypt encrypts the payload for the subscription using the aes128gcm content coding
+// (RFC 8188) with the Web Push key derivation (RFC 8291), under a fresh server key and salt.
+func Encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	uaPublic, err := decodeKey(sub.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("decoding subscription key: %w", err)
+	}
+	authSecret, err := decodeKey(sub.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("decoding subscription auth secret: %w", err)
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return encrypt(uaPublic, authSecret, payload, serverKey, salt)
+}
+
+func encrypt(uaPublic, authSecret, payload []byte, serverKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > MaxPayload {
+		return nil, ErrPayloadTooLarge
+	}
+	uaKey, err := ecdh.P256().NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	secret, err := serverKey.ECDH(uaKey)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := serverKey.PublicKey().Bytes()
+
+	// Mix the auth secret into the shared secret, then derive the content key and nonce
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)
+	ikm, err := derive(secret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := derive(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := derive(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	// A single, final record: the payload followed by the 0x02 delimiter and no padding
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 2)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// derive runs HKDF-SHA256 and returns length bytes of output.
+func derive(secret, salt, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeKey decodes a base64url key, with or without padding.
+func decodeKey(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
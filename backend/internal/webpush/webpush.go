@@ -0,0 +1,171 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"siddu-verse-backend/internal/safehttp"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrGone means the push service no longer accepts messages for the subscription, usually
+// because the user revoked permission. The subscription should be deleted.
+var ErrGone = errors.New("push subscription has expired or been unsubscribed")
+
+// Subscription is a browser's push subscription, as returned by PushManager.subscribe().
+type Subscription struct {
+	Endpoint string
+	P256dh   string // The browser's public key, base64url encoded
+	Auth     string // The browser's authentication secret, base64url encoded
+}
+
+// Pusher sends web push messages. Handlers and jobs depend on this interface so delivery can
+// be swapped out.
+type Pusher interface {
+	Push(ctx context.Context, sub Subscription, payload []byte) error
+	// PublicKey is the VAPID key browsers subscribe with, base64url encoded, or "" when push
+	// messages aren't actually sent.
+	PublicKey() string
+}
+
+// LogPusher writes push messages to the log instead of sending them. Used in development.
+type LogPusher struct{}
+
+func (LogPusher) Push(ctx context.Context, sub Subscription, payload []byte) error {
+	log.Printf("webpush: endpoint=%s\n%s", sub.Endpoint, payload)
+	return nil
+}
+
+func (LogPusher) PublicKey() string { return "" }
+
+// VAPIDPusher sends encrypted push messages (RFC 8291) to the subscription's push service,
+// identifying the server with a VAPID token (RFC 8292).
+type VAPIDPusher struct {
+	Key     *ecdsa.PrivateKey
+	Subject string        // Contact for the push service operator, a mailto: or https: URL
+	TTL     time.Duration // How long the push service keeps undelivered messages
+	Client  *http.Client
+}
+
+// NewVAPIDPusher creates a pusher from a base64url encoded P-256 private key, the format
+// produced by common VAPID key generators such as `npx web-push generate-vapid-keys`.
+func NewVAPIDPusher(privateKey, subject string) (*VAPIDPusher, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding VAPID private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing VAPID private key: %w", err)
+	}
+	// The uncompressed public key is 0x04 || X || Y
+	public := key.PublicKey().Bytes()
+	return &VAPIDPusher{
+		Key: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(public[1:33]), Y: new(big.Int).SetBytes(public[33:])},
+			D:         new(big.Int).SetBytes(raw),
+		},
+		Subject: subject,
+		TTL:     24 * time.Hour,
+		Client:  safehttp.NewClient(10 * time.Second),
+	}, nil
+}
+
+func (p *VAPIDPusher) PublicKey() string {
+	public := make([]byte, 65)
+	public[0] = 4
+	p.Key.X.FillBytes(public[1:33])
+	p.Key.Y.FillBytes(public[33:])
+	return base64.RawURLEncoding.EncodeToString(public)
+}
+
+func (p *VAPIDPusher) Push(ctx context.Context, sub Subscription, payload []byte) error {
+	if !ValidEndpoint(sub.Endpoint) {
+		return ErrGone
+	}
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+	token, err := p.token(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "vapid t="+token+", k="+p.PublicKey())
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(p.TTL.Seconds())))
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrGone
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("push service responded %s", resp.Status)
+	}
+	return nil
+}
+
+// ValidEndpoint reports whether a browser's push endpoint can be sent to: push services are
+// always reached over https. The pusher's client also refuses internal addresses.
+func ValidEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && u.Scheme == "https" && u.Host != "" && u.User == nil
+}
+
+// token signs the VAPID JWT for the push service hosting the endpoint.
+func (p *VAPIDPusher) token(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": p.Subject,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(p.Key)
+}
+
+// NewFromEnv returns a VAPIDPusher using VAPID_PRIVATE_KEY and VAPID_SUBJECT when the key is
+// set, otherwise a LogPusher.
+func NewFromEnv() Pusher {
+	privateKey := os.Getenv("VAPID_PRIVATE_KEY")
+	if privateKey == "" {
+		log.Println("VAPID_PRIVATE_KEY not set, push notifications will be logged instead of sent.")
+		return LogPusher{}
+	}
+
+	subject := os.Getenv("VAPID_SUBJECT")
+	if subject == "" {
+		subject = "mailto:no-reply@siddu-verse.com"
+	}
+	pusher, err := NewVAPIDPusher(privateKey, subject)
+	if err != nil {
+		log.Fatalf("Invalid VAPID_PRIVATE_KEY: %v", err)
+	}
+	return pusher
+}